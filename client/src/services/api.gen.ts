@@ -0,0 +1,580 @@
+// Code generated by "go run ./cmd/openapi" in server. DO NOT EDIT.
+
+import { apiService, ApiService } from "./api.service"
+
+export interface AssignmentSlot {
+    assigned_at: string
+    due_at: string
+    peak_man_hours: number
+    pushed_over_budget: number
+}
+
+export interface AssignmentSlots {
+    class_id: string
+    homework_id: string
+    man_hours: number
+    slots: AssignmentSlot[]
+}
+
+export type AuditAction = "auth.login_succeeded" | "auth.login_failed" | "auth.password_reset" | "user.role_changed" | "user.profile_updated" | "user.read" | "grade.changed"
+
+export interface AuditEvent {
+    id: string
+    action: AuditAction
+    actor_id: string | null
+    target_id: string | null
+    metadata: { [key: string]: unknown }
+    created_at: string
+}
+
+export interface ChannelSet {
+    email: boolean
+    in_app: boolean
+}
+
+export interface ChannelSetBody {
+    email: boolean | null
+    in_app: boolean | null
+}
+
+export interface ChannelsBody {
+    reminder: ChannelSetBody | null
+    escalation: ChannelSetBody | null
+}
+
+export interface CheckResult {
+    status: string
+    duration_ms: number
+}
+
+export interface ClassLoad {
+    class_id: string
+    timezone: string
+    budget: number
+    dates: string[]
+    students: StudentLoad[]
+    summary: DaySummary[]
+}
+
+export interface CreateInvitationBody {
+    email: string
+    role: "student" | "teacher"
+    school_num: string
+}
+
+export interface DaySummary {
+    date: string
+    median: number
+    max: number
+    overloaded_students: number
+}
+
+export type DigestFrequency = "none" | "daily" | "weekly"
+
+export interface ErrorBody {
+    code: string
+    status: number
+    message: string
+    details?: { [key: string]: string }
+    request_id?: string
+}
+
+export interface ErrorResponse {
+    error: ErrorBody
+}
+
+export interface GetUploadAvatarSignedURLResponse {
+    url: string
+    form_data: { [key: string]: string }
+}
+
+export interface HomeworkStudent {
+    homework_id: string
+    student_id: string
+    score: number | null
+    submitted_at: string | null
+}
+
+export interface ImportResult {
+    class_id: string
+    dry_run: boolean
+    applied: boolean
+    created: number
+    matched: number
+    invalid: number
+    rows: RowResult[]
+}
+
+export interface InvitationPreview {
+    email: string
+    role: UserRole
+    school_num: string
+    class_name: string
+    expires_at: string
+}
+
+export interface InvitationRegisterBody {
+    first_name: string
+    middle_name?: string
+    last_name?: string
+    romanized_name?: string | null
+    phone: string
+    gender: "male" | "female" | "other" | "prefer_not_to_say"
+    password: string
+}
+
+export type InvitationStatus = "pending" | "accepted" | "revoked" | "expired"
+
+export interface InvitationView {
+    id: string
+    class_id: string
+    school_id: string
+    inviter_id: string
+    email: string
+    role: UserRole
+    school_num: string
+    created_at: string
+    expires_at: string
+    accepted_at: string | null
+    accepted_user_id: string | null
+    revoked_at: string | null
+    status: InvitationStatus
+}
+
+export interface JobInfo {
+    name: string
+    schedule: string
+    next_run: string
+}
+
+export interface JobRun {
+    id: string
+    job_name: string
+    trigger: JobTrigger
+    triggered_by: string | null
+    status: JobRunStatus
+    started_at: string
+    finished_at: string | null
+    error: string | null
+}
+
+export type JobRunStatus = "running" | "succeeded" | "failed"
+
+export type JobTrigger = "schedule" | "manual"
+
+export interface LoginBody {
+    email: string
+    password: string
+}
+
+export interface Notification {
+    id: string
+    user_id: string
+    title: string
+    body: string
+    created_at: string
+    read_at: string | null
+}
+
+export interface NotificationChannels {
+    reminder: ChannelSet
+    escalation: ChannelSet
+}
+
+export interface PreviewAssignmentsBody {
+    assignments: ProposedAssignment[]
+}
+
+export interface ProblemDetails {
+    type: string
+    title: string
+    status: number
+    detail: string
+    instance?: string
+    code: string
+    errors?: { [key: string]: string }
+    request_id?: string
+}
+
+export interface ProposalOutcome {
+    homework_id: string
+    class_id: string
+    pushed_over_budget: number
+    accepted: boolean
+    alternative_due_dates: string[]
+}
+
+export interface ProposedAssignment {
+    homework_id: string
+    class_id: string
+    assigned_at?: string | null
+    due_at: string
+}
+
+export interface PublicUser {
+    id: string
+    role: UserRole
+    first_name: string
+    middle_name: string
+    last_name: string
+    romanized_name: string | null
+    phone: string
+    gender: UserGender
+    email: string
+    avatar_url: string | null
+    school_num: string | null
+    bio: string | null
+    school_id: string | null
+}
+
+export interface ReadinessReport {
+    status: string
+    checks?: { [key: string]: CheckResult }
+}
+
+export interface RegisterBody {
+    role: "student" | "teacher" | "guardian"
+    first_name: string
+    middle_name?: string
+    last_name?: string
+    romanized_name?: string | null
+    phone: string
+    gender: "male" | "female" | "other" | "prefer_not_to_say"
+    password: string
+    school_num?: string | null
+}
+
+export interface ResetPwdBody {
+    reset_pwd_token: string
+    new_password: string
+}
+
+export interface RowResult {
+    line: number
+    email: string
+    status: string
+    user_id: string | null
+    invited: boolean
+    errors?: { [key: string]: string }
+}
+
+export interface SetScoreBody {
+    score?: number | null
+}
+
+export interface StudentLoad {
+    student_id: string
+    name: string
+    budget: number
+    man_hours: number[]
+}
+
+export interface UpdatePreferencesBody {
+    timezone?: string | null
+    locale?: "en" | "th" | null
+    channels?: ChannelsBody | null
+    quiet_hours_start?: string | null
+    quiet_hours_end?: string | null
+    digest_frequency?: "none" | "daily" | "weekly" | null
+}
+
+export interface UpdateUserBody {
+    first_name?: string | null
+    middle_name?: string | null
+    last_name?: string | null
+    romanized_name?: string | null
+    phone?: string | null
+    gender?: "male" | "female" | "other" | "prefer_not_to_say" | null
+    bio?: string | null
+}
+
+export interface UpdateUserRoleBody {
+    role: "student" | "teacher" | "guardian"
+}
+
+export type UserGender = "male" | "female" | "other" | "prefer_not_to_say"
+
+export interface UserPreferences {
+    timezone: string
+    locale: string
+    channels: NotificationChannels
+    quiet_hours_start: string | null
+    quiet_hours_end: string | null
+    digest_frequency: DigestFrequency
+    updated_at: string
+}
+
+export type UserRole = "student" | "teacher" | "guardian" | "admin" | "school_admin"
+
+export interface WhatIf {
+    pushed_over_budget: number
+    proposals: ProposalOutcome[]
+    classes: ClassLoad[]
+}
+
+export class ApiClient {
+    constructor(
+        private readonly apiService: ApiService,
+        private readonly baseUrl: string
+    ) {}
+
+    // Mail a registration link, or a warning when the email is taken
+    async getRegistrationMail(email: string): Promise<null> {
+        return await this.apiService.get<null>(
+            `${this.baseUrl}/api/v1/auth/registration-mail/${encodeURIComponent(email)}`
+        )
+    }
+
+    // Register with the token of a registration link, and sign in
+    async register(registrationToken: string, body: RegisterBody): Promise<{ user: PublicUser }> {
+        return await this.apiService.post<RegisterBody, { user: PublicUser }>(
+            `${this.baseUrl}/api/v1/auth/register/${encodeURIComponent(registrationToken)}`,
+            body
+        )
+    }
+
+    // Register with the token of an invitation, and sign in
+    async registerByInvitation(invitationToken: string, body: InvitationRegisterBody): Promise<{ user: PublicUser }> {
+        return await this.apiService.post<InvitationRegisterBody, { user: PublicUser }>(
+            `${this.baseUrl}/api/v1/auth/invitation-register/${encodeURIComponent(invitationToken)}`,
+            body
+        )
+    }
+
+    // Sign in, setting the access token cookie
+    async login(body: LoginBody): Promise<{ user: PublicUser }> {
+        return await this.apiService.post<LoginBody, { user: PublicUser }>(
+            `${this.baseUrl}/api/v1/auth/login`,
+            body
+        )
+    }
+
+    // Sign out, clearing the access token cookie
+    async logout(): Promise<null> {
+        return await this.apiService.post<undefined, null>(
+            `${this.baseUrl}/api/v1/auth/logout`
+        )
+    }
+
+    // Mail a reset password link
+    async getResetPwdMail(email: string): Promise<null> {
+        return await this.apiService.get<null>(
+            `${this.baseUrl}/api/v1/auth/reset-password-mail/${encodeURIComponent(email)}`
+        )
+    }
+
+    // Set a new password with the token of a reset password link
+    async resetPwd(body: ResetPwdBody): Promise<null> {
+        return await this.apiService.put<ResetPwdBody, null>(
+            `${this.baseUrl}/api/v1/auth/reset-password`,
+            body
+        )
+    }
+
+    // Get the signed in user
+    async getMe(): Promise<{ user: PublicUser }> {
+        return await this.apiService.get<{ user: PublicUser }>(
+            `${this.baseUrl}/api/v1/users/me`
+        )
+    }
+
+    // Get a user
+    async getUserById(id: string): Promise<{ user: PublicUser }> {
+        return await this.apiService.get<{ user: PublicUser }>(
+            `${this.baseUrl}/api/v1/users/${encodeURIComponent(id)}`
+        )
+    }
+
+    // Update the signed in user
+    async updateUser(body: UpdateUserBody): Promise<{ user: PublicUser }> {
+        return await this.apiService.put<UpdateUserBody, { user: PublicUser }>(
+            `${this.baseUrl}/api/v1/users`,
+            body
+        )
+    }
+
+    // Change the role of a user
+    async updateUserRole(id: string, body: UpdateUserRoleBody): Promise<{ user: PublicUser }> {
+        return await this.apiService.put<UpdateUserRoleBody, { user: PublicUser }>(
+            `${this.baseUrl}/api/v1/users/${encodeURIComponent(id)}/role`,
+            body
+        )
+    }
+
+    // Get a signed URL and form to upload an avatar to the storage
+    async getUploadAvatarSignedURL(): Promise<GetUploadAvatarSignedURLResponse> {
+        return await this.apiService.get<GetUploadAvatarSignedURLResponse>(
+            `${this.baseUrl}/api/v1/users/avatar-signed-url`
+        )
+    }
+
+    // Set the uploaded avatar as the avatar of the signed in user
+    async handleAvatarUpload(): Promise<{ avatar_url: string }> {
+        return await this.apiService.post<undefined, { avatar_url: string }>(
+            `${this.baseUrl}/api/v1/users/avatar`
+        )
+    }
+
+    // Get the preferences of the signed in user
+    async getMyPreferences(): Promise<{ preferences: UserPreferences }> {
+        return await this.apiService.get<{ preferences: UserPreferences }>(
+            `${this.baseUrl}/api/v1/users/me/preferences`
+        )
+    }
+
+    // Update the preferences of the signed in user
+    async updateMyPreferences(body: UpdatePreferencesBody): Promise<{ preferences: UserPreferences }> {
+        return await this.apiService.patch<UpdatePreferencesBody, { preferences: UserPreferences }>(
+            `${this.baseUrl}/api/v1/users/me/preferences`,
+            body
+        )
+    }
+
+    // List the in-app notifications of the signed in user
+    async getMyNotifications(): Promise<{ notifications: Notification[] }> {
+        return await this.apiService.get<{ notifications: Notification[] }>(
+            `${this.baseUrl}/api/v1/notifications`
+        )
+    }
+
+    // Mark a notification of the signed in user as read
+    async markNotificationRead(id: string): Promise<{ notification: Notification }> {
+        return await this.apiService.patch<undefined, { notification: Notification }>(
+            `${this.baseUrl}/api/v1/notifications/${encodeURIComponent(id)}/read`
+        )
+    }
+
+    // List the scheduled jobs
+    async getJobs(): Promise<{ jobs: JobInfo[] }> {
+        return await this.apiService.get<{ jobs: JobInfo[] }>(
+            `${this.baseUrl}/api/v1/jobs`
+        )
+    }
+
+    // List the latest job runs
+    async getJobRuns(query?: { job?: string; limit?: number }): Promise<{ job_runs: JobRun[] }> {
+        return await this.apiService.get<{ job_runs: JobRun[] }>(
+            `${this.baseUrl}/api/v1/jobs/runs`,
+            query
+        )
+    }
+
+    // Run a job now
+    async triggerJob(name: string): Promise<{ job_run: JobRun }> {
+        return await this.apiService.post<undefined, { job_run: JobRun }>(
+            `${this.baseUrl}/api/v1/jobs/${encodeURIComponent(name)}/trigger`
+        )
+    }
+
+    // Import the students of a class from a CSV or XLSX file
+    async importRoster(id: string, body: FormData, query?: { dry_run?: boolean }): Promise<{ roster: ImportResult }> {
+        return await this.apiService.post<FormData, { roster: ImportResult }>(
+            `${this.baseUrl}/api/v1/classes/${encodeURIComponent(id)}/roster`,
+            body,
+            query
+        )
+    }
+
+    // Invite a student or teacher to a class
+    async createInvitation(id: string, body: CreateInvitationBody): Promise<{ invitation: InvitationView }> {
+        return await this.apiService.post<CreateInvitationBody, { invitation: InvitationView }>(
+            `${this.baseUrl}/api/v1/classes/${encodeURIComponent(id)}/invitations`,
+            body
+        )
+    }
+
+    // List the invitations to a class
+    async getClassInvitations(id: string, query?: { status?: InvitationStatus }): Promise<{ invitations: InvitationView[] }> {
+        return await this.apiService.get<{ invitations: InvitationView[] }>(
+            `${this.baseUrl}/api/v1/classes/${encodeURIComponent(id)}/invitations`,
+            query
+        )
+    }
+
+    // Revoke a pending invitation
+    async revokeInvitation(id: string): Promise<{ invitation: InvitationView }> {
+        return await this.apiService.delete<undefined, { invitation: InvitationView }>(
+            `${this.baseUrl}/api/v1/invitations/${encodeURIComponent(id)}`
+        )
+    }
+
+    // Preview an invitation before registering with it
+    async getInvitationByToken(invitationToken: string): Promise<{ invitation: InvitationPreview }> {
+        return await this.apiService.get<{ invitation: InvitationPreview }>(
+            `${this.baseUrl}/api/v1/invitations/preview/${encodeURIComponent(invitationToken)}`
+        )
+    }
+
+    // Get the daily man-hours of every student of a class, from every teacher
+    async getClassLoad(id: string, query?: { from?: string; days?: number }): Promise<{ load: ClassLoad }> {
+        return await this.apiService.get<{ load: ClassLoad }>(
+            `${this.baseUrl}/api/v1/classes/${encodeURIComponent(id)}/load`,
+            query
+        )
+    }
+
+    // Propose when to assign a homework to a class and when it is due, lowering the peak load
+    async getAssignmentSlots(id: string, query?: { homework_id?: string; from?: string; days?: number }): Promise<{ assignment_slots: AssignmentSlots }> {
+        return await this.apiService.get<{ assignment_slots: AssignmentSlots }>(
+            `${this.baseUrl}/api/v1/classes/${encodeURIComponent(id)}/load/slots`,
+            query
+        )
+    }
+
+    // Preview the daily man-hours of the students if the assignments were made, saving nothing
+    async previewAssignments(body: PreviewAssignmentsBody): Promise<{ what_if: WhatIf }> {
+        return await this.apiService.post<PreviewAssignmentsBody, { what_if: WhatIf }>(
+            `${this.baseUrl}/api/v1/load/what-if`,
+            body
+        )
+    }
+
+    // Grade the homework of a student
+    async setHomeworkScore(id: string, studentId: string, body: SetScoreBody): Promise<{ grade: HomeworkStudent }> {
+        return await this.apiService.put<SetScoreBody, { grade: HomeworkStudent }>(
+            `${this.baseUrl}/api/v1/homework/${encodeURIComponent(id)}/students/${encodeURIComponent(studentId)}/score`,
+            body
+        )
+    }
+
+    // List the latest audit events
+    async getAuditEvents(query?: { actor_id?: string; target_id?: string; action?: AuditAction; from?: string; to?: string; limit?: number }): Promise<{ audit_events: AuditEvent[] }> {
+        return await this.apiService.get<{ audit_events: AuditEvent[] }>(
+            `${this.baseUrl}/api/v1/audit-events`,
+            query
+        )
+    }
+
+    // Tell the process serves requests
+    async getLiveness(): Promise<{ status: string }> {
+        return await this.apiService.get<{ status: string }>(
+            `${this.baseUrl}/healthz`
+        )
+    }
+
+    // Check the dependencies of the server
+    async getReadiness(): Promise<ReadinessReport> {
+        return await this.apiService.get<ReadinessReport>(
+            `${this.baseUrl}/readyz`
+        )
+    }
+
+    // Browse the API documentation
+    async getDocs(): Promise<string> {
+        return await this.apiService.get<string>(
+            `${this.baseUrl}/api/docs`
+        )
+    }
+
+    // Get this OpenAPI document
+    async getOpenAPIDocument(): Promise<{ [key: string]: unknown }> {
+        return await this.apiService.get<{ [key: string]: unknown }>(
+            `${this.baseUrl}/api/docs/openapi.json`
+        )
+    }
+}
+
+export const apiClient = new ApiClient(
+    apiService,
+    process.env.NEXT_PUBLIC_API_URL ?? ""
+)
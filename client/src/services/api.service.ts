@@ -1,3 +1,5 @@
+import type { ErrorResponse } from "./api.gen"
+
 export class ApiService {
     private timeoutMs = 8000
 
@@ -44,7 +46,11 @@ export class ApiService {
             }
         }
 
-        const headers = new Headers({ "Content-Type": "application/json" })
+        // The browser sets the multipart boundary of a FormData itself
+        const isFormData = options?.body instanceof FormData
+        const headers = new Headers(
+            isFormData ? {} : { "Content-Type": "application/json" }
+        )
 
         try {
             const response = await fetch(fetchUrl, {
@@ -55,7 +61,9 @@ export class ApiService {
                 cache: "no-cache", // Prevent caching
                 body:
                     options?.body !== undefined && method !== "GET"
-                        ? JSON.stringify(options.body)
+                        ? isFormData
+                            ? (options.body as FormData)
+                            : JSON.stringify(options.body)
                         : undefined,
                 signal: controller.signal,
             })
@@ -65,21 +73,22 @@ export class ApiService {
             const parsed = await this.safeParseJson(response)
 
             if (!response.ok) {
-                // Prefer server-provided message if available
+                // Prefer the message of the error envelope if available
                 let errMsg = `HTTP ${response.status}`
-                if (
-                    parsed &&
-                    typeof parsed === "object" &&
-                    (parsed as any).error
-                ) {
-                    errMsg = (parsed as any).error
+                let code: string | undefined
+                let details: Record<string, string> | undefined
+                const envelope = (parsed as ErrorResponse | null)?.error
+                if (envelope && typeof envelope === "object") {
+                    errMsg = envelope.message
+                    code = envelope.code
+                    details = envelope.details
                 } else if (typeof parsed === "string" && parsed.length > 0) {
                     errMsg = parsed
                 }
 
                 console.log(errMsg)
 
-                throw new ApiError(errMsg, response.status)
+                throw new ApiError(errMsg, response.status, code, details)
             }
 
             return parsed as R
@@ -119,6 +128,15 @@ export class ApiService {
         return this.request<T, R>("PUT", url, { body, params, timeoutMs })
     }
 
+    async patch<T, R>(
+        url: string,
+        body?: T,
+        params?: Record<string, any>,
+        timeoutMs?: number
+    ) {
+        return this.request<T, R>("PATCH", url, { body, params, timeoutMs })
+    }
+
     async delete<T, R>(
         url: string,
         body?: T,
@@ -133,11 +151,20 @@ export const apiService = new ApiService()
 
 export class ApiError extends Error {
     status: number
-
-    constructor(message: string, status: number) {
+    code?: string // Stable, e.g. USER_NOT_FOUND
+    details?: Record<string, string> // e.g. a message per invalid field
+
+    constructor(
+        message: string,
+        status: number,
+        code?: string,
+        details?: Record<string, string>
+    ) {
         super(message)
         this.name = "ApiError"
         this.status = status
+        this.code = code
+        this.details = details
     }
 }
 
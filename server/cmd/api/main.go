@@ -1,27 +1,71 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	bootstrapfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/bootstrap"
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
 	authfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/auth"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	digestfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/digest"
+	gradesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/grades"
+	healthfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/health"
+	invitationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/invitations"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
 	libfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/lib"
+	loadfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/load"
 	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	migratefx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/migrate"
+	notificationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/notifications"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	remindersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/reminders"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
 	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
 	"go.uber.org/fx"
 )
 
 func main() {
+	// Subcommands
+	if args := configfx.NewFlagsConfig().Args; len(args) > 0 {
+		if args[0] != "migrate" {
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n", args[0])
+			os.Exit(2)
+		}
+		os.Exit(runMigrate(args[1:]))
+	}
+
 	// Register every module and run with FX
 	fx.New(
 		// Prerequisite
 		configfx.Module,
+		metricsfx.Module,
+		tracingfx.Module,
 		libfx.Module,
+		migratefx.Module,
 
 		// Service
+		authzfx.Module,
+		auditfx.Module,
+		preferencesfx.Module,
 		mailfx.Module,
 		usersfx.Module,
 		authfx.Module,
+		notificationsfx.Module,
+		digestfx.Module,
+		remindersfx.Module,
+		jobsfx.Module,
+		rosterfx.Module,
+		invitationsfx.Module,
+		loadfx.Module,
+		gradesfx.Module,
+		healthfx.Module,
+		openapifx.Module,
 
 		// Middlewares
 		middlewarefx.Module,
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	libfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/lib"
+	migratefx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/migrate"
+	"go.uber.org/fx"
+)
+
+// runMigrate runs the "migrate" subcommand and returns the exit code
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		printMigrateUsage()
+		return 2
+	}
+
+	var migrator *migratefx.Migrator
+	app := fx.New(
+		configfx.Module,
+		libfx.Module,
+		migratefx.Module,
+		fx.Populate(&migrator),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := migrator.RunCommand(context.Background(), args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, migratefx.ErrInvalidCommand) {
+			printMigrateUsage()
+			return 2
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func printMigrateUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: api [-e environment] migrate <command>\n\n"+migratefx.CommandUsage)
+}
@@ -0,0 +1,51 @@
+// openapi generates the TypeScript client of the web client from the OpenAPI
+// document served at /api/docs. Run it from server after changing a route or a
+// request/response struct:
+//
+//	go run ./cmd/openapi [-o path] [-json path]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	bootstrapfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/bootstrap"
+)
+
+func main() {
+	output := flag.String("o", "../client/src/services/api.gen.ts", "Path of the generated TypeScript client")
+	jsonOutput := flag.String("json", "", "Path to also write the OpenAPI document to, if any")
+	flag.Parse()
+
+	if err := run(*output, *jsonOutput); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(output, jsonOutput string) error {
+	document, err := bootstrapfx.NewDocument()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(output, []byte(document.TypeScript()), 0o644); err != nil {
+		return err
+	}
+	fmt.Println("Wrote", output)
+
+	if jsonOutput != "" {
+		data, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(jsonOutput, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("Wrote", jsonOutput)
+	}
+
+	return nil
+}
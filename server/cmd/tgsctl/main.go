@@ -0,0 +1,122 @@
+// tgsctl runs operational tasks against the same database, storage and mail
+// server as the API, e.g. creating the first admin.
+//
+// Usage: tgsctl [-e environment] <command> [flags]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	libfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/lib"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	migratefx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/migrate"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
+	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
+	"go.uber.org/fx"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"create-admin", "Create an admin account", runCreateAdmin},
+	{"reset-password", "Set a new password for a user", runResetPassword},
+	{"deactivate-user", "Prevent a user from logging in", runDeactivateUser},
+	{"import-roster", "Import a CSV roster of students into a class", runImportRoster},
+	{"migrate", "Apply, revert or list the database migrations", runMigrate},
+	{"resend-mail", "Send a mail from the outbox again", runResendMail},
+	{"purge-orphans", "Delete storage objects no longer referenced", runPurgeOrphans},
+}
+
+// errUsage makes the command exit with code 2, after its usage is printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	// Same -e flag as the API
+	args := configfx.NewFlagsConfig().Args
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+	if args[0] == "help" {
+		printUsage()
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		// The operator works across the schools
+		err := cmd.run(tenant.Bypass(context.Background()), args[1:])
+		switch {
+		case err == nil:
+			os.Exit(0)
+		case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+			os.Exit(2)
+		default:
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", args[0])
+	printUsage()
+	os.Exit(2)
+}
+
+// newApp builds the dependency graph shared by every command and populates
+// the targets. The app is never started, so no server or job runs.
+func newApp(targets ...any) error {
+	app := fx.New(
+		configfx.Module,
+		tracingfx.Module,
+		libfx.Module,
+		mailfx.Module,
+		auditfx.Module,
+		usersfx.Module,
+		rosterfx.Module,
+		migratefx.Module,
+		fx.Provide(preferencesfx.NewPreferencesService), // For the mails
+		fx.Provide(jobsfx.NewPendingUploadsCleanupJob),
+		fx.Provide(metricsfx.NewMetrics), // Collected, never served
+
+		fx.Populate(targets...),
+		fx.NopLogger,
+	)
+	return app.Err()
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: tgsctl [-e environment] <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun 'tgsctl <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting
+func newFlagSet(name, usage string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: tgsctl [-e environment] %s %s\n\nFlags:\n", name, usage)
+		flagSet.PrintDefaults()
+	}
+	return flagSet
+}
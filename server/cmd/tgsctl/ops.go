@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	migratefx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/migrate"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
+	"github.com/google/uuid"
+)
+
+func runImportRoster(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("import-roster", "-class <class_id> -file <roster.csv|roster.xlsx> [-dry-run]")
+	classIDStr := flagSet.String("class", "", "ID of the class to enrol the students into (required)")
+	filePath := flagSet.String("file", "", "CSV or XLSX with first_name, last_name, email, school_num, phone and optional gender columns (required)")
+	dryRun := flagSet.Bool("dry-run", false, "Validate and report without writing anything")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	classID, err := uuid.Parse(*classIDStr)
+	if err != nil || *filePath == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := rosterfx.ParseFile(*filePath, file)
+	if err != nil {
+		return err
+	}
+
+	var rosterService rosterfx.RosterServiceInterface
+	if err := newApp(&rosterService); err != nil {
+		return err
+	}
+
+	result, err := rosterService.ImportRoster(ctx, classID, rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range result.Rows {
+		invited := ""
+		if row.Invited {
+			invited = "(invited)"
+		}
+		fmt.Printf("line %d\t%-8s %s %s\n", row.Line, row.Status, row.Email, invited)
+		for field, message := range row.Errors {
+			fmt.Printf("\t\t%s: %s\n", field, message)
+		}
+	}
+	fmt.Printf("\n%d created, %d matched, %d invalid\n", result.Created, result.Matched, result.Invalid)
+
+	switch {
+	case result.Applied:
+		fmt.Println("Roster imported")
+	case result.DryRun:
+		fmt.Println("Dry run, nothing was written")
+	default:
+		return errors.New("roster has invalid rows, nothing was written")
+	}
+
+	return nil
+}
+
+func runMigrate(ctx context.Context, args []string) error {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: tgsctl [-e environment] migrate <command>\n\n"+migratefx.CommandUsage)
+	}
+	if len(args) == 0 {
+		usage()
+		return errUsage
+	}
+
+	var migrator *migratefx.Migrator
+	if err := newApp(&migrator); err != nil {
+		return err
+	}
+
+	err := migrator.RunCommand(ctx, args, os.Stdout)
+	if errors.Is(err, migratefx.ErrInvalidCommand) {
+		usage()
+		return errUsage
+	}
+
+	return err
+}
+
+func runResendMail(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("resend-mail", "-id <mail_id>")
+	mailIDStr := flagSet.String("id", "", "ID of the mail in the outbox (required)")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	mailID, err := uuid.Parse(*mailIDStr)
+	if err != nil {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	var mailService *mailfx.MailService
+	if err := newApp(&mailService); err != nil {
+		return err
+	}
+
+	if err := mailService.ResendMail(ctx, mailID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-sent mail %s\n", mailID)
+	return nil
+}
+
+func runPurgeOrphans(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("purge-orphans", "")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	var cleanupJob *jobsfx.PendingUploadsCleanupJob
+	if err := newApp(&cleanupJob); err != nil {
+		return err
+	}
+
+	count, err := cleanupJob.PurgeOrphans(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted %d orphaned object(s)\n", count)
+	return nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Same rules as RegisterBody, which does not allow admins
+type adminBody struct {
+	Email     string           `binding:"required,email,max=255"`
+	FirstName string           `binding:"required,max=128,name"`
+	LastName  string           `binding:"omitempty,max=128,name"`
+	Phone     string           `binding:"required,e164"`
+	Gender    types.UserGender `binding:"required,oneof='male' 'female' 'other' 'prefer_not_to_say'"`
+}
+
+// Same bounds as RegisterBody
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 64
+)
+
+func runCreateAdmin(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("create-admin", "-email <email> -first-name <name> -phone <e164> [flags]")
+	email := flagSet.String("email", "", "Email of the admin (required)")
+	firstName := flagSet.String("first-name", "", "First name (required)")
+	lastName := flagSet.String("last-name", "", "Last name")
+	phone := flagSet.String("phone", "", "Phone number in e164 format (required)")
+	gender := flagSet.String("gender", string(types.UserGenderPNTS), "male, female, other or prefer_not_to_say")
+	schoolIDStr := flagSet.String("school", "", "ID of a school, to create an admin of that school only instead of a platform admin")
+	passwordStdin := flagSet.Bool("password-stdin", false, "Read the password from stdin instead of generating one")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *email == "" || *firstName == "" || *phone == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	role := types.UserRoleAdmin
+	var schoolID *uuid.UUID
+	if *schoolIDStr != "" {
+		parsed, err := uuid.Parse(*schoolIDStr)
+		if err != nil {
+			flagSet.Usage()
+			return errUsage
+		}
+		role = types.UserRoleSchoolAdmin
+		schoolID = &parsed
+	}
+
+	password, generated, err := readOrGeneratePassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	body := &adminBody{
+		Email:     strings.ToLower(*email),
+		FirstName: common.NormalizeName(*firstName),
+		LastName:  common.NormalizeName(*lastName),
+		Phone:     *phone,
+		Gender:    types.UserGender(*gender),
+	}
+	// The router registers the name tags on the API, which is not started
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := common.RegisterNameValidations(validate); err != nil {
+			return err
+		}
+	}
+	if err := binding.Validator.ValidateStruct(body); err != nil {
+		return err
+	}
+
+	var userService usersfx.UserServiceInterface
+	if err := newApp(&userService); err != nil {
+		return err
+	}
+
+	admin := &models.User{
+		Role:      role,
+		SchoolID:  schoolID,
+		FirstName: body.FirstName,
+		LastName:  body.LastName,
+		Phone:     body.Phone,
+		Gender:    body.Gender,
+		Email:     body.Email,
+		Password:  password,
+	}
+	if err := userService.CreateUser(ctx, admin); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s %s (%s)\n", admin.Role, admin.Email, admin.ID)
+	if generated {
+		fmt.Printf("Password: %s\n", password)
+	}
+
+	return nil
+}
+
+func runResetPassword(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("reset-password", "-email <email> [-password-stdin]")
+	email := flagSet.String("email", "", "Email of the user (required)")
+	passwordStdin := flagSet.Bool("password-stdin", false, "Read the password from stdin instead of generating one")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	password, generated, err := readOrGeneratePassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	var userService usersfx.UserServiceInterface
+	if err := newApp(&userService); err != nil {
+		return err
+	}
+
+	// Fail with "user not found" rather than a database error
+	user, err := userService.GetUserByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if err := userService.UpdateUserPwdByEmail(ctx, user.Email, password); err != nil {
+		return err
+	}
+
+	fmt.Printf("Reset the password of %s\n", user.Email)
+	if generated {
+		fmt.Printf("Password: %s\n", password)
+	}
+
+	return nil
+}
+
+func runDeactivateUser(ctx context.Context, args []string) error {
+	flagSet := newFlagSet("deactivate-user", "-email <email>")
+	email := flagSet.String("email", "", "Email of the user (required)")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	var userService usersfx.UserServiceInterface
+	if err := newApp(&userService); err != nil {
+		return err
+	}
+
+	if err := userService.DeactivateUserByEmail(ctx, *email); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deactivated %s, their current sessions end on their next request\n", *email)
+	return nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// readOrGeneratePassword reads one line from stdin, or generates a password.
+// Passwords are never taken from flags, which end up in the shell history.
+func readOrGeneratePassword(fromStdin bool) (string, bool, error) {
+	if !fromStdin {
+		return rand.Text(), true, nil // 26 base32 characters
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", false, fmt.Errorf("failed reading password: %w", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", false, fmt.Errorf("password must be %d to %d characters long", minPasswordLength, maxPasswordLength)
+	}
+
+	return password, false, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	healthfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/health"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -18,6 +20,7 @@ func registerHooks(
 	router *gin.Engine,
 	logger *zap.Logger,
 	routes Routes,
+	healthService healthfx.HealthServiceInterface,
 ) {
 	srv := &http.Server{
 		Addr:    ":" + strconv.Itoa(appConfig.AppPort),
@@ -45,6 +48,15 @@ func registerHooks(
 			OnStop: func(ctx context.Context) error {
 				logger.Info("Shutting down server...")
 
+				// Fail the readiness first, so the orchestrator stops sending
+				// traffic while the server still serves it
+				healthService.StartDraining()
+				logger.Info("Draining traffic", zap.Duration("delay", appConfig.ShutdownDrainDelay))
+				select {
+				case <-time.After(appConfig.ShutdownDrainDelay):
+				case <-ctx.Done():
+				}
+
 				// Gracefully shutdown the server
 				if err := srv.Shutdown(ctx); err != nil {
 					logger.Error("Failed to stop server", zap.Error(err))
@@ -63,6 +75,6 @@ func registerHooks(
 
 var Module = fx.Module(
 	"bootstrapfx",
-	fx.Provide(NewRoutes),
+	fx.Provide(NewRoutes, NewDocument),
 	fx.Invoke(registerHooks),
 )
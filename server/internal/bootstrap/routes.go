@@ -1,7 +1,19 @@
 package bootstrapfx
 
 import (
+	"slices"
+
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
 	authfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/auth"
+	gradesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/grades"
+	healthfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/health"
+	invitationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/invitations"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	loadfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/load"
+	notificationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/notifications"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
 	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
 	"go.uber.org/fx"
 )
@@ -13,8 +25,18 @@ type Route interface {
 
 type RoutesParams struct {
 	fx.In
-	AuthRoutes  *authfx.AuthRoutes
-	UsersRoutes *usersfx.UsersRoutes
+	AuthRoutes          *authfx.AuthRoutes
+	UsersRoutes         *usersfx.UsersRoutes
+	PreferencesRoutes   *preferencesfx.PreferencesRoutes
+	NotificationsRoutes *notificationsfx.NotificationsRoutes
+	JobsRoutes          *jobsfx.JobsRoutes
+	RosterRoutes        *rosterfx.RosterRoutes
+	InvitationsRoutes   *invitationsfx.InvitationsRoutes
+	LoadRoutes          *loadfx.LoadRoutes
+	GradesRoutes        *gradesfx.GradesRoutes
+	AuditRoutes         *auditfx.AuditRoutes
+	HealthRoutes        *healthfx.HealthRoutes
+	DocsRoutes          *openapifx.DocsRoutes
 }
 
 type Routes []Route
@@ -23,6 +45,16 @@ func NewRoutes(params RoutesParams) Routes {
 	return Routes{
 		params.AuthRoutes,
 		params.UsersRoutes,
+		params.PreferencesRoutes,
+		params.NotificationsRoutes,
+		params.JobsRoutes,
+		params.RosterRoutes,
+		params.InvitationsRoutes,
+		params.LoadRoutes,
+		params.GradesRoutes,
+		params.AuditRoutes,
+		params.HealthRoutes,
+		params.DocsRoutes,
 	}
 }
 
@@ -32,3 +64,25 @@ func (routes Routes) Setup() {
 		route.Setup()
 	}
 }
+
+// Operations describes every route of Routes, for the OpenAPI document
+func Operations() []openapifx.Operation {
+	return slices.Concat(
+		authfx.Operations(),
+		usersfx.Operations(),
+		preferencesfx.Operations(),
+		notificationsfx.Operations(),
+		jobsfx.Operations(),
+		rosterfx.Operations(),
+		invitationsfx.Operations(),
+		loadfx.Operations(),
+		gradesfx.Operations(),
+		auditfx.Operations(),
+		healthfx.Operations(),
+		openapifx.Operations(),
+	)
+}
+
+func NewDocument() (*openapifx.Document, error) {
+	return openapifx.NewDocument("Touch Grass Scheduler API", "1.0.0", Operations())
+}
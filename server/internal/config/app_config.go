@@ -2,6 +2,7 @@ package configfx
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/joho/godotenv"
@@ -22,6 +23,10 @@ type AppConfig struct {
 	DBPort     int    `env:"DB_PORT" envDefault:"5432"`
 	DBSSLMode  string `env:"DB_SSLMODE" envDefault:"disable"`
 
+	// Migration
+	MigrateOnStart     bool `env:"MIGRATE_ON_START" envDefault:"false"`
+	SchemaVersionCheck bool `env:"SCHEMA_VERSION_CHECK" envDefault:"false"` // Refuse to start on mismatch
+
 	// Client
 	ClientURL string `env:"CLIENT_URL" envDefault:"http://localhost:3000"`
 
@@ -29,16 +34,48 @@ type AppConfig struct {
 	AppDomain string `env:"APP_DOMAIN" envDefault:"localhost"`
 	AppPort   int    `env:"APP_PORT" envDefault:"8080"`
 
+	// Health
+	HealthCheckMail    bool          `env:"HEALTH_CHECK_MAIL" envDefault:"false"` // Readiness also dials the mail server
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"` // Not ready before shutting down
+
+	// Metrics
+	MetricsPort int `env:"METRICS_PORT" envDefault:"9090"` // Never expose it publicly
+
+	// Tracing, the OTLP exporter reads the standard OTEL_EXPORTER_OTLP_* variables
+	TracingExporter    string  `env:"TRACING_EXPORTER" envDefault:"none"`  // none or otlp
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"` // Of the traces started here
+
 	// JWT
 	JWTSecret    string `env:"JWT_SECRET,required"`
 	JWTExpiresIn int    `env:"JWT_EXPIRES_IN" envDefault:"24"`
 
+	// Invitation
+	InvitationExpiresIn int `env:"INVITATION_EXPIRES_IN" envDefault:"7"` // Days
+
 	// Mail Service
 	MailHost     string `env:"MAIL_HOST,required"`
 	MailPort     int    `env:"MAIL_PORT,required"`
 	MailUser     string `env:"MAIL_USER,required"`
 	MailPassword string `env:"MAIL_PASSWORD,required"`
 
+	// Mail Sender
+	MailSenderAddress string `env:"MAIL_SENDER_ADDRESS" envDefault:"noreply@touchgrassscheduler.com"`
+	MailSenderName    string `env:"MAIL_SENDER_NAME" envDefault:"Touch-Grass-Scheduler"`
+	MailReplyTo       string `env:"MAIL_REPLY_TO"` // Optional
+
+	// Workload
+	DailyManHoursBudget float64 `env:"DAILY_MAN_HOURS_BUDGET" envDefault:"3"` // Per student
+
+	// Digest
+	DigestDaysAhead int `env:"DIGEST_DAYS_AHEAD" envDefault:"7"`
+	DigestSendHour  int `env:"DIGEST_SEND_HOUR" envDefault:"6"` // 0-23, in the time zone of each user
+
+	// Reminder
+	ReminderOffsets        []time.Duration `env:"REMINDER_OFFSETS" envSeparator:"," envDefault:"24h,2h"` // Before due_at
+	ReminderCheckInterval  time.Duration   `env:"REMINDER_CHECK_INTERVAL" envDefault:"5m"`
+	EscalateGuardiansAfter time.Duration   `env:"ESCALATE_GUARDIANS_AFTER" envDefault:"0s"` // After due_at
+	EscalateTeachersAfter  time.Duration   `env:"ESCALATE_TEACHERS_AFTER" envDefault:"24h"` // After due_at
+
 	// Object Storage
 	StorageEndpoint        string `env:"STORAGE_ENDPOINT,required"`
 	StorageAccessKeyID     string `env:"STORAGE_ACCESS_KEY_ID,required"`
@@ -62,9 +99,22 @@ func NewAppConfig(params AppConfigParams) (*AppConfig, error) {
 		return nil, fmt.Errorf("failed parsing env: %w", err)
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid env: %w", err)
+	}
+
 	return config, nil
 }
 
+// Validate rejects the values that parse but cannot be used
+func (c *AppConfig) Validate() error {
+	if c.DigestSendHour < 0 || c.DigestSendHour > 23 {
+		return fmt.Errorf("DIGEST_SEND_HOUR must be between 0 and 23, got %d", c.DigestSendHour)
+	}
+
+	return nil
+}
+
 func (c *AppConfig) GetDBConfig() string {
 	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
 		c.DBHost, c.DBUser, c.DBPassword, c.DBName, c.DBPort, c.DBSSLMode)
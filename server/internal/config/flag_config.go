@@ -4,13 +4,16 @@ import (
 	"flag"
 	"log"
 	"slices"
+	"sync"
 )
 
 type FlagConfig struct {
 	Environment string
+	Args        []string // Non-flag arguments, e.g. a subcommand
 }
 
-func NewFlagsConfig() *FlagConfig {
+// Flags can only be parsed once per process
+var parseFlags = sync.OnceValue(func() *FlagConfig {
 	flagConfig := &FlagConfig{}
 
 	flag.StringVar(&flagConfig.Environment, "e", "development", "Environment to run in (shorthand)")
@@ -20,8 +23,13 @@ func NewFlagsConfig() *FlagConfig {
 		log.Printf("Warning: Invalid environment '%s', fall back to 'development'", flagConfig.Environment)
 		flagConfig.Environment = "development"
 	}
+	flagConfig.Args = flag.Args()
 
 	return flagConfig
+})
+
+func NewFlagsConfig() *FlagConfig {
+	return parseFlags()
 }
 
 func isValidEnvironment(env string) bool {
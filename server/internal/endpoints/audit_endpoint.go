@@ -0,0 +1,9 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type AuditEndpoint types.BaseStringEnum
+
+const (
+	GetAuditEventsV1 AuditEndpoint = "api/v1/audit-events"
+)
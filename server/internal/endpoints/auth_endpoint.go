@@ -5,10 +5,11 @@ import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
 type AuthEndpoint types.BaseStringEnum
 
 const (
-	GetRegistrationMailV1 AuthEndpoint = "api/v1/auth/registration-mail"
-	RegisterV1            AuthEndpoint = "api/v1/auth/register"
-	LoginV1               AuthEndpoint = "api/v1/auth/login"
-	LogoutV1              AuthEndpoint = "api/v1/auth/logout"
-	GetResetPwdMailV1     AuthEndpoint = "api/v1/auth/reset-password-mail"
-	ResetPwdV1            AuthEndpoint = "api/v1/auth/reset-password"
+	GetRegistrationMailV1  AuthEndpoint = "api/v1/auth/registration-mail"
+	RegisterV1             AuthEndpoint = "api/v1/auth/register"
+	RegisterByInvitationV1 AuthEndpoint = "api/v1/auth/invitation-register" // token is required
+	LoginV1                AuthEndpoint = "api/v1/auth/login"
+	LogoutV1               AuthEndpoint = "api/v1/auth/logout"
+	GetResetPwdMailV1      AuthEndpoint = "api/v1/auth/reset-password-mail"
+	ResetPwdV1             AuthEndpoint = "api/v1/auth/reset-password"
 )
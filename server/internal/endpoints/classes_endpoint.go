@@ -0,0 +1,13 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type ClassesEndpoint types.BaseStringEnum
+
+const (
+	ImportRosterV1        ClassesEndpoint = "api/v1/classes" // id is required
+	CreateInvitationV1    ClassesEndpoint = "api/v1/classes" // id is required
+	GetClassInvitationsV1 ClassesEndpoint = "api/v1/classes" // id is required
+	GetClassLoadV1        ClassesEndpoint = "api/v1/classes" // id is required
+	GetAssignmentSlotsV1  ClassesEndpoint = "api/v1/classes" // id is required
+)
@@ -8,4 +8,6 @@ const (
 	ClientRegistrationVerification ClientEndpoint = "register" // token is required
 	ClientForgotPwd                ClientEndpoint = "forgot-password"
 	ClientResetPwd                 ClientEndpoint = "reset-password" // token is required
+	ClientSetPwd                   ClientEndpoint = "set-password"   // token is required, same API as reset-password
+	ClientInvitation               ClientEndpoint = "invitation"     // token is required
 )
@@ -0,0 +1,10 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type DocsEndpoint types.BaseStringEnum
+
+const (
+	GetDocs            DocsEndpoint = "api/docs"
+	GetOpenAPIDocument DocsEndpoint = "api/docs/openapi.json"
+)
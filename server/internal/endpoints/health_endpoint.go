@@ -0,0 +1,10 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type HealthEndpoint types.BaseStringEnum
+
+const (
+	Liveness  HealthEndpoint = "healthz"
+	Readiness HealthEndpoint = "readyz"
+)
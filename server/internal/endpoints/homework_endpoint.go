@@ -0,0 +1,9 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type HomeworkEndpoint types.BaseStringEnum
+
+const (
+	SetHomeworkScoreV1 HomeworkEndpoint = "api/v1/homework" // id and studentId are required
+)
@@ -0,0 +1,10 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type InvitationsEndpoint types.BaseStringEnum
+
+const (
+	RevokeInvitationV1     InvitationsEndpoint = "api/v1/invitations"         // id is required
+	GetInvitationByTokenV1 InvitationsEndpoint = "api/v1/invitations/preview" // token is required
+)
@@ -0,0 +1,11 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type JobsEndpoint types.BaseStringEnum
+
+const (
+	GetJobsV1    JobsEndpoint = "api/v1/jobs"
+	GetJobRunsV1 JobsEndpoint = "api/v1/jobs/runs"
+	TriggerJobV1 JobsEndpoint = "api/v1/jobs" // name is required
+)
@@ -0,0 +1,9 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type LoadEndpoint types.BaseStringEnum
+
+const (
+	PreviewAssignmentsV1 LoadEndpoint = "api/v1/load/what-if"
+)
@@ -0,0 +1,10 @@
+package endpoints
+
+import "github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+
+type NotificationsEndpoint types.BaseStringEnum
+
+const (
+	GetMyNotificationsV1   NotificationsEndpoint = "api/v1/notifications"
+	MarkNotificationReadV1 NotificationsEndpoint = "api/v1/notifications" // id is required
+)
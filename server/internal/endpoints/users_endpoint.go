@@ -6,8 +6,11 @@ type UsersEndpoint types.BaseStringEnum
 
 const (
 	GetMeV1                    UsersEndpoint = "api/v1/users/me"
+	GetMyPreferencesV1         UsersEndpoint = "api/v1/users/me/preferences"
+	UpdateMyPreferencesV1      UsersEndpoint = "api/v1/users/me/preferences"
 	GetUserByIDV1              UsersEndpoint = "api/v1/users"
 	UpdateUserByIDV1           UsersEndpoint = "api/v1/users"
+	UpdateUserRoleV1           UsersEndpoint = "api/v1/users"
 	GetUploadAvatarSignedURLV1 UsersEndpoint = "api/v1/users/avatar-signed-url"
 	HandleAvatarUploadV1       UsersEndpoint = "api/v1/users/avatar"
 )
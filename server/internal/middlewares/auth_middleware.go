@@ -3,98 +3,125 @@ package middlewarefx
 import (
 	"errors"
 	"fmt"
-	"net/http"
 	"slices"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type AuthMiddlewareParams struct {
 	fx.In
 	AppConfig *configfx.AppConfig
 	Logger    *zap.Logger
+	DB        *gorm.DB
 }
 
 type AuthMiddleware struct {
 	AppConfig *configfx.AppConfig
 	Logger    *zap.Logger
+	DB        *gorm.DB
 }
 
 func NewAuthMiddleware(params AuthMiddlewareParams) *AuthMiddleware {
 	return &AuthMiddleware{
 		AppConfig: params.AppConfig,
 		Logger:    params.Logger,
+		DB:        params.DB,
 	}
 }
 
-func (m *AuthMiddleware) HandlerCoreLogic(ctx *gin.Context) (string, types.UserRole, error) {
+// AccessClaims are the claims of a valid access token
+type AccessClaims struct {
+	UserID   string
+	Role     types.UserRole
+	SchoolID *uuid.UUID // Nil for platform admins and users without a school
+}
+
+func (m *AuthMiddleware) HandlerCoreLogic(ctx *gin.Context) (*AccessClaims, error) {
 	accessTokenString, err := ctx.Cookie("accessToken")
 	if err != nil {
-		return "", "", fmt.Errorf("failed retrieving access token: %w", err)
+		return nil, fmt.Errorf("failed retrieving access token: %w", err)
 	}
 	if accessTokenString == "" {
-		return "", "", errors.New("empty access token")
+		return nil, errors.New("empty access token")
 	}
 
 	accessToken, err := common.ParseJWTToken(accessTokenString, m.AppConfig.JWTSecret)
 	if err != nil {
-		return "", "", fmt.Errorf("failed parsing access token: %w", err)
+		return nil, fmt.Errorf("failed parsing access token: %w", err)
 	}
 
 	if !accessToken.Valid {
-		return "", "", errors.New("invalid access token")
+		return nil, errors.New("invalid access token")
 	}
 
 	// Validate accessToken claims
 	claims, ok := accessToken.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", "", errors.New("invalid or missing claims")
+		return nil, errors.New("invalid or missing claims")
 	}
 
 	// Extract user ID and role from claims
 	userID, ok := claims["user_id"].(string)
 	if !ok {
-		return "", "", errors.New("invalid or missing user_id in claims")
+		return nil, errors.New("invalid or missing user_id in claims")
 	}
 
 	userRole, ok := claims["role"].(string)
 	if !ok {
-		return "", "", errors.New("invalid or missing role in claims")
+		return nil, errors.New("invalid or missing role in claims")
+	}
+
+	// Tokens issued before tenancy have no school_id claim, their user must log in again
+	schoolIDClaim, ok := claims["school_id"]
+	if !ok {
+		return nil, errors.New("missing school_id in claims")
 	}
 
-	return userID, types.UserRole(userRole), nil
+	accessClaims := &AccessClaims{UserID: userID, Role: types.UserRole(userRole)}
+	if schoolIDClaim != nil {
+		schoolIDStr, _ := schoolIDClaim.(string)
+		schoolID, err := uuid.Parse(schoolIDStr)
+		if err != nil {
+			return nil, errors.New("invalid school_id in claims")
+		}
+		accessClaims.SchoolID = &schoolID
+	}
+
+	return accessClaims, nil
 }
 
 func (m *AuthMiddleware) HandlerWithRole(roles ...types.UserRole) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		userID, userRole, err := m.HandlerCoreLogic(ctx)
+		accessClaims, err := m.HandlerCoreLogic(ctx)
 		if err != nil {
 			m.Logger.Debug("Access token validation failed", zap.Error(err))
-			ctx.AbortWithStatusJSON(
-				http.StatusUnauthorized,
-				gin.H{"error": "invalid or missing access token"},
-			)
+			common.RespondError(ctx, common.ErrInvalidAccessToken)
+			return
+		}
+
+		if customErr, ok := m.checkActive(ctx, accessClaims); !ok {
+			common.RespondError(ctx, customErr)
 			return
 		}
 
 		// Check if user has required role
-		if !slices.Contains(roles, userRole) {
-			ctx.AbortWithStatusJSON(
-				http.StatusForbidden,
-				gin.H{"error": "insufficient permissions"},
-			)
+		if !slices.Contains(roles, accessClaims.Role) {
+			common.RespondError(ctx, common.ErrPermissionDenied)
 			return
 		}
 
-		// Set user info in context for later use
-		ctx.Set("user_id", userID)
-		ctx.Set("role", userRole)
+		setAccessClaims(ctx, accessClaims)
 
 		ctx.Next()
 	}
@@ -102,20 +129,82 @@ func (m *AuthMiddleware) HandlerWithRole(roles ...types.UserRole) gin.HandlerFun
 
 func (m *AuthMiddleware) Handler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		userID, userRole, err := m.HandlerCoreLogic(ctx)
+		accessClaims, err := m.HandlerCoreLogic(ctx)
 		if err != nil {
 			m.Logger.Debug("Access token validation failed", zap.Error(err))
-			ctx.AbortWithStatusJSON(
-				http.StatusUnauthorized,
-				gin.H{"error": "invalid or missing access token"},
-			)
+			common.RespondError(ctx, common.ErrInvalidAccessToken)
+			return
+		}
+
+		if customErr, ok := m.checkActive(ctx, accessClaims); !ok {
+			common.RespondError(ctx, customErr)
 			return
 		}
 
-		// Set user info in context for later use
-		ctx.Set("user_id", userID)
-		ctx.Set("role", userRole)
+		setAccessClaims(ctx, accessClaims)
 
 		ctx.Next()
 	}
 }
+
+// ======================== HELPER METHODS ========================
+
+// checkActive rejects the tokens of a deactivated or deleted user, so that a
+// deactivation takes effect on the next request rather than when they expire
+func (m *AuthMiddleware) checkActive(ctx *gin.Context, accessClaims *AccessClaims) (common.CustomError, bool) {
+	reqCtx := ctx.Request.Context()
+	logger := logging.FromContext(reqCtx, m.Logger)
+
+	// Before the request is scoped, any school may hold the user
+	var count int64
+	result := m.DB.WithContext(tenant.Bypass(reqCtx)).Model(&models.User{}).
+		Where("id = ? AND deactivated_at IS NULL", accessClaims.UserID).
+		Count(&count)
+	if result.Error != nil {
+		logger.Error(
+			"User database retrieval failed",
+			zap.String("user_id", accessClaims.UserID),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase, false
+	}
+
+	if count == 0 {
+		logger.Debug(
+			"Access token rejected",
+			zap.String("reason", "user_deactivated"),
+			zap.String("user_id", accessClaims.UserID),
+		)
+		return common.ErrUserDeactivated, false
+	}
+
+	return common.CustomError{}, true
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// setAccessClaims sets the user info in context for later use, and scopes the
+// request context and logger to the user. Platform admins bypass the school scope.
+func setAccessClaims(ctx *gin.Context, accessClaims *AccessClaims) {
+	ctx.Set("user_id", accessClaims.UserID)
+	ctx.Set("role", accessClaims.Role)
+
+	// Add the user to the request scoped logger
+	reqCtx := ctx.Request.Context()
+	if logger := logging.FromContext(reqCtx, nil); logger != nil {
+		logger = logger.With(zap.String("user_id", accessClaims.UserID))
+		ctx.Request = ctx.Request.WithContext(logging.WithLogger(reqCtx, logger))
+	}
+
+	if accessClaims.Role == types.UserRoleAdmin {
+		ctx.Request = ctx.Request.WithContext(tenant.Bypass(ctx.Request.Context()))
+		return
+	}
+
+	schoolID := uuid.Nil
+	if accessClaims.SchoolID != nil {
+		schoolID = *accessClaims.SchoolID
+		ctx.Set("school_id", schoolID.String())
+	}
+	ctx.Request = ctx.Request.WithContext(tenant.WithSchool(ctx.Request.Context(), schoolID))
+}
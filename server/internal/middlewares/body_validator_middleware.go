@@ -2,17 +2,22 @@ package middlewarefx
 
 import (
 	"errors"
-	"fmt"
-	"net/http"
 	"reflect"
-	"strings"
 
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
+// Normalizer is implemented by the request bodies that clean up their valid
+// fields, e.g. names to NFC
+type Normalizer interface {
+	Normalize()
+}
+
 type RequestBodyValidatorParams struct {
 	fx.In
 	Logger *zap.Logger
@@ -40,13 +45,14 @@ func (m *RequestBodyValidator) Handler(structType any) gin.HandlerFunc {
 				zap.String("struct_name", reflect.TypeOf(structType).Name()),
 				zap.Error(err),
 			)
-			ctx.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				gin.H{"error": parseValidationErrors(err)},
-			)
+			common.RespondError(ctx, ValidationErr(err, i18n.FromContext(ctx.Request.Context())))
 			return
 		}
 
+		if normalizer, ok := validateBody.(Normalizer); ok {
+			normalizer.Normalize()
+		}
+
 		// Store the validated body in context for the controller to use
 		ctx.Set("validatedBody", validateBody)
 		ctx.Next()
@@ -55,47 +61,24 @@ func (m *RequestBodyValidator) Handler(structType any) gin.HandlerFunc {
 
 // ======================== HELPER FUNCTIONS ========================
 
-func parseValidationErrors(err error) map[string]string {
-	valErrors := make(map[string]string)
-
+// ValidationErr is ErrValidation with a user-friendly message per invalid
+// field in locale, or ErrMalformedRequestBody when err is not a validation error
+func ValidationErr(err error, locale string) common.CustomError {
 	var validationErrors validator.ValidationErrors
 	if !errors.As(err, &validationErrors) {
-		// If it's not a validation error, return generic message
-		return map[string]string{"general": "Invalid request format"}
+		return common.ErrMalformedRequestBody
 	}
 
+	return common.ErrValidation.WithDetails(ParseValidationErrors(validationErrors, locale))
+}
+
+// ParseValidationErrors maps each invalid field to a user-friendly message
+// in locale
+func ParseValidationErrors(validationErrors validator.ValidationErrors, locale string) map[string]string {
+	valErrors := make(map[string]string)
 	for _, fieldError := range validationErrors {
-		valErrors[fieldError.Field()] = getValidationMessage(fieldError)
+		valErrors[fieldError.Field()] = i18n.ValidationMessage(locale, fieldError)
 	}
 
 	return valErrors
 }
-
-// returns user-friendly error messages
-func getValidationMessage(fe validator.FieldError) string {
-	field := fe.Field()
-	tag := fe.Tag()
-	param := fe.Param()
-
-	switch tag {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return "Please provide a valid email address"
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters long", field, param)
-	case "max":
-		return fmt.Sprintf("%s must not exceed %s characters", field, param)
-	case "alpha":
-		return fmt.Sprintf("%s must contain only letters", field)
-	case "e164":
-		return "Please provide a valid phone number in e164 format"
-	case "number":
-		return fmt.Sprintf("%s must be a valid number", field)
-	case "oneof":
-		values := strings.ReplaceAll(param, " ", ", ")
-		return fmt.Sprintf("%s must be one of: %s", field, values)
-	default:
-		return fmt.Sprintf("%s is invalid", field)
-	}
-}
@@ -0,0 +1,12 @@
+package types
+
+// ActionTokenType is the "typ" claim of an action token, so that a token is
+// only accepted by the flow it was issued for
+type ActionTokenType BaseStringEnum
+
+const (
+	ActionTokenTypeRegistration ActionTokenType = "registration"
+	ActionTokenTypeResetPwd     ActionTokenType = "reset_pwd"
+	ActionTokenTypeInvitation   ActionTokenType = "invitation"
+	ActionTokenTypeSetPwd       ActionTokenType = "set_pwd"
+)
@@ -0,0 +1,14 @@
+package types
+
+// AuditAction is stored as varchar, new actions need no migration
+type AuditAction BaseStringEnum
+
+const (
+	AuditActionLoginSucceeded AuditAction = "auth.login_succeeded"
+	AuditActionLoginFailed    AuditAction = "auth.login_failed"
+	AuditActionPasswordReset  AuditAction = "auth.password_reset"
+	AuditActionRoleChanged    AuditAction = "user.role_changed"
+	AuditActionProfileUpdated AuditAction = "user.profile_updated"
+	AuditActionUserRead       AuditAction = "user.read" // By an admin, of another user
+	AuditActionGradeChanged   AuditAction = "grade.changed"
+)
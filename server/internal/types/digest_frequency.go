@@ -0,0 +1,9 @@
+package types
+
+type DigestFrequency BaseStringEnum
+
+const (
+	DigestFrequencyNone   DigestFrequency = "none"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
@@ -0,0 +1,9 @@
+package types
+
+type JobRunStatus BaseStringEnum
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
@@ -0,0 +1,8 @@
+package types
+
+type JobTrigger BaseStringEnum
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
@@ -0,0 +1,8 @@
+package types
+
+type MailStatus BaseStringEnum
+
+const (
+	MailStatusSent   MailStatus = "sent"
+	MailStatusFailed MailStatus = "failed"
+)
@@ -0,0 +1,8 @@
+package types
+
+type NotificationChannel BaseStringEnum
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
@@ -0,0 +1,10 @@
+package types
+
+// NotificationKind is what a notification is about, users choosing the
+// channels of each kind
+type NotificationKind BaseStringEnum
+
+const (
+	NotificationKindReminder   NotificationKind = "reminder"   // Due soon or overdue, to the student
+	NotificationKindEscalation NotificationKind = "escalation" // Overdue, to the guardians and teachers
+)
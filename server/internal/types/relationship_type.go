@@ -0,0 +1,9 @@
+package types
+
+type RelationshipType BaseStringEnum
+
+const (
+	RelationshipTypeMother RelationshipType = "mother"
+	RelationshipTypeFather RelationshipType = "father"
+	RelationshipTypeOther  RelationshipType = "other"
+)
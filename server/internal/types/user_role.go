@@ -3,8 +3,9 @@ package types
 type UserRole BaseStringEnum
 
 const (
-	UserRoleStudent  UserRole = "student"
-	UserRoleTeacher  UserRole = "teacher"
-	UserRoleGuardian UserRole = "guardian"
-	UserRoleAdmin    UserRole = "admin"
+	UserRoleStudent     UserRole = "student"
+	UserRoleTeacher     UserRole = "teacher"
+	UserRoleGuardian    UserRole = "guardian"
+	UserRoleAdmin       UserRole = "admin" // Platform admin, over every school
+	UserRoleSchoolAdmin UserRole = "school_admin"
 )
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS "pending_uploads";
+DROP TABLE IF EXISTS "assignments";
+DROP TABLE IF EXISTS "homework_students";
+DROP TABLE IF EXISTS "homework_teachers";
+DROP TABLE IF EXISTS "class_students";
+DROP TABLE IF EXISTS "class_teachers";
+DROP TABLE IF EXISTS "student_guardians";
+DROP TABLE IF EXISTS "homework";
+DROP TABLE IF EXISTS "books";
+DROP TABLE IF EXISTS "classes";
+DROP TABLE IF EXISTS "schools";
+DROP TABLE IF EXISTS "users";
+
+DROP TYPE IF EXISTS upload_type;
+DROP TYPE IF EXISTS relationship_type;
+DROP TYPE IF EXISTS role;
+DROP TYPE IF EXISTS gender;
@@ -1,5 +1,15 @@
-CREATE TYPE gender AS ENUM('male', 'female', 'other', 'prefer_not_to_say');
-CREATE TYPE role AS ENUM('student', 'teacher', 'guardian', 'admin');
+-- Baseline of the schema, as created by db/postgres/sqls/000_init.sql before
+-- the migrations. Every statement is a no-op on a database created from it,
+-- which gets the later changes from the next migrations.
+DO $$ BEGIN
+    CREATE TYPE gender AS ENUM('male', 'female', 'other', 'prefer_not_to_say');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE role AS ENUM('student', 'teacher', 'guardian', 'admin');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
 
 -- Entities
 CREATE TABLE IF NOT EXISTS "users" (
@@ -55,7 +65,10 @@ CREATE TABLE IF NOT EXISTS "homework" (
 );  
 
 -- Relationships
-CREATE TYPE relationship_type as ENUM('mother', 'father', 'other');
+DO $$ BEGIN
+    CREATE TYPE relationship_type as ENUM('mother', 'father', 'other');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
 
 CREATE TABLE IF NOT EXISTS "student_guardians" (
     "student_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
@@ -100,7 +113,10 @@ CREATE TABLE IF NOT EXISTS "assignments" (
 );
 
 -- For validating the files that is uploaded to object storage 
-CREATE TYPE upload_type as ENUM('avatar');
+DO $$ BEGIN
+    CREATE TYPE upload_type as ENUM('avatar');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
 
 CREATE TABLE IF NOT EXISTS "pending_uploads" (
     "object_key" VARCHAR(128) NOT NULL,
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "digest_frequency";
+
+DROP TYPE IF EXISTS digest_frequency;
@@ -0,0 +1,6 @@
+DO $$ BEGIN
+    CREATE TYPE digest_frequency AS ENUM('none', 'daily', 'weekly');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "digest_frequency" digest_frequency NOT NULL DEFAULT 'weekly';
@@ -0,0 +1 @@
+ALTER TABLE "homework_students" DROP COLUMN IF EXISTS "submitted_at";
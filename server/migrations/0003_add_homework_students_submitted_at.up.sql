@@ -0,0 +1 @@
+ALTER TABLE "homework_students" ADD COLUMN IF NOT EXISTS "submitted_at" TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1,16 @@
+DO $$ BEGIN
+    CREATE TYPE notification_channel AS ENUM('email', 'in_app');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+    "title" VARCHAR(255) NOT NULL,
+    "body" VARCHAR(2048) NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "read_at" TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "notifications_user_id_created_at_idx"
+    ON "notifications" ("user_id", "created_at" DESC);
@@ -0,0 +1,11 @@
+-- One row per reminder sent, so that a restart never sends the same reminder twice
+CREATE TABLE IF NOT EXISTS "reminder_deliveries" (
+    "homework_id" UUID NOT NULL REFERENCES "homework"("id") ON DELETE CASCADE,
+    "class_id" UUID NOT NULL REFERENCES "classes"("id") ON DELETE CASCADE,
+    "student_id" UUID NOT NULL, -- Nil UUID for reminders about the whole class
+    "recipient_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+    "kind" VARCHAR(32) NOT NULL,
+    "channel" notification_channel NOT NULL,
+    "sent_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("homework_id", "class_id", "student_id", "recipient_id", "kind", "channel")
+);
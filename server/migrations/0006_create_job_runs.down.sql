@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "job_runs";
+
+DROP TYPE IF EXISTS job_run_status;
+DROP TYPE IF EXISTS job_trigger;
@@ -0,0 +1,23 @@
+DO $$ BEGIN
+    CREATE TYPE job_trigger AS ENUM('schedule', 'manual');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE job_run_status AS ENUM('running', 'succeeded', 'failed');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS "job_runs" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "job_name" VARCHAR(64) NOT NULL,
+    "trigger" job_trigger NOT NULL,
+    "triggered_by" UUID DEFAULT NULL REFERENCES "users"("id") ON DELETE SET NULL,
+    "status" job_run_status NOT NULL,
+    "started_at" TIMESTAMPTZ NOT NULL,
+    "finished_at" TIMESTAMPTZ DEFAULT NULL,
+    "error" TEXT DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "job_runs_job_name_started_at_idx"
+    ON "job_runs" ("job_name", "started_at" DESC);
@@ -0,0 +1 @@
+ALTER TABLE "users" ALTER COLUMN "avatar_key" TYPE VARCHAR(128);
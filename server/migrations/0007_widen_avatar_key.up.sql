@@ -0,0 +1,2 @@
+-- Match models.User, object keys may be longer than 128 characters
+ALTER TABLE "users" ALTER COLUMN "avatar_key" TYPE VARCHAR(512);
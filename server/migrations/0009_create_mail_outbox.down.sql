@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "mail_outbox";
+DROP TYPE IF EXISTS mail_status;
@@ -0,0 +1,17 @@
+-- Every mail sent by the server, so that it can be re-sent
+CREATE TYPE mail_status AS ENUM('sent', 'failed');
+
+CREATE TABLE IF NOT EXISTS "mail_outbox" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "recipient" VARCHAR(255) NOT NULL,
+    "subject" VARCHAR(255) NOT NULL,
+    "html_body" TEXT NOT NULL,
+    "text_body" TEXT NOT NULL,
+    "status" mail_status NOT NULL,
+    "error" TEXT DEFAULT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 1,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "sent_at" TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "mail_outbox_created_at_idx" ON "mail_outbox" ("created_at" DESC);
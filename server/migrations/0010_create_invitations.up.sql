@@ -0,0 +1,20 @@
+-- Teacher-created invitations into a class, accepted by registering through a signed link
+CREATE TABLE IF NOT EXISTS "invitations" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "class_id" UUID NOT NULL REFERENCES "classes" ("id") ON DELETE CASCADE,
+    "inviter_id" UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "email" VARCHAR(255) NOT NULL,
+    "role" role NOT NULL CHECK ("role" IN ('student', 'teacher')),
+    "school_num" VARCHAR(16) NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "accepted_at" TIMESTAMPTZ DEFAULT NULL,
+    "accepted_user_id" UUID DEFAULT NULL REFERENCES "users" ("id") ON DELETE SET NULL,
+    "revoked_at" TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "invitations_class_id_idx" ON "invitations" ("class_id", "created_at" DESC);
+
+-- At most one open invitation per email and class
+CREATE UNIQUE INDEX IF NOT EXISTS "invitations_open_email_idx" ON "invitations" ("class_id", LOWER("email"))
+WHERE "accepted_at" IS NULL AND "revoked_at" IS NULL;
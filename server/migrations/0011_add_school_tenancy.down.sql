@@ -0,0 +1,5 @@
+ALTER TABLE "invitations" DROP COLUMN IF EXISTS "school_id";
+DROP INDEX IF EXISTS "users_school_id_idx";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "school_id";
+-- Postgres cannot drop an enum value, 'school_admin' is left in the role type
+-- and such users no longer pass any role check
@@ -0,0 +1,30 @@
+-- Admin of a single school, the 'admin' role stays the platform admin
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'school_admin';
+
+-- NULL for platform admins and users without a school yet
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "school_id" UUID DEFAULT NULL REFERENCES "schools"("id") ON DELETE CASCADE;
+
+UPDATE "users" SET "school_id" = "classes"."school_id"
+FROM "class_students" JOIN "classes" ON "classes"."id" = "class_students"."class_id"
+WHERE "class_students"."student_id" = "users"."id" AND "users"."school_id" IS NULL;
+
+UPDATE "users" SET "school_id" = "classes"."school_id"
+FROM "class_teachers" JOIN "classes" ON "classes"."id" = "class_teachers"."class_id"
+WHERE "class_teachers"."teacher_id" = "users"."id" AND "users"."school_id" IS NULL;
+
+-- Guardians follow their students, after the students are backfilled
+UPDATE "users" SET "school_id" = "students"."school_id"
+FROM "student_guardians" JOIN "users" AS "students" ON "students"."id" = "student_guardians"."student_id"
+WHERE "student_guardians"."guardian_id" = "users"."id"
+    AND "users"."school_id" IS NULL
+    AND "students"."school_id" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "users_school_id_idx" ON "users" ("school_id");
+
+-- Denormalized from the class, so that invitations are scoped like any other table
+ALTER TABLE "invitations" ADD COLUMN IF NOT EXISTS "school_id" UUID REFERENCES "schools"("id") ON DELETE CASCADE;
+
+UPDATE "invitations" SET "school_id" = "classes"."school_id"
+FROM "classes" WHERE "classes"."id" = "invitations"."class_id";
+
+ALTER TABLE "invitations" ALTER COLUMN "school_id" SET NOT NULL;
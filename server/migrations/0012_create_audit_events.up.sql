@@ -0,0 +1,27 @@
+-- Append-only record of security-relevant and grading events
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "action" VARCHAR(64) NOT NULL,
+    -- No foreign keys, events outlive the users they mention
+    "actor_id" UUID DEFAULT NULL,
+    "target_id" UUID DEFAULT NULL,
+    "metadata" JSONB NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "audit_events_created_at_idx" ON "audit_events" ("created_at" DESC);
+CREATE INDEX IF NOT EXISTS "audit_events_actor_id_idx" ON "audit_events" ("actor_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS "audit_events_target_id_idx" ON "audit_events" ("target_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS "audit_events_action_idx" ON "audit_events" ("action", "created_at" DESC);
+
+CREATE OR REPLACE FUNCTION "audit_events_reject_change"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_append_only" BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE FUNCTION "audit_events_reject_change"();
+
+CREATE TRIGGER "audit_events_no_truncate" BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION "audit_events_reject_change"();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "users_romanized_name_idx";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "romanized_name";
@@ -0,0 +1,4 @@
+-- Latin spelling of the name, for searching and sorting names in any script
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "romanized_name" VARCHAR(384) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS "users_romanized_name_idx" ON "users" (lower("romanized_name"));
@@ -0,0 +1,8 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "bio";
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "digest_frequency" digest_frequency NOT NULL DEFAULT 'weekly';
+
+UPDATE "users" SET "digest_frequency" = "user_preferences"."digest_frequency"
+FROM "user_preferences" WHERE "user_preferences"."user_id" = "users"."id";
+
+DROP TABLE IF EXISTS "user_preferences";
@@ -0,0 +1,21 @@
+-- Users without a row have the default preferences, see models.DefaultUserPreferences
+CREATE TABLE IF NOT EXISTS "user_preferences" (
+    "user_id" UUID PRIMARY KEY REFERENCES "users"("id") ON DELETE CASCADE,
+    "timezone" VARCHAR(64) NOT NULL DEFAULT 'Asia/Bangkok',
+    "locale" VARCHAR(8) NOT NULL DEFAULT 'en',
+    "channels" JSONB NOT NULL DEFAULT '{"reminder": {"email": true, "in_app": true}, "escalation": {"email": true, "in_app": true}}',
+    "quiet_hours_start" VARCHAR(5) DEFAULT NULL,
+    "quiet_hours_end" VARCHAR(5) DEFAULT NULL,
+    "digest_frequency" digest_frequency NOT NULL DEFAULT 'weekly',
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (("quiet_hours_start" IS NULL) = ("quiet_hours_end" IS NULL))
+);
+
+-- The digest frequency moves to the preferences, kept for those who changed it
+INSERT INTO "user_preferences" ("user_id", "digest_frequency")
+SELECT "id", "digest_frequency" FROM "users" WHERE "digest_frequency" <> 'weekly'
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "digest_frequency";
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "bio" VARCHAR(500) DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- IANA time zone of the school, in which a homework due on a date is due at
+-- the end of that day
+ALTER TABLE "schools" ADD COLUMN IF NOT EXISTS "timezone" VARCHAR(64) NOT NULL DEFAULT 'Asia/Bangkok';
@@ -0,0 +1,8 @@
+-- Days without school besides the weekends, on which no homework is assigned
+-- nor due
+CREATE TABLE IF NOT EXISTS "school_holidays" (
+    "school_id" UUID NOT NULL REFERENCES "schools"("id") ON DELETE CASCADE,
+    "date" DATE NOT NULL,
+    "name" VARCHAR(128) NOT NULL,
+    PRIMARY KEY ("school_id", "date")
+);
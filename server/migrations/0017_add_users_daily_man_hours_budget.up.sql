@@ -0,0 +1,4 @@
+-- Daily man-hours budget of a student, e.g. an accommodation, the
+-- DAILY_MAN_HOURS_BUDGET of the server when NULL
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "daily_man_hours_budget" NUMERIC(4, 2) DEFAULT NULL
+    CHECK ("daily_man_hours_budget" > 0);
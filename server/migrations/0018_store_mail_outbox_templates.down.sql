@@ -0,0 +1,6 @@
+ALTER TABLE "mail_outbox" ADD COLUMN IF NOT EXISTS "html_body" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "mail_outbox" ADD COLUMN IF NOT EXISTS "text_body" TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE "mail_outbox" DROP COLUMN IF EXISTS "data";
+ALTER TABLE "mail_outbox" DROP COLUMN IF EXISTS "template";
+ALTER TABLE "mail_outbox" DROP COLUMN IF EXISTS "user_id";
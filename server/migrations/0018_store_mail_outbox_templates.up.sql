@@ -0,0 +1,9 @@
+-- Mails are rendered again from their template and data on resend, so that
+-- the one-time links of the bodies are never stored
+ALTER TABLE "mail_outbox" ADD COLUMN IF NOT EXISTS "user_id" UUID DEFAULT NULL REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "mail_outbox" ADD COLUMN IF NOT EXISTS "template" VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE "mail_outbox" ALTER COLUMN "template" DROP DEFAULT;
+ALTER TABLE "mail_outbox" ADD COLUMN IF NOT EXISTS "data" JSONB DEFAULT NULL; -- NULL when the mail has a one-time link
+
+ALTER TABLE "mail_outbox" DROP COLUMN IF EXISTS "html_body";
+ALTER TABLE "mail_outbox" DROP COLUMN IF EXISTS "text_body";
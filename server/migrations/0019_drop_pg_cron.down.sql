@@ -0,0 +1,2 @@
+-- pg_cron is not installed again, the cleanup stays in process
+SELECT 1;
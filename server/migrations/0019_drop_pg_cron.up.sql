@@ -0,0 +1,10 @@
+-- The pending upload cleanup runs in process (pkg/jobs) since the scheduler
+-- replaced pg_cron. Databases created from the former 001_cron.sql still
+-- schedule it there, where it would run twice.
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_cron') THEN
+        -- Dynamic, the cron schema only exists along with the extension
+        EXECUTE 'SELECT cron.unschedule(jobid) FROM cron.job WHERE jobname = ''daily-pending-upload-cleanup''';
+        DROP EXTENSION pg_cron;
+    END IF;
+END $$;
@@ -0,0 +1,6 @@
+-- Emails are matched whatever their case, so are stored lowercased and unique
+-- whatever their case. Fails while two users differ only by the case of their
+-- email, one of them must be renamed first.
+UPDATE "users" SET "email" = LOWER("email") WHERE "email" <> LOWER("email");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "users_email_lower_idx" ON "users" (LOWER("email"));
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS "homework_students_audit_score_update" ON "homework_students";
+DROP TRIGGER IF EXISTS "homework_students_audit_score_insert" ON "homework_students";
+DROP TRIGGER IF EXISTS "users_audit_role_change" ON "users";
+DROP FUNCTION IF EXISTS "audit_events_record_grade_change"();
+DROP FUNCTION IF EXISTS "audit_events_record_role_change"();
+DROP FUNCTION IF EXISTS "audit_events_actor_id"();
@@ -0,0 +1,41 @@
+-- Role and grade changes are recorded by the database, whichever path writes
+-- them, in the same transaction. The writer names the actor with
+-- SET LOCAL "app.actor_id" = '<user_id>', the actor is empty otherwise.
+CREATE OR REPLACE FUNCTION "audit_events_actor_id"() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.actor_id', true), '')::UUID;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION "audit_events_record_role_change"() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO "audit_events" ("action", "actor_id", "target_id", "metadata")
+    VALUES ('user.role_changed', "audit_events_actor_id"(), NEW."id",
+        jsonb_build_object('from', OLD."role", 'to', NEW."role"));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "users_audit_role_change" AFTER UPDATE OF "role" ON "users"
+FOR EACH ROW WHEN (OLD."role" IS DISTINCT FROM NEW."role")
+EXECUTE FUNCTION "audit_events_record_role_change"();
+
+-- A score set on insert is a change from no score
+CREATE OR REPLACE FUNCTION "audit_events_record_grade_change"() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO "audit_events" ("action", "actor_id", "target_id", "metadata")
+    VALUES ('grade.changed', "audit_events_actor_id"(), NEW."student_id",
+        jsonb_build_object(
+            'homework_id', NEW."homework_id",
+            'from', CASE WHEN TG_OP = 'UPDATE' THEN OLD."score" END,
+            'to', NEW."score"
+        ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "homework_students_audit_score_insert" AFTER INSERT ON "homework_students"
+FOR EACH ROW WHEN (NEW."score" IS NOT NULL)
+EXECUTE FUNCTION "audit_events_record_grade_change"();
+
+CREATE TRIGGER "homework_students_audit_score_update" AFTER UPDATE OF "score" ON "homework_students"
+FOR EACH ROW WHEN (OLD."score" IS DISTINCT FROM NEW."score")
+EXECUTE FUNCTION "audit_events_record_grade_change"();
@@ -0,0 +1,8 @@
+-- One row per digest sent, so that a rerun of the job never sends the same digest twice
+CREATE TABLE IF NOT EXISTS "digest_deliveries" (
+    "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+    "frequency" digest_frequency NOT NULL,
+    "digest_date" DATE NOT NULL, -- In the time zone of the user
+    "sent_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("user_id", "frequency", "digest_date")
+);
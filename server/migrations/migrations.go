@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations of the database.
+//
+// Every migration is a pair of files named "<version>_<name>.up.sql" and
+// "<version>_<name>.down.sql", where version is a zero-padded number.
+// Applied migrations must never be edited, add a new one instead.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
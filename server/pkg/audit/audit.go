@@ -0,0 +1,12 @@
+package auditfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"auditfx",
+	fx.Provide(
+		NewAuditRoutes,
+		NewAuditController,
+		NewAuditService,
+	),
+)
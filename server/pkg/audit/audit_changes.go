@@ -0,0 +1,42 @@
+package auditfx
+
+import (
+	"reflect"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+)
+
+// Change of a field, recorded in the metadata of an event
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Changes diffs a partial update body against the record before the update,
+// by json field name. Fields of the body that are null or equal to the record
+// are left out.
+func Changes(before any, body any) (map[string]Change, error) {
+	beforeMap, err := common.StructToSnakeMap(before)
+	if err != nil {
+		return nil, err
+	}
+	bodyMap, err := common.StructToSnakeMap(body)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]Change{}
+	for field, to := range bodyMap {
+		if to == nil {
+			continue
+		}
+
+		from := beforeMap[field]
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		changes[field] = Change{From: from, To: to}
+	}
+
+	return changes, nil
+}
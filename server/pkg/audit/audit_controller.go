@@ -0,0 +1,124 @@
+package auditfx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type AuditControllerParams struct {
+	fx.In
+	Logger       *zap.Logger
+	AuditService AuditServiceInterface
+	AuthzService authzfx.AuthzServiceInterface
+}
+
+type AuditController struct {
+	Logger       *zap.Logger
+	AuditService AuditServiceInterface
+	AuthzService authzfx.AuthzServiceInterface
+}
+
+func NewAuditController(params AuditControllerParams) *AuditController {
+	return &AuditController{
+		Logger:       params.Logger,
+		AuditService: params.AuditService,
+		AuthzService: params.AuthzService,
+	}
+}
+
+// ======================== METHODS ========================
+
+// GetAuditEvents filters by the actor_id, target_id, action, from and to
+// (RFC 3339) query parameters
+func (controller *AuditController) GetAuditEvents(ctx *gin.Context) {
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionAuditRead, uuid.Nil)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	filter := &AuditEventFilter{Action: types.AuditAction(ctx.Query("action"))}
+
+	var ok bool
+	if filter.ActorID, ok = parseUUIDQuery(ctx, "actor_id"); !ok {
+		return
+	}
+	if filter.TargetID, ok = parseUUIDQuery(ctx, "target_id"); !ok {
+		return
+	}
+	if filter.From, ok = parseTimeQuery(ctx, "from"); !ok {
+		return
+	}
+	if filter.To, ok = parseTimeQuery(ctx, "to"); !ok {
+		return
+	}
+
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{
+				"limit": "limit must be a positive number",
+			}))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := controller.AuditService.GetAuditEvents(ctx.Request.Context(), filter)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"audit_events": events})
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// parseUUIDQuery responds with 400 when the query parameter is set but not a uuid
+func parseUUIDQuery(ctx *gin.Context, key string) (*uuid.UUID, bool) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{key: key + " is not uuid"}))
+		return nil, false
+	}
+
+	return &id, true
+}
+
+// parseTimeQuery responds with 400 when the query parameter is set but not RFC 3339
+func parseTimeQuery(ctx *gin.Context, key string) (*time.Time, bool) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, true
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{key: key + " must be an RFC 3339 time"}))
+		return nil, false
+	}
+
+	return &t, true
+}
@@ -0,0 +1,72 @@
+package auditfx
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type AuditRoutesParams struct {
+	fx.In
+	Logger          *zap.Logger
+	Router          *gin.Engine
+	AuthMiddleware  *middlewarefx.AuthMiddleware
+	AuditController *AuditController
+}
+
+type AuditRoutes struct {
+	Logger          *zap.Logger
+	Router          *gin.Engine
+	AuthMiddleware  *middlewarefx.AuthMiddleware
+	AuditController *AuditController
+}
+
+func NewAuditRoutes(params AuditRoutesParams) *AuditRoutes {
+	return &AuditRoutes{
+		Logger:          params.Logger,
+		Router:          params.Router,
+		AuthMiddleware:  params.AuthMiddleware,
+		AuditController: params.AuditController,
+	}
+}
+
+func (routes *AuditRoutes) Setup() {
+	routes.Logger.Info("Setting up [Audit] routes.")
+
+	routes.Router.GET(string(endpoints.GetAuditEventsV1),
+		routes.AuthMiddleware.Handler(),
+		routes.AuditController.GetAuditEvents)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    string(endpoints.GetAuditEventsV1),
+			ID:      "getAuditEvents",
+			Tag:     "audit",
+			Summary: "List the latest audit events",
+			Auth:    openapifx.AuthAdmin,
+			Query: []openapifx.Param{
+				{Name: "actor_id", Schema: uuid.UUID{}},
+				{Name: "target_id", Schema: uuid.UUID{}},
+				{Name: "action", Schema: types.AuditAction("")},
+				{Name: "from", Schema: time.Time{}, Description: "Inclusive"},
+				{Name: "to", Schema: time.Time{}, Description: "Exclusive"},
+				{Name: "limit", Schema: 0},
+			},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"audit_events": []*models.AuditEvent{}}},
+			},
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package auditfx
+
+import (
+	"context"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuditServiceParams struct {
+	fx.In
+	Logger *zap.Logger
+	DB     *gorm.DB
+}
+
+type AuditService struct {
+	Logger *zap.Logger
+	DB     *gorm.DB
+}
+
+type AuditServiceInterface interface {
+	Record(ctx context.Context, event *models.AuditEvent)
+	RecordTx(tx *gorm.DB, event *models.AuditEvent) error
+	GetAuditEvents(ctx context.Context, filter *AuditEventFilter) ([]*models.AuditEvent, error)
+}
+
+// Verify interface implementation at compile time
+var _ AuditServiceInterface = (*AuditService)(nil)
+
+func NewAuditService(params AuditServiceParams) AuditServiceInterface {
+	return &AuditService{
+		Logger: params.Logger,
+		DB:     params.DB,
+	}
+}
+
+// AuditEventFilter narrows GetAuditEvents, zero fields match every event
+type AuditEventFilter struct {
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	Action   types.AuditAction
+	From     *time.Time // Inclusive
+	To       *time.Time // Exclusive
+	Limit    int
+}
+
+const (
+	defaultAuditEventsLimit = 50
+	maxAuditEventsLimit     = 200
+)
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// Record writes an event of something that changed nothing, e.g. a login. A
+// failure is logged and never fails the caller.
+func (service *AuditService) Record(ctx context.Context, event *models.AuditEvent) {
+	_ = service.RecordTx(service.DB.WithContext(ctx), event) // Logged by RecordTx
+}
+
+// RecordTx writes an event in the transaction of the change it records, so the
+// change is rolled back when the event cannot be written
+func (service *AuditService) RecordTx(tx *gorm.DB, event *models.AuditEvent) error {
+	if event.Metadata == nil {
+		event.Metadata = map[string]any{}
+	}
+
+	if err := tx.Create(event).Error; err != nil {
+		service.Logger.Error("Audit event database creation failed",
+			zap.String("action", string(event.Action)),
+			zap.Error(err),
+		)
+		return common.ErrDatabase
+	}
+
+	return nil
+}
+
+// GetAuditEvents returns the latest events first
+func (service *AuditService) GetAuditEvents(
+	ctx context.Context,
+	filter *AuditEventFilter,
+) ([]*models.AuditEvent, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventsLimit
+	}
+	limit = min(limit, maxAuditEventsLimit)
+
+	query := service.DB.WithContext(ctx).Order("created_at DESC").Limit(limit)
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	events := []*models.AuditEvent{}
+	if result := query.Find(&events); result.Error != nil {
+		logger.Error("Audit events database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	return events, nil
+}
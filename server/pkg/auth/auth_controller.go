@@ -42,26 +42,65 @@ func NewAuthController(params AuthControllerParams) *AuthController {
 // ======================== REQUEST BODY ========================
 
 type RegisterBody struct {
-	Role       types.UserRole   `json:"role"        binding:"required,oneof='student' 'teacher' 'guardian'"` // Not allow Admin to be registered
-	FirstName  string           `json:"first_name"  binding:"required,max=128,alpha"`
-	MiddleName string           `json:"middle_name" binding:"omitempty,max=128,alpha"`
-	LastName   string           `json:"last_name"   binding:"omitempty,max=128,alpha"`
-	Phone      string           `json:"phone"       binding:"required,e164"`
-	Gender     types.UserGender `json:"gender"      binding:"required,oneof=''male' 'female' 'other' 'prefer_not_to_say'"`
-	Password   string           `json:"password"    binding:"required,min=8,max=64"`
-	SchoolNum  *string          `json:"school_num"  binding:"omitempty,number,max=16"` // Be either student_num or teacher_num
+	Role          types.UserRole   `json:"role"           binding:"required,oneof='student' 'teacher' 'guardian'"` // Not allow Admin to be registered
+	FirstName     string           `json:"first_name"     binding:"required,max=128,name"`
+	MiddleName    string           `json:"middle_name"    binding:"omitempty,max=128,name"`
+	LastName      string           `json:"last_name"      binding:"omitempty,max=128,name"`
+	RomanizedName *string          `json:"romanized_name" binding:"omitempty,max=384,romanized_name"` // For search and sorting
+	Phone         string           `json:"phone"          binding:"required,e164"`
+	Gender        types.UserGender `json:"gender"         binding:"required,oneof='male' 'female' 'other' 'prefer_not_to_say'"`
+	Password      string           `json:"password"       binding:"required,min=8,max=64"`
+	SchoolNum     *string          `json:"school_num"     binding:"omitempty,number,max=16"` // Be either student_num or teacher_num
+}
+
+func (rb *RegisterBody) Normalize() {
+	rb.FirstName = common.NormalizeName(rb.FirstName)
+	rb.MiddleName = common.NormalizeName(rb.MiddleName)
+	rb.LastName = common.NormalizeName(rb.LastName)
+	rb.RomanizedName = common.NormalizeNamePtr(rb.RomanizedName)
 }
 
 func (rb RegisterBody) ToUserModel() *models.User {
 	return &models.User{
-		Role:       rb.Role,
-		FirstName:  rb.FirstName,
-		MiddleName: rb.MiddleName,
-		LastName:   rb.LastName,
-		Phone:      rb.Phone,
-		Gender:     rb.Gender,
-		Password:   rb.Password,
-		SchoolNum:  rb.SchoolNum,
+		Role:          rb.Role,
+		FirstName:     rb.FirstName,
+		MiddleName:    rb.MiddleName,
+		LastName:      rb.LastName,
+		RomanizedName: rb.RomanizedName,
+		Phone:         rb.Phone,
+		Gender:        rb.Gender,
+		Password:      rb.Password,
+		SchoolNum:     rb.SchoolNum,
+	}
+}
+
+// InvitationRegisterBody is RegisterBody without the fields pre-filled by the invitation
+type InvitationRegisterBody struct {
+	FirstName     string           `json:"first_name"     binding:"required,max=128,name"`
+	MiddleName    string           `json:"middle_name"    binding:"omitempty,max=128,name"`
+	LastName      string           `json:"last_name"      binding:"omitempty,max=128,name"`
+	RomanizedName *string          `json:"romanized_name" binding:"omitempty,max=384,romanized_name"`
+	Phone         string           `json:"phone"          binding:"required,e164"`
+	Gender        types.UserGender `json:"gender"         binding:"required,oneof='male' 'female' 'other' 'prefer_not_to_say'"`
+	Password      string           `json:"password"       binding:"required,min=8,max=64"`
+}
+
+func (rb *InvitationRegisterBody) Normalize() {
+	rb.FirstName = common.NormalizeName(rb.FirstName)
+	rb.MiddleName = common.NormalizeName(rb.MiddleName)
+	rb.LastName = common.NormalizeName(rb.LastName)
+	rb.RomanizedName = common.NormalizeNamePtr(rb.RomanizedName)
+}
+
+func (rb InvitationRegisterBody) ToUserModel() *models.User {
+	return &models.User{
+		FirstName:     rb.FirstName,
+		MiddleName:    rb.MiddleName,
+		LastName:      rb.LastName,
+		RomanizedName: rb.RomanizedName,
+		Phone:         rb.Phone,
+		Gender:        rb.Gender,
+		Password:      rb.Password,
 	}
 }
 
@@ -83,12 +122,12 @@ func (controller *AuthController) GetRegistrationMail(ctx *gin.Context) {
 
 	// Validate email
 	if _, err := mail.ParseAddress(email); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid email"})
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"email": "invalid email"}))
 		return
 	}
 
 	// Business logic
-	err := controller.AuthService.GetRegistrationMail(email)
+	err := controller.AuthService.GetRegistrationMail(ctx.Request.Context(), email)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -108,12 +147,49 @@ func (controller *AuthController) Register(ctx *gin.Context) {
 	// Validate school number requirements
 	if err := validateSchoolNum(registerBody); err != nil {
 		controller.Logger.Debug("Register body request validation failed", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"school_num": err.Error()}))
+		return
+	}
+
+	// Business logic
+	user, accessToken, err := controller.AuthService.Register(ctx.Request.Context(), registrationTokenString, registerBody)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	// Convert user struct to map with snake_case key
+	userMap, err := common.StructToSnakeMap(user)
+	if err != nil {
+		controller.Logger.Error(
+			"Body response parse failed",
+			zap.String("response", "user"),
+			zap.Error(err),
+		)
+		common.RespondError(ctx, common.ErrInternal)
 		return
 	}
 
+	controller.setAccessTokenCookie(ctx, accessToken)
+	ctx.JSON(http.StatusCreated, gin.H{
+		"user": userMap,
+	})
+}
+
+func (controller *AuthController) RegisterByInvitation(ctx *gin.Context) {
+	// Get invitationToken from params
+	invitationTokenString := ctx.Param("invitationToken")
+
+	// Get validated body from context that set by RequestBodyValidator
+	validatedBody, _ := ctx.Get("validatedBody")
+	invitationRegisterBody, _ := validatedBody.(*InvitationRegisterBody)
+
 	// Business logic
-	user, accessToken, err := controller.AuthService.Register(registrationTokenString, registerBody)
+	user, accessToken, err := controller.AuthService.RegisterByInvitation(
+		ctx.Request.Context(),
+		invitationTokenString,
+		invitationRegisterBody,
+	)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -127,7 +203,7 @@ func (controller *AuthController) Register(ctx *gin.Context) {
 			zap.String("response", "user"),
 			zap.Error(err),
 		)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		common.RespondError(ctx, common.ErrInternal)
 		return
 	}
 
@@ -143,7 +219,7 @@ func (controller *AuthController) Login(ctx *gin.Context) {
 	loginBody, _ := validatedBody.(*LoginBody)
 
 	// Business logic
-	user, accessToken, err := controller.AuthService.Login(loginBody)
+	user, accessToken, err := controller.AuthService.Login(ctx.Request.Context(), loginBody)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -157,7 +233,7 @@ func (controller *AuthController) Login(ctx *gin.Context) {
 			zap.String("response", "user"),
 			zap.Error(err),
 		)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		common.RespondError(ctx, common.ErrInternal)
 		return
 	}
 
@@ -178,12 +254,12 @@ func (controller *AuthController) GetResetPwdMail(ctx *gin.Context) {
 
 	// Validate email
 	if _, err := mail.ParseAddress(email); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid email"})
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"email": "invalid email"}))
 		return
 	}
 
 	// Business logic
-	err := controller.AuthService.GetResetPwdMail(email)
+	err := controller.AuthService.GetResetPwdMail(ctx.Request.Context(), email)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -198,7 +274,7 @@ func (controller *AuthController) ResetPwd(ctx *gin.Context) {
 	resetPwdBody, _ := validatedBody.(*ResetPwdBody)
 
 	// Business logic
-	err := controller.AuthService.ResetPwd(resetPwdBody)
+	err := controller.AuthService.ResetPwd(ctx.Request.Context(), resetPwdBody)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -1,8 +1,12 @@
 package authfx
 
 import (
+	"net/http"
+
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
 	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -46,6 +50,10 @@ func (routes *AuthRoutes) Setup() {
 		routes.RequestBodyValidator.Handler(RegisterBody{}),
 		routes.AuthController.Register)
 
+	routes.Router.POST(string(endpoints.RegisterByInvitationV1)+"/:invitationToken",
+		routes.RequestBodyValidator.Handler(InvitationRegisterBody{}),
+		routes.AuthController.RegisterByInvitation)
+
 	routes.Router.POST(string(endpoints.LoginV1),
 		routes.RequestBodyValidator.Handler(LoginBody{}),
 		routes.AuthController.Login)
@@ -61,3 +69,75 @@ func (routes *AuthRoutes) Setup() {
 		routes.RequestBodyValidator.Handler(ResetPwdBody{}),
 		routes.AuthController.ResetPwd)
 }
+
+func Operations() []openapifx.Operation {
+	userResponse := openapifx.Object{"user": models.PublicUser{}}
+
+	return []openapifx.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetRegistrationMailV1) + "/:email",
+			ID:        "getRegistrationMail",
+			Tag:       "auth",
+			Summary:   "Mail a registration link, or a warning when the email is taken",
+			Responses: []openapifx.Response{{Status: http.StatusOK}},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      string(endpoints.RegisterV1) + "/:registrationToken",
+			ID:        "register",
+			Tag:       "auth",
+			Summary:   "Register with the token of a registration link, and sign in",
+			Body:      RegisterBody{},
+			Responses: []openapifx.Response{{Status: http.StatusCreated, Body: userResponse}},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      string(endpoints.RegisterByInvitationV1) + "/:invitationToken",
+			ID:        "registerByInvitation",
+			Tag:       "auth",
+			Summary:   "Register with the token of an invitation, and sign in",
+			Body:      InvitationRegisterBody{},
+			Responses: []openapifx.Response{{Status: http.StatusCreated, Body: userResponse}},
+			Errors:    []int{http.StatusNotFound, http.StatusConflict},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      string(endpoints.LoginV1),
+			ID:        "login",
+			Tag:       "auth",
+			Summary:   "Sign in, setting the access token cookie",
+			Body:      LoginBody{},
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: userResponse}},
+			Errors:    []int{http.StatusUnauthorized, http.StatusForbidden},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      string(endpoints.LogoutV1),
+			ID:        "logout",
+			Tag:       "auth",
+			Summary:   "Sign out, clearing the access token cookie",
+			Auth:      openapifx.AuthUser,
+			Responses: []openapifx.Response{{Status: http.StatusOK}},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetResetPwdMailV1) + "/:email",
+			ID:        "getResetPwdMail",
+			Tag:       "auth",
+			Summary:   "Mail a reset password link",
+			Responses: []openapifx.Response{{Status: http.StatusOK}},
+			Errors:    []int{http.StatusNotFound},
+		},
+		{
+			Method:    http.MethodPut,
+			Path:      string(endpoints.ResetPwdV1),
+			ID:        "resetPwd",
+			Tag:       "auth",
+			Summary:   "Set a new password with the token of a reset password link",
+			Body:      ResetPwdBody{},
+			Responses: []openapifx.Response{{Status: http.StatusOK}},
+			Errors:    []int{http.StatusNotFound},
+		},
+	}
+}
@@ -1,18 +1,23 @@
 package authfx
 
 import (
+	"context"
 	"errors"
 	"net/mail"
+	"strings"
 	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	invitationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/invitations"
 	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
 	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -20,71 +25,87 @@ import (
 
 type AuthServiceParams struct {
 	fx.In
-	AppConfig     *configfx.AppConfig
-	Logger        *zap.Logger
-	MailService   *mailfx.MailService
-	UserService   usersfx.UserServiceInterface
-	StorageClient *minio.Client
+	AppConfig         *configfx.AppConfig
+	Logger            *zap.Logger
+	MailService       *mailfx.MailService
+	UserService       usersfx.UserServiceInterface
+	InvitationService invitationsfx.InvitationServiceInterface
+	AuditService      auditfx.AuditServiceInterface
+	Metrics           *metricsfx.Metrics
+	StorageClient     *minio.Client
 }
 
 type AuthService struct {
-	AppConfig     *configfx.AppConfig
-	Logger        *zap.Logger
-	MailService   *mailfx.MailService
-	UserService   usersfx.UserServiceInterface
-	StorageClient *minio.Client
+	AppConfig         *configfx.AppConfig
+	Logger            *zap.Logger
+	MailService       *mailfx.MailService
+	UserService       usersfx.UserServiceInterface
+	InvitationService invitationsfx.InvitationServiceInterface
+	AuditService      auditfx.AuditServiceInterface
+	Metrics           *metricsfx.Metrics
+	StorageClient     *minio.Client
 }
 
 // Verify interface implementation at compile time
 var _ AuthServiceInterface = (*AuthService)(nil)
 
 type AuthServiceInterface interface {
-	GetRegistrationMail(email string) error
-	Register(registrationTokenString string, body *RegisterBody) (*models.PublicUser, string, error)
-	Login(body *LoginBody) (*models.PublicUser, string, error)
-	GetResetPwdMail(email string) error
-	ResetPwd(body *ResetPwdBody) error
+	GetRegistrationMail(ctx context.Context, email string) error
+	Register(ctx context.Context, registrationTokenString string, body *RegisterBody) (*models.PublicUser, string, error)
+	RegisterByInvitation(ctx context.Context, invitationTokenString string, body *InvitationRegisterBody) (*models.PublicUser, string, error)
+	Login(ctx context.Context, body *LoginBody) (*models.PublicUser, string, error)
+	GetResetPwdMail(ctx context.Context, email string) error
+	ResetPwd(ctx context.Context, body *ResetPwdBody) error
 }
 
 func NewAuthService(params AuthServiceParams) AuthServiceInterface {
 	return &AuthService{
-		AppConfig:     params.AppConfig,
-		Logger:        params.Logger,
-		MailService:   params.MailService,
-		UserService:   params.UserService,
-		StorageClient: params.StorageClient,
+		AppConfig:         params.AppConfig,
+		Logger:            params.Logger,
+		MailService:       params.MailService,
+		UserService:       params.UserService,
+		InvitationService: params.InvitationService,
+		AuditService:      params.AuditService,
+		Metrics:           params.Metrics,
+		StorageClient:     params.StorageClient,
 	}
 }
 
 // ======================== BUSINESS LOGIC METHODS ========================
 
-func (service *AuthService) GetRegistrationMail(email string) error {
+func (service *AuthService) GetRegistrationMail(ctx context.Context, email string) error {
+	// Emails are stored lowercased, so is the email of the registration token
+	email = strings.ToLower(email)
+
 	// Check if email already existed
-	user, err := service.UserService.GetUserByEmail(email)
+	// Auth is unauthenticated, so it bypasses the school scope, emails are unique across schools
+	user, err := service.UserService.GetUserByEmail(tenant.Bypass(ctx), email)
 	if err != nil && !errors.Is(err, common.ErrUserNotFound) {
 		return err
 	}
 
 	// Send warning email if user already existed
 	if user != nil {
-		err = service.MailService.SendRegistrationWarning(user)
+		err = service.MailService.SendRegistrationWarning(ctx, user)
 		return err
 	}
 
 	// Generate registration token
 	var registrationToken string
 	registrationToken, err = service.generateActionToken(email,
+		types.ActionTokenTypeRegistration,
 		time.Hour*time.Duration(service.AppConfig.JWTExpiresIn))
 	if err != nil {
 		return err
 	}
 
 	// Send verification email if it is new user
-	err = service.MailService.SendRegistrationVerification(email, registrationToken)
+	err = service.MailService.SendRegistrationVerification(ctx, email, registrationToken)
 	return err
 }
 
 func (service *AuthService) Register(
+	ctx context.Context,
 	registrationTokenStr string,
 	body *RegisterBody,
 ) (*models.PublicUser, string, error) {
@@ -112,6 +133,10 @@ func (service *AuthService) Register(
 		service.Logger.Debug("Registration token type assertion failed")
 		return nil, "", common.ErrActionTokenClaimsRetrieval
 	}
+	if claims["typ"] != string(types.ActionTokenTypeRegistration) {
+		service.Logger.Debug("Registration token kind mismatched", zap.Any("typ", claims["typ"]))
+		return nil, "", common.ErrInvalidActionToken
+	}
 	email, ok := claims["email"].(string)
 	if !ok {
 		service.Logger.Debug(
@@ -129,11 +154,13 @@ func (service *AuthService) Register(
 
 	// Create new user
 	user := body.ToUserModel()
-	user.Email = email
-	if err := service.UserService.CreateUser(user); err != nil {
+	user.Email = strings.ToLower(email) // Tokens issued before kept the case as typed
+	if err := service.UserService.CreateUser(tenant.Bypass(ctx), user); err != nil {
 		return nil, "", err
 	}
+	service.Metrics.Registrations.WithLabelValues("link").Inc()
 	publicUser, err := user.ToPublic(
+		ctx,
 		service.Logger,
 		service.StorageClient,
 		service.AppConfig.StorageBucketName,
@@ -143,7 +170,7 @@ func (service *AuthService) Register(
 	}
 
 	// Generate JWT accessToken
-	accessToken, err := service.generateAccessToken(user.ID, user.Role)
+	accessToken, err := service.generateAccessToken(user)
 	if err != nil {
 		return nil, "", err
 	}
@@ -151,18 +178,70 @@ func (service *AuthService) Register(
 	return publicUser, accessToken, nil
 }
 
-func (service *AuthService) Login(body *LoginBody) (*models.PublicUser, string, error) {
-	user, err := service.UserService.GetUserByEmail(body.Email)
+// RegisterByInvitation creates the user with the email, role and school number
+// of the invitation, enrolled into its class
+func (service *AuthService) RegisterByInvitation(
+	ctx context.Context,
+	invitationTokenStr string,
+	body *InvitationRegisterBody,
+) (*models.PublicUser, string, error) {
+	user := body.ToUserModel()
+	if err := service.InvitationService.AcceptInvitation(ctx, invitationTokenStr, user); err != nil {
+		return nil, "", err
+	}
+	service.Metrics.Registrations.WithLabelValues("invitation").Inc()
+
+	publicUser, err := user.ToPublic(
+		ctx,
+		service.Logger,
+		service.StorageClient,
+		service.AppConfig.StorageBucketName,
+		time.Hour*time.Duration(service.AppConfig.JWTExpiresIn))
+	if err != nil {
+		return nil, "", common.ErrURLSigning
+	}
+
+	// Generate JWT accessToken
+	accessToken, err := service.generateAccessToken(user)
 	if err != nil {
+		return nil, "", err
+	}
+
+	return publicUser, accessToken, nil
+}
+
+func (service *AuthService) Login(ctx context.Context, body *LoginBody) (*models.PublicUser, string, error) {
+	body.Email = strings.ToLower(body.Email)
+
+	user, err := service.UserService.GetUserByEmail(tenant.Bypass(ctx), body.Email)
+	if err != nil {
+		if errors.Is(err, common.ErrUserNotFound) {
+			service.recordLoginFailed(ctx, body.Email, nil, "unknown_email")
+		}
 		return nil, "", common.ErrInvalidCredentials
 	}
 
 	// Compare password with hashed
 	if !common.CheckHashedPassword(body.Password, user.Password) {
+		service.recordLoginFailed(ctx, body.Email, user, "wrong_password")
 		return nil, "", common.ErrInvalidCredentials
 	}
 
+	// The auth middleware also rejects the access tokens issued before
+	if user.DeactivatedAt != nil {
+		service.recordLoginFailed(ctx, body.Email, user, "deactivated")
+		return nil, "", common.ErrUserDeactivated
+	}
+
+	service.Metrics.Logins.WithLabelValues("succeeded").Inc()
+	service.AuditService.Record(ctx, &models.AuditEvent{
+		Action:   types.AuditActionLoginSucceeded,
+		ActorID:  &user.ID,
+		TargetID: &user.ID,
+	})
+
 	publicUser, err := user.ToPublic(
+		ctx,
 		service.Logger,
 		service.StorageClient,
 		service.AppConfig.StorageBucketName,
@@ -172,7 +251,7 @@ func (service *AuthService) Login(body *LoginBody) (*models.PublicUser, string,
 	}
 
 	// Generate JWT accessToken
-	accessToken, err := service.generateAccessToken(user.ID, user.Role)
+	accessToken, err := service.generateAccessToken(user)
 	if err != nil {
 		return nil, "", err
 	}
@@ -180,23 +259,25 @@ func (service *AuthService) Login(body *LoginBody) (*models.PublicUser, string,
 	return publicUser, accessToken, nil
 }
 
-func (service *AuthService) GetResetPwdMail(email string) error {
+func (service *AuthService) GetResetPwdMail(ctx context.Context, email string) error {
+	email = strings.ToLower(email)
+
 	// Check if email actually existed
-	user, err := service.UserService.GetUserByEmail(email)
+	user, err := service.UserService.GetUserByEmail(tenant.Bypass(ctx), email)
 	if err != nil {
 		return err
 	}
 
-	resetPwdToken, err := service.generateActionToken(user.Email, time.Minute*10)
+	resetPwdToken, err := service.generateActionToken(user.Email, types.ActionTokenTypeResetPwd, time.Minute*10)
 	if err != nil {
 		return err
 	}
 
-	err = service.MailService.SendResetPwd(user, resetPwdToken)
+	err = service.MailService.SendResetPwd(ctx, user, resetPwdToken)
 	return err
 }
 
-func (service *AuthService) ResetPwd(body *ResetPwdBody) error {
+func (service *AuthService) ResetPwd(ctx context.Context, body *ResetPwdBody) error {
 	// Parse resetPwdToken
 	resetPwdToken, err := common.ParseJWTToken(body.ResetPwdToken, service.AppConfig.JWTSecret)
 	if err != nil {
@@ -222,22 +303,37 @@ func (service *AuthService) ResetPwd(body *ResetPwdBody) error {
 		return common.ErrActionTokenClaimsRetrieval
 	}
 
-	// Hash and updaate password
-	err = service.UserService.UpdateUserPwdByEmail(email, body.NewPassword)
-	if err != nil {
-		return err
-	}
+	switch claims["typ"] {
+	case string(types.ActionTokenTypeResetPwd):
+		// Hash and updaate password
+		return service.UserService.UpdateUserPwdByEmail(tenant.Bypass(ctx), email, body.NewPassword)
+	case string(types.ActionTokenTypeSetPwd):
+		// A set-password token of a roster import is only valid until the password is set
+		pwdFingerprint, ok := claims["pwd"].(string)
+		if pwdFingerprint == "" || !ok {
+			service.Logger.Debug(
+				"Set password token claims retrieval failed",
+				zap.String("key", "pwd"),
+			)
+			return common.ErrActionTokenClaimsRetrieval
+		}
 
-	return nil
+		return service.UserService.SetUserPwdByEmail(tenant.Bypass(ctx), email, pwdFingerprint, body.NewPassword)
+	default:
+		service.Logger.Debug("Reset password token kind mismatched", zap.Any("typ", claims["typ"]))
+		return common.ErrInvalidActionToken
+	}
 }
 
 // ======================== HELPER METHODS ========================
 
 func (service *AuthService) generateActionToken(
 	email string,
+	typ types.ActionTokenType,
 	expiresIn time.Duration,
 ) (string, error) {
 	claims := jwt.MapClaims{
+		"typ":   typ,
 		"email": email,
 	}
 
@@ -252,13 +348,28 @@ func (service *AuthService) generateActionToken(
 	return signedToken, nil
 }
 
-func (service *AuthService) generateAccessToken(
-	userID uuid.UUID,
-	role types.UserRole,
-) (string, error) {
+// recordLoginFailed records the attempt against the user of the email, if any
+func (service *AuthService) recordLoginFailed(ctx context.Context, email string, user *models.User, reason string) {
+	service.Metrics.Logins.WithLabelValues("failed").Inc()
+
+	event := &models.AuditEvent{
+		Action:   types.AuditActionLoginFailed,
+		Metadata: map[string]any{"email": email, "reason": reason},
+	}
+	if user != nil {
+		event.TargetID = &user.ID
+	}
+
+	service.AuditService.Record(ctx, event)
+}
+
+// generateAccessToken signs the claims read by AuthMiddleware, school_id being
+// null for platform admins and users without a school
+func (service *AuthService) generateAccessToken(user *models.User) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": userID,
-		"role":    role,
+		"user_id":   user.ID,
+		"role":      user.Role,
+		"school_id": user.SchoolID,
 	}
 
 	signedToken, err := common.GenerateJTWToken(claims,
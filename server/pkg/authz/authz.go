@@ -0,0 +1,11 @@
+package authzfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"authzfx",
+	fx.Provide(
+		NewRelations,
+		NewAuthzService,
+	),
+)
@@ -0,0 +1,186 @@
+package authzfx
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+// Permission is a named action on a kind of resource, checked against the
+// resource's ID
+type Permission string
+
+const (
+	// Resource: user ID
+	PermissionUserRead         Permission = "user:read"
+	PermissionUserUpdate       Permission = "user:update"
+	PermissionUserAvatarUpload Permission = "user:avatar:upload"
+	PermissionUserHomeworkRead Permission = "user:homework:read" // Of a student
+	PermissionUserRoleUpdate   Permission = "user:role:update"
+
+	// Resource: class ID
+	PermissionClassManage          Permission = "class:manage" // Roster and invitations
+	PermissionClassAssignmentsEdit Permission = "class:assignments:edit"
+	PermissionClassLoadRead        Permission = "class:load:read" // Of every student, from every teacher
+
+	// Resource: invitation ID
+	PermissionInvitationRevoke Permission = "invitation:revoke"
+
+	// Resource: homework ID
+	PermissionHomeworkGrade  Permission = "homework:grade"
+	PermissionHomeworkAssign Permission = "homework:assign" // To a class, checked with class:assignments:edit
+
+	// Resource: none, uuid.Nil
+	PermissionAuditRead Permission = "audit:read"
+)
+
+// Grant allows a permission to some roles, when Check passes. A nil Check
+// allows the roles on every resource.
+type Grant struct {
+	Roles []types.UserRole
+	Check Check
+}
+
+// Check is a relationship check between the subject and the resource
+type Check func(ctx context.Context, relations Relations, subject *Subject, resourceID uuid.UUID) (bool, error)
+
+var (
+	// Roles that own a profile, admins are managed with tgsctl
+	profileRoles = []types.UserRole{types.UserRoleStudent, types.UserRoleTeacher, types.UserRoleGuardian}
+
+	// Grants of class:manage, shared with the class scoped permissions
+	classManageGrants = []Grant{
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin}, Check: isClassInOwnSchool},
+		{Roles: []types.UserRole{types.UserRoleTeacher}, Check: isClassTeacher},
+	}
+)
+
+// Policy is every permission and who is granted it. A permission not listed is denied.
+var Policy = map[Permission][]Grant{
+	PermissionUserRead: {
+		{Roles: allRoles(), Check: isSelf},
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin}, Check: isUserInOwnSchool},
+		{Roles: []types.UserRole{types.UserRoleTeacher}, Check: teachesStudent},
+		{Roles: []types.UserRole{types.UserRoleGuardian}, Check: isGuardianOf},
+	},
+	PermissionUserUpdate: {
+		{Roles: profileRoles, Check: isSelf},
+	},
+	PermissionUserAvatarUpload: {
+		{Roles: profileRoles, Check: isSelf},
+	},
+	PermissionUserHomeworkRead: {
+		{Roles: []types.UserRole{types.UserRoleStudent}, Check: isSelf},
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin}, Check: isUserInOwnSchool},
+		{Roles: []types.UserRole{types.UserRoleTeacher}, Check: teachesStudent},
+		{Roles: []types.UserRole{types.UserRoleGuardian}, Check: isGuardianOf},
+	},
+	PermissionUserRoleUpdate: {
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin}, Check: isUserInOwnSchool},
+	},
+	PermissionClassManage:          classManageGrants,
+	PermissionClassAssignmentsEdit: classManageGrants,
+	PermissionClassLoadRead:        classManageGrants,
+	PermissionInvitationRevoke: {
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin, types.UserRoleTeacher}, Check: canManageInvitationClass},
+	},
+	PermissionHomeworkGrade: {
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleTeacher}, Check: isHomeworkTeacher},
+	},
+	PermissionHomeworkAssign: {
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+		{Roles: []types.UserRole{types.UserRoleSchoolAdmin}, Check: isHomeworkInOwnSchool},
+		{Roles: []types.UserRole{types.UserRoleTeacher}, Check: isHomeworkTeacher},
+	},
+	PermissionAuditRead: {
+		{Roles: []types.UserRole{types.UserRoleAdmin}},
+	},
+}
+
+// ======================== CHECKS ========================
+
+func isSelf(_ context.Context, _ Relations, subject *Subject, resourceID uuid.UUID) (bool, error) {
+	return subject.UserID == resourceID, nil
+}
+
+func isUserInOwnSchool(ctx context.Context, relations Relations, subject *Subject, userID uuid.UUID) (bool, error) {
+	if subject.SchoolID == nil {
+		return false, nil
+	}
+	return relations.IsUserInSchool(ctx, userID, *subject.SchoolID)
+}
+
+func isClassInOwnSchool(ctx context.Context, relations Relations, subject *Subject, classID uuid.UUID) (bool, error) {
+	if subject.SchoolID == nil {
+		return false, nil
+	}
+	return relations.IsClassInSchool(ctx, classID, *subject.SchoolID)
+}
+
+func isClassTeacher(ctx context.Context, relations Relations, subject *Subject, classID uuid.UUID) (bool, error) {
+	return relations.IsClassTeacher(ctx, subject.UserID, classID)
+}
+
+func teachesStudent(ctx context.Context, relations Relations, subject *Subject, studentID uuid.UUID) (bool, error) {
+	return relations.TeachesStudent(ctx, subject.UserID, studentID)
+}
+
+func isGuardianOf(ctx context.Context, relations Relations, subject *Subject, studentID uuid.UUID) (bool, error) {
+	return relations.IsGuardianOf(ctx, subject.UserID, studentID)
+}
+
+func isHomeworkTeacher(ctx context.Context, relations Relations, subject *Subject, homeworkID uuid.UUID) (bool, error) {
+	return relations.IsHomeworkTeacher(ctx, subject.UserID, homeworkID)
+}
+
+func isHomeworkInOwnSchool(ctx context.Context, relations Relations, subject *Subject, homeworkID uuid.UUID) (bool, error) {
+	if subject.SchoolID == nil {
+		return false, nil
+	}
+	return relations.IsHomeworkInSchool(ctx, homeworkID, *subject.SchoolID)
+}
+
+// canManageInvitationClass applies the class:manage checks of the subject's
+// role to the class of the invitation
+func canManageInvitationClass(ctx context.Context, relations Relations, subject *Subject, invitationID uuid.UUID) (bool, error) {
+	classID, found, err := relations.GetInvitationClassID(ctx, invitationID)
+	if err != nil || !found {
+		return false, err
+	}
+
+	for _, grant := range classManageGrants {
+		if !grant.allows(subject.Role) {
+			continue
+		}
+		if grant.Check == nil {
+			return true, nil
+		}
+		return grant.Check(ctx, relations, subject, classID)
+	}
+
+	return false, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func allRoles() []types.UserRole {
+	return []types.UserRole{
+		types.UserRoleStudent,
+		types.UserRoleTeacher,
+		types.UserRoleGuardian,
+		types.UserRoleSchoolAdmin,
+		types.UserRoleAdmin,
+	}
+}
+
+func (grant Grant) allows(role types.UserRole) bool {
+	return slices.Contains(grant.Roles, role)
+}
@@ -0,0 +1,107 @@
+package authzfx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Relations answers the relationship checks of the policy
+type Relations interface {
+	IsClassTeacher(ctx context.Context, teacherID uuid.UUID, classID uuid.UUID) (bool, error)
+	IsClassInSchool(ctx context.Context, classID uuid.UUID, schoolID uuid.UUID) (bool, error)
+	IsUserInSchool(ctx context.Context, userID uuid.UUID, schoolID uuid.UUID) (bool, error)
+	IsGuardianOf(ctx context.Context, guardianID uuid.UUID, studentID uuid.UUID) (bool, error)
+	TeachesStudent(ctx context.Context, teacherID uuid.UUID, studentID uuid.UUID) (bool, error)
+	GetInvitationClassID(ctx context.Context, invitationID uuid.UUID) (uuid.UUID, bool, error)
+	IsHomeworkTeacher(ctx context.Context, teacherID uuid.UUID, homeworkID uuid.UUID) (bool, error)
+	IsHomeworkInSchool(ctx context.Context, homeworkID uuid.UUID, schoolID uuid.UUID) (bool, error)
+}
+
+type RelationsParams struct {
+	fx.In
+	DB *gorm.DB
+}
+
+// DBRelations reads the relationships from the database. The checks state
+// the school explicitly, so they are not tenant scoped.
+type DBRelations struct {
+	DB *gorm.DB
+}
+
+// Verify interface implementation at compile time
+var _ Relations = (*DBRelations)(nil)
+
+func NewRelations(params RelationsParams) Relations {
+	return &DBRelations{DB: params.DB}
+}
+
+func (relations *DBRelations) IsClassTeacher(ctx context.Context, teacherID uuid.UUID, classID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.ClassTeacher{}, "class_id = ? AND teacher_id = ?", classID, teacherID)
+}
+
+func (relations *DBRelations) IsClassInSchool(ctx context.Context, classID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.Class{}, "id = ? AND school_id = ?", classID, schoolID)
+}
+
+func (relations *DBRelations) IsUserInSchool(ctx context.Context, userID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.User{}, "id = ? AND school_id = ?", userID, schoolID)
+}
+
+func (relations *DBRelations) IsGuardianOf(ctx context.Context, guardianID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.StudentGuardian{}, "guardian_id = ? AND student_id = ?", guardianID, studentID)
+}
+
+// TeachesStudent is whether the student is in a class of the teacher
+func (relations *DBRelations) TeachesStudent(ctx context.Context, teacherID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.ClassStudent{},
+		"student_id = ? AND class_id IN (SELECT class_id FROM class_teachers WHERE teacher_id = ?)",
+		studentID, teacherID)
+}
+
+func (relations *DBRelations) GetInvitationClassID(ctx context.Context, invitationID uuid.UUID) (uuid.UUID, bool, error) {
+	invitation := &models.Invitation{}
+
+	result := relations.DB.WithContext(tenant.Bypass(ctx)).Select("class_id").First(invitation, invitationID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return uuid.Nil, false, nil
+	} else if result.Error != nil {
+		return uuid.Nil, false, result.Error
+	}
+
+	return invitation.ClassID, true, nil
+}
+
+func (relations *DBRelations) IsHomeworkTeacher(ctx context.Context, teacherID uuid.UUID, homeworkID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.HomeworkTeacher{}, "homework_id = ? AND teacher_id = ?", homeworkID, teacherID)
+}
+
+// IsHomeworkInSchool is whether a teacher of the homework is in the school,
+// homework having no school of its own
+func (relations *DBRelations) IsHomeworkInSchool(ctx context.Context, homeworkID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	return relations.exists(ctx, &models.HomeworkTeacher{},
+		"homework_id = ? AND teacher_id IN (SELECT id FROM users WHERE school_id = ?)",
+		homeworkID, schoolID)
+}
+
+// ======================== HELPER METHODS ========================
+
+func (relations *DBRelations) exists(ctx context.Context, model any, query string, args ...any) (bool, error) {
+	var count int64
+
+	result := relations.DB.WithContext(tenant.Bypass(ctx)).
+		Model(model).
+		Where(query, args...).
+		Limit(1).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,81 @@
+package authzfx
+
+import (
+	"context"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type AuthzServiceParams struct {
+	fx.In
+	Logger    *zap.Logger
+	Relations Relations
+}
+
+type AuthzService struct {
+	Logger    *zap.Logger
+	Relations Relations
+}
+
+type AuthzServiceInterface interface {
+	Authorize(ctx context.Context, subject *Subject, permission Permission, resourceID uuid.UUID) error
+}
+
+// Verify interface implementation at compile time
+var _ AuthzServiceInterface = (*AuthzService)(nil)
+
+func NewAuthzService(params AuthzServiceParams) AuthzServiceInterface {
+	return &AuthzService{
+		Logger:    params.Logger,
+		Relations: params.Relations,
+	}
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// Authorize allows the subject if any grant of the permission matches its role
+// and passes its check on the resource
+func (service *AuthzService) Authorize(
+	ctx context.Context,
+	subject *Subject,
+	permission Permission,
+	resourceID uuid.UUID,
+) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	for _, grant := range Policy[permission] {
+		if !grant.allows(subject.Role) {
+			continue
+		}
+		if grant.Check == nil {
+			return nil
+		}
+
+		allowed, err := grant.Check(ctx, service.Relations, subject, resourceID)
+		if err != nil {
+			logger.Error(
+				"Permission relations database retrieval failed",
+				zap.String("permission", string(permission)),
+				zap.String("resource_id", resourceID.String()),
+				zap.Error(err),
+			)
+			return common.ErrDatabase
+		}
+		if allowed {
+			return nil
+		}
+	}
+
+	logger.Debug(
+		"Permission denied",
+		zap.String("permission", string(permission)),
+		zap.String("user_id", subject.UserID.String()),
+		zap.String("role", string(subject.Role)),
+		zap.String("resource_id", resourceID.String()),
+	)
+	return common.ErrPermissionDenied
+}
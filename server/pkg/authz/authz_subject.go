@@ -0,0 +1,42 @@
+package authzfx
+
+import (
+	"fmt"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Subject is the authenticated user a permission is checked for
+type Subject struct {
+	UserID   uuid.UUID
+	Role     types.UserRole
+	SchoolID *uuid.UUID // Nil for platform admins and users without a school
+}
+
+// SubjectFromContext reads the subject set by AuthMiddleware
+func SubjectFromContext(ctx *gin.Context) (*Subject, error) {
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("parse user_id: %w", err)
+	}
+
+	role, _ := ctx.Get("role")
+	userRole, ok := role.(types.UserRole)
+	if !ok {
+		return nil, fmt.Errorf("missing role")
+	}
+
+	subject := &Subject{UserID: userID, Role: userRole}
+
+	if schoolIDStr := ctx.GetString("school_id"); schoolIDStr != "" {
+		schoolID, err := uuid.Parse(schoolIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse school_id: %w", err)
+		}
+		subject.SchoolID = &schoolID
+	}
+
+	return subject, nil
+}
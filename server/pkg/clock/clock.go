@@ -0,0 +1,62 @@
+// Package clock is the time of the services. They ask the injected Clock
+// rather than time.Now, so that tests can freeze it:
+//
+//	frozen := clock.NewFrozen(time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC))
+//	service := notificationsfx.NewNotificationService(notificationsfx.NotificationServiceParams{Clock: frozen, …})
+//	frozen.Advance(time.Hour)
+//
+// Elapsed times (latencies, timeouts) are still measured with time.Now and
+// time.Since, they are not instants of the domain.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	// Now returns the current instant in UTC
+	Now() time.Time
+}
+
+// New returns the clock of the system
+func New() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Frozen is a clock that only moves when told to, safe for concurrent use
+type Frozen struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Verify interface implementation at compile time
+var _ Clock = (*Frozen)(nil)
+
+func NewFrozen(now time.Time) *Frozen {
+	return &Frozen{now: now.UTC()}
+}
+
+func (frozen *Frozen) Now() time.Time {
+	frozen.mu.Lock()
+	defer frozen.mu.Unlock()
+	return frozen.now
+}
+
+func (frozen *Frozen) Set(now time.Time) {
+	frozen.mu.Lock()
+	defer frozen.mu.Unlock()
+	frozen.now = now.UTC()
+}
+
+func (frozen *Frozen) Advance(d time.Duration) {
+	frozen.mu.Lock()
+	defer frozen.mu.Unlock()
+	frozen.now = frozen.now.Add(d)
+}
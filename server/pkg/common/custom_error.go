@@ -7,100 +7,262 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// CustomError is responded as the error envelope, see RespondError. The Code
+// is stable and machine-readable, clients must not match the Message.
 type CustomError struct {
 	StatusCode int
+	Code       string
 	Message    string
+	Details    map[string]string // e.g. a message per invalid field
 }
 
 func (e CustomError) Error() string {
 	return e.Message
 }
 
+// Is matches by Code, so that errors.Is holds for the errors WithDetails
+func (e CustomError) Is(target error) bool {
+	var customErr CustomError
+	return errors.As(target, &customErr) && customErr.Code == e.Code
+}
+
+// WithDetails returns a copy of e carrying details
+func (e CustomError) WithDetails(details map[string]string) CustomError {
+	e.Details = details
+	return e
+}
+
 var (
 	// 500 Internal Server Errors
+	ErrUnknown = CustomError{
+		StatusCode: http.StatusInternalServerError,
+		Code:       "UNKNOWN_ERROR",
+		Message:    "unknown error",
+	}
+	ErrInternal = CustomError{
+		StatusCode: http.StatusInternalServerError,
+		Code:       "INTERNAL_ERROR",
+		Message:    "something went wrong",
+	}
 	ErrDatabase = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "DATABASE_ERROR",
 		Message:    "database error",
 	}
 	ErrStorage = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "STORAGE_ERROR",
 		Message:    "storage error",
 	}
 	ErrTokenGeneration = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "TOKEN_GENERATION_FAILED",
 		Message:    "failed generating access token",
 	}
 	ErrPasswordHashing = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "PASSWORD_HASHING_FAILED",
 		Message:    "failed hashing password",
 	}
 	ErrMailHTMLSetting = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "MAIL_HTML_SETTING_FAILED",
 		Message:    "failed setting mail html",
 	}
 	ErrMailSending = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "MAIL_SENDING_FAILED",
 		Message:    "failed sending mail",
 	}
 	ErrUUIDGeneration = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "UUID_GENERATION_FAILED",
 		Message:    "failed generating uuid",
 	}
 	ErrURLSigning = CustomError{
 		StatusCode: http.StatusInternalServerError,
+		Code:       "URL_SIGNING_FAILED",
 		Message:    "failed signing url",
 	}
 
 	// 400 Bad Request
+	ErrValidation = CustomError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "VALIDATION_FAILED",
+		Message:    "request validation failed",
+	}
+	ErrMalformedRequestBody = CustomError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "MALFORMED_REQUEST_BODY",
+		Message:    "invalid request format",
+	}
+	ErrRosterMalformed = CustomError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "ROSTER_MALFORMED",
+		Message:    "roster file is malformed",
+	}
+	ErrRosterTooManyRows = CustomError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "ROSTER_TOO_MANY_ROWS",
+		Message:    "roster must not exceed 1000 rows",
+	}
 	ErrDuplicatedEmail = CustomError{
 		StatusCode: http.StatusBadRequest,
+		Code:       "DUPLICATED_EMAIL",
 		Message:    "email already exists",
 	}
 	ErrInvalidActionToken = CustomError{
 		StatusCode: http.StatusBadRequest,
+		Code:       "INVALID_ACTION_TOKEN",
 		Message:    "invalid action token",
 	}
 	ErrActionTokenParsing = CustomError{
 		StatusCode: http.StatusBadRequest,
+		Code:       "ACTION_TOKEN_PARSING_FAILED",
 		Message:    "failed parsing action token",
 	}
 	ErrActionTokenClaimsRetrieval = CustomError{
 		StatusCode: http.StatusBadRequest,
+		Code:       "ACTION_TOKEN_CLAIMS_RETRIEVAL_FAILED",
 		Message:    "failed retrieval action token claims",
 	}
 	ErrActionTokenExpired = CustomError{
 		StatusCode: http.StatusBadRequest,
+		Code:       "ACTION_TOKEN_EXPIRED",
 		Message:    "action token already expired",
 	}
+	ErrActionTokenUsed = CustomError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "ACTION_TOKEN_USED",
+		Message:    "action token already used",
+	}
 
 	// 401 Authentication/Authorization Errors
+	ErrInvalidAccessToken = CustomError{
+		StatusCode: http.StatusUnauthorized,
+		Code:       "INVALID_ACCESS_TOKEN",
+		Message:    "invalid or missing access token",
+	}
 	ErrInvalidCredentials = CustomError{
 		StatusCode: http.StatusUnauthorized,
+		Code:       "INVALID_CREDENTIALS",
 		Message:    "invalid credentials",
 	}
 
+	// 403 Forbidden
+	ErrPermissionDenied = CustomError{
+		StatusCode: http.StatusForbidden,
+		Code:       "PERMISSION_DENIED",
+		Message:    "insufficient permissions",
+	}
+	ErrUserDeactivated = CustomError{
+		StatusCode: http.StatusForbidden,
+		Code:       "USER_DEACTIVATED",
+		Message:    "user is deactivated",
+	}
+
 	// 404 Not Found
+	ErrRouteNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "ROUTE_NOT_FOUND",
+		Message:    "route not found",
+	}
 	ErrUserNotFound = CustomError{
 		StatusCode: http.StatusNotFound,
+		Code:       "USER_NOT_FOUND",
 		Message:    "user not found",
 	}
 	ErrStorageObjectNotFound = CustomError{
 		StatusCode: http.StatusNotFound,
+		Code:       "STORAGE_OBJECT_NOT_FOUND",
 		Message:    "object not found",
 	}
 	ErrPendingUploadNotFound = CustomError{
 		StatusCode: http.StatusNotFound,
+		Code:       "PENDING_UPLOAD_NOT_FOUND",
 		Message:    "pending upload not found",
 	}
+	ErrNotificationNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "NOTIFICATION_NOT_FOUND",
+		Message:    "notification not found",
+	}
+	ErrJobNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "JOB_NOT_FOUND",
+		Message:    "job not found",
+	}
+	ErrClassNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "CLASS_NOT_FOUND",
+		Message:    "class not found",
+	}
+	ErrMailNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "MAIL_NOT_FOUND",
+		Message:    "mail not found",
+	}
+	ErrInvitationNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "INVITATION_NOT_FOUND",
+		Message:    "invitation not found",
+	}
+	ErrHomeworkNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "HOMEWORK_NOT_FOUND",
+		Message:    "homework not found",
+	}
+	ErrHomeworkStudentNotFound = CustomError{
+		StatusCode: http.StatusNotFound,
+		Code:       "HOMEWORK_STUDENT_NOT_FOUND",
+		Message:    "homework is not given to the student",
+	}
+
+	// 409 Conflict
+	ErrJobAlreadyRunning = CustomError{
+		StatusCode: http.StatusConflict,
+		Code:       "JOB_ALREADY_RUNNING",
+		Message:    "job is already running",
+	}
+	ErrInvitationAlreadyPending = CustomError{
+		StatusCode: http.StatusConflict,
+		Code:       "INVITATION_ALREADY_PENDING",
+		Message:    "email already has a pending invitation to the class",
+	}
+	ErrInvitationNotPending = CustomError{
+		StatusCode: http.StatusConflict,
+		Code:       "INVITATION_NOT_PENDING",
+		Message:    "invitation is no longer pending",
+	}
+	ErrMailNotResendable = CustomError{
+		StatusCode: http.StatusConflict,
+		Code:       "MAIL_NOT_RESENDABLE",
+		Message:    "mail has a one-time link, request a new one instead",
+	}
+
+	// 413 Request Entity Too Large
+	ErrRosterFileTooLarge = CustomError{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Code:       "ROSTER_FILE_TOO_LARGE",
+		Message:    "file must not exceed 2 MiB",
+	}
+
+	// 415 Unsupported Media Type
+	ErrRosterUnsupportedType = CustomError{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Code:       "ROSTER_UNSUPPORTED_TYPE",
+		Message:    "roster must be a .csv or .xlsx file",
+	}
 )
 
 // ======================== HELPER FUNCTIONS ========================
 
+// HandleBusinessLogicErr responds with err when it is a CustomError, and with
+// ErrUnknown otherwise
 func HandleBusinessLogicErr(ctx *gin.Context, err error) {
 	var customErr CustomError
-	if errors.As(err, &customErr) {
-		ctx.JSON(customErr.StatusCode, gin.H{"error": customErr.Error()})
-	} else {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "unknown error"})
+	if !errors.As(err, &customErr) {
+		customErr = ErrUnknown
 	}
+	RespondError(ctx, customErr)
 }
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the envelope of every error response:
+//
+//	{"error": {"code": "USER_NOT_FOUND", "status": 404, "message": "user not found", "request_id": "..."}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code      string            `json:"code"`
+	Status    int               `json:"status"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// ProblemDetails is the RFC 7807 form of the envelope, responded to the
+// clients accepting application/problem+json
+type ProblemDetails struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code"`
+	Errors    map[string]string `json:"errors,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+const ContentTypeProblemJSON = "application/problem+json"
+
+// RespondError aborts the request with customErr, in the envelope or, when
+// the client accepts it, as RFC 7807 problem details
+func RespondError(ctx *gin.Context, customErr CustomError) {
+	requestID, _ := logging.RequestIDFromContext(ctx.Request.Context())
+
+	if strings.Contains(ctx.GetHeader("Accept"), ContentTypeProblemJSON) {
+		// Set first, so that the JSON render keeps it
+		ctx.Header("Content-Type", ContentTypeProblemJSON)
+		ctx.AbortWithStatusJSON(customErr.StatusCode, ProblemDetails{
+			Type:      "about:blank",
+			Title:     http.StatusText(customErr.StatusCode),
+			Status:    customErr.StatusCode,
+			Detail:    customErr.Message,
+			Instance:  ctx.Request.URL.Path,
+			Code:      customErr.Code,
+			Errors:    customErr.Details,
+			RequestID: requestID,
+		})
+		return
+	}
+
+	ctx.AbortWithStatusJSON(customErr.StatusCode, ErrorResponse{
+		Error: ErrorBody{
+			Code:      customErr.Code,
+			Status:    customErr.StatusCode,
+			Message:   customErr.Message,
+			Details:   customErr.Details,
+			RequestID: requestID,
+		},
+	})
+}
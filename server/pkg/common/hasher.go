@@ -1,19 +1,35 @@
 package common
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// UnusablePassword is stored for accounts without a password yet, e.g.
+// imported from a roster. It is not a bcrypt hash, so no password matches it.
+const UnusablePassword = "!"
+
 // The functions below are merely wrapper functions of bcrypt
 
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 12)
-	return string(bytes), fmt.Errorf("failed hashing the password %w", err)
+	if err != nil {
+		return "", fmt.Errorf("failed hashing the password: %w", err)
+	}
+	return string(bytes), nil
 }
 
 func CheckHashedPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// PasswordFingerprint identifies a password hash without revealing it, so
+// that a token bound to it is no longer valid once the password changes
+func PasswordFingerprint(hash string) string {
+	sum := sha256.Sum256([]byte(hash))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func GenerateJTWToken(claims jwt.MapClaims, jwtSecret string, jwtExpiresIn time.Duration) (string, error) {
-	// Compute token expiration
+	// Compute token expiration, on the wall clock that jwt checks it against
 	exp := time.Now().Add(jwtExpiresIn)
 	claims["exp"] = jwt.NewNumericDate(exp)
 
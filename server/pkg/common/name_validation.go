@@ -0,0 +1,96 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Punctuation allowed between the letters of a name, e.g. Jean-Luc O'Neil Jr.
+const namePunctuation = " -'’."
+
+// RegisterNameValidations registers the "name" tag, for names in any script
+// including the combining vowels and tone marks of Thai, and the
+// "romanized_name" tag, for their Latin spelling
+func RegisterNameValidations(validate *validator.Validate) error {
+	if err := validate.RegisterValidation("name", func(fl validator.FieldLevel) bool {
+		return IsValidName(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	return validate.RegisterValidation("romanized_name", func(fl validator.FieldLevel) bool {
+		return IsValidRomanizedName(fl.Field().String())
+	})
+}
+
+// NormalizeName composes name to NFC, so that a name typed with decomposed
+// marks is stored and compared the same, and collapses its spaces
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// NormalizeNamePtr is NormalizeName for the optional names, nil staying nil
+func NormalizeNamePtr(name *string) *string {
+	if name == nil {
+		return nil
+	}
+
+	normalized := NormalizeName(*name)
+	return &normalized
+}
+
+// IsValidName reports whether the normalized name is letters of any script,
+// each optionally followed by combining marks, separated by punctuation
+func IsValidName(name string) bool {
+	return isValidName(NormalizeName(name), unicode.IsLetter, true)
+}
+
+// IsValidRomanizedName is IsValidName for the Latin letters without marks
+func IsValidRomanizedName(name string) bool {
+	isLatin := func(r rune) bool {
+		return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+	}
+	return isValidName(NormalizeName(name), isLatin, false)
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// isValidName checks that name starts with a letter, that a mark only
+// follows a letter or a mark, and that a punctuation only follows a letter, a
+// mark or, for a space, a period
+func isValidName(name string, isLetter func(rune) bool, allowMarks bool) bool {
+	if name == "" {
+		return false
+	}
+
+	var previous rune
+	for i, r := range name {
+		switch {
+		case isLetter(r):
+		case allowMarks && unicode.Is(unicode.M, r):
+			if i == 0 || !isLetterOrMark(previous, isLetter) {
+				return false
+			}
+		case strings.ContainsRune(namePunctuation, r):
+			if i == 0 {
+				return false
+			}
+			if !isLetterOrMark(previous, isLetter) && (r != ' ' || previous != '.') {
+				return false
+			}
+		default:
+			return false
+		}
+		previous = r
+	}
+
+	// Only an abbreviation may end with punctuation
+	return isLetterOrMark(previous, isLetter) || previous == '.'
+}
+
+func isLetterOrMark(r rune, isLetter func(rune) bool) bool {
+	return isLetter(r) || unicode.Is(unicode.M, r)
+}
@@ -0,0 +1,99 @@
+// Package dates is the date and time policy of the API:
+//
+//   - Instants, e.g. created_at, are RFC 3339 with an offset, both in requests
+//     and responses ("2025-03-04T16:59:59Z" or "2025-03-04T23:59:59+07:00").
+//   - Calendar dates, e.g. "2025-03-04", are the days of a school, in its time
+//     zone. They are never turned into instants with the zone of the server.
+//   - A homework due on a date is due at the end of that day in the time zone
+//     of the school, see DueAt.
+package dates
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout of a calendar date in the API
+const Layout = "2006-01-02"
+
+// Date is a calendar date, without a time nor a zone. It is "2006-01-02" in
+// JSON and query strings.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses a date formatted as Layout
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(Layout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("dates: %q is not a date formatted as YYYY-MM-DD", value)
+	}
+	return DateOf(t), nil
+}
+
+// DateOf returns the calendar date of t in its location. Convert t to the time
+// zone of the school first.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DueAt is the instant a homework due on date is due: the last second of that
+// day in location, the time zone of the school. Not the last nanosecond, which
+// Postgres would round up to the next day.
+func DueAt(date Date, location *time.Location) time.Time {
+	return time.Date(date.Year, date.Month, date.Day, 23, 59, 59, 0, location)
+}
+
+// ======================== METHODS ========================
+
+func (date Date) String() string {
+	return date.StartIn(time.UTC).Format(Layout)
+}
+
+func (date Date) IsZero() bool {
+	return date == Date{}
+}
+
+// StartIn returns midnight of date in location
+func (date Date) StartIn(location *time.Location) time.Time {
+	return time.Date(date.Year, date.Month, date.Day, 0, 0, 0, 0, location)
+}
+
+// AddDays returns the date days later, or earlier when negative
+func (date Date) AddDays(days int) Date {
+	return DateOf(date.StartIn(time.UTC).AddDate(0, 0, days))
+}
+
+func (date Date) Weekday() time.Weekday {
+	return date.StartIn(time.UTC).Weekday()
+}
+
+func (date Date) Before(other Date) bool {
+	return date.StartIn(time.UTC).Before(other.StartIn(time.UTC))
+}
+
+func (date Date) After(other Date) bool {
+	return other.Before(date)
+}
+
+// DaysUntil counts the calendar days from date to other, negative if other is
+// before date
+func (date Date) DaysUntil(other Date) int {
+	return int(other.StartIn(time.UTC).Sub(date.StartIn(time.UTC)) / (24 * time.Hour))
+}
+
+func (date Date) MarshalText() ([]byte, error) {
+	return []byte(date.String()), nil
+}
+
+func (date *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*date = parsed
+	return nil
+}
@@ -0,0 +1,14 @@
+package digestfx
+
+import (
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"digestfx",
+	fx.Provide(
+		NewDigestService,
+		jobsfx.AsJob(NewDigestJob),
+	),
+)
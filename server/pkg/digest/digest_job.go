@@ -0,0 +1,52 @@
+package digestfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"go.uber.org/fx"
+)
+
+type DigestJobParams struct {
+	fx.In
+	DigestService *DigestService
+	Clock         clock.Clock
+}
+
+// DigestJob runs every hour, so that each user gets their digest at
+// DigestSendHour in their own time zone, see DigestService.SendDigests
+type DigestJob struct {
+	DigestService *DigestService
+	Clock         clock.Clock
+}
+
+func NewDigestJob(params DigestJobParams) *DigestJob {
+	return &DigestJob{
+		DigestService: params.DigestService,
+		Clock:         params.Clock,
+	}
+}
+
+func (job *DigestJob) Name() string {
+	return "digests"
+}
+
+func (job *DigestJob) Schedule() string {
+	return "0 * * * *"
+}
+
+func (job *DigestJob) Run(ctx context.Context) error {
+	now := job.Clock.Now()
+
+	var errs []error
+	for _, frequency := range []types.DigestFrequency{types.DigestFrequencyDaily, types.DigestFrequencyWeekly} {
+		if err := job.DigestService.SendDigests(ctx, frequency, now); err != nil {
+			errs = append(errs, fmt.Errorf("%s digests: %w", frequency, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
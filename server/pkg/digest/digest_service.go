@@ -0,0 +1,305 @@
+package digestfx
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DigestServiceParams struct {
+	fx.In
+	AppConfig          *configfx.AppConfig
+	Logger             *zap.Logger
+	DB                 *gorm.DB
+	MailService        *mailfx.MailService
+	PreferencesService preferencesfx.PreferencesServiceInterface
+}
+
+type DigestService struct {
+	AppConfig          *configfx.AppConfig
+	Logger             *zap.Logger
+	DB                 *gorm.DB
+	MailService        *mailfx.MailService
+	PreferencesService preferencesfx.PreferencesServiceInterface
+}
+
+func NewDigestService(params DigestServiceParams) *DigestService {
+	return &DigestService{
+		AppConfig:          params.AppConfig,
+		Logger:             params.Logger,
+		DB:                 params.DB,
+		MailService:        params.MailService,
+		PreferencesService: params.PreferencesService,
+	}
+}
+
+// Number of users loaded per database round trip
+const userBatchSize = 100
+
+// Row of the upcoming assignments query
+type upcomingItemRow struct {
+	StudentID    uuid.UUID
+	HomeworkID   uuid.UUID
+	HomeworkName string
+	ClassID      uuid.UUID
+	ClassName    string
+	ManHours     float64
+	AssignedAt   *time.Time
+	DueAt        time.Time
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// SendDigests sends a digest to every student and guardian subscribed to the frequency
+// whose digest is due at now in their time zone: from DigestSendHour, every day
+// or on Mondays. Digests are held during the quiet hours of the user, and sent on
+// the first run after them the same day. Users with nothing due in the window
+// are skipped. A user whose digest fails is logged and left for the next run.
+func (service *DigestService) SendDigests(
+	ctx context.Context,
+	frequency types.DigestFrequency,
+	now time.Time,
+) error {
+	var users []*models.User
+
+	// Users without preferences have the default frequency
+	result := service.DB.WithContext(ctx).
+		Select("users.*").
+		Joins("LEFT JOIN user_preferences AS p ON p.user_id = users.id").
+		Where("COALESCE(p.digest_frequency, ?) = ? AND users.deactivated_at IS NULL AND users.role IN ?",
+			models.DefaultDigestFrequency, frequency,
+			[]types.UserRole{types.UserRoleStudent, types.UserRoleGuardian}).
+		FindInBatches(&users, userBatchSize, func(tx *gorm.DB, batch int) error {
+			userIDs := make([]uuid.UUID, 0, len(users))
+			for _, user := range users {
+				userIDs = append(userIDs, user.ID)
+			}
+
+			preferences, err := service.PreferencesService.GetPreferencesByUserIDs(ctx, userIDs)
+			if err != nil {
+				return err
+			}
+
+			for _, user := range users {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				userPreferences := preferences[user.ID]
+				local := now.In(userPreferences.Location())
+				if !service.isDue(frequency, local) || userPreferences.InQuietHours(now) {
+					continue
+				}
+
+				service.sendDigest(ctx, user, frequency, local)
+			}
+			return nil
+		})
+	if result.Error != nil {
+		service.Logger.Error(
+			"Digest users database retrieval failed",
+			zap.String("frequency", string(frequency)),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase
+	}
+
+	return nil
+}
+
+// BuildDigest returns one section per student the user follows (themselves, or
+// their linked students for guardians). Students with nothing due are left out.
+// The days start at midnight in the location of now.
+func (service *DigestService) BuildDigest(
+	ctx context.Context,
+	user *models.User,
+	now time.Time,
+) ([]mailfx.DigestSection, error) {
+	students, err := service.getDigestStudents(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(students) == 0 {
+		return nil, nil
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+
+	daysAhead := service.AppConfig.DigestDaysAhead
+	windowStart := workload.StartOfDay(now)
+	windowEnd := windowStart.AddDate(0, 0, daysAhead)
+
+	var rows []upcomingItemRow
+	result := service.DB.WithContext(ctx).
+		Table("assignments AS a").
+		Select(`cs.student_id, h.id AS homework_id, h.name AS homework_name,
+			c.id AS class_id, c.name AS class_name, h.man_hours, a.assigned_at, a.due_at`).
+		Joins("JOIN class_students AS cs ON cs.class_id = a.class_id").
+		Joins("JOIN homework AS h ON h.id = a.homework_id").
+		Joins("JOIN classes AS c ON c.id = a.class_id").
+		Where("cs.student_id IN ? AND a.due_at >= ? AND a.due_at < ?",
+			studentIDs, windowStart, windowEnd).
+		Scopes(tenant.Column("c.school_id")).
+		Order("a.due_at").
+		Scan(&rows)
+	if result.Error != nil {
+		service.Logger.Error(
+			"Upcoming assignments database retrieval failed",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	itemsByStudent := make(map[uuid.UUID][]workload.Item)
+	for _, row := range rows {
+		itemsByStudent[row.StudentID] = append(itemsByStudent[row.StudentID], workload.Item{
+			HomeworkID:   row.HomeworkID,
+			HomeworkName: row.HomeworkName,
+			ClassID:      row.ClassID,
+			ClassName:    row.ClassName,
+			ManHours:     row.ManHours,
+			AssignedAt:   row.AssignedAt,
+			DueAt:        row.DueAt,
+		})
+	}
+
+	sections := []mailfx.DigestSection{}
+	for _, student := range students {
+		items, ok := itemsByStudent[student.ID]
+		if !ok {
+			continue
+		}
+
+		sections = append(sections, mailfx.DigestSection{
+			StudentName: fullName(student),
+			Days: workload.DailyLoad(
+				items,
+				now,
+				daysAhead,
+				service.AppConfig.DailyManHoursBudget,
+			),
+		})
+	}
+
+	return sections, nil
+}
+
+// ======================== HELPER METHODS ========================
+
+// isDue reports whether the digest of the frequency goes out at now, in the
+// time zone of its user
+func (service *DigestService) isDue(frequency types.DigestFrequency, now time.Time) bool {
+	if now.Hour() < service.AppConfig.DigestSendHour {
+		return false
+	}
+
+	return frequency == types.DigestFrequencyDaily || now.Weekday() == time.Monday
+}
+
+// sendDigest claims the digest of the day in digest_deliveries before building
+// it, so that the later runs of the day, or a rerun, skip the users it was
+// already sent to. An empty digest keeps its claim, there is nothing to send
+// that day. The claim is released if building or sending fails, so that the
+// next run retries. Failures are logged, they must not block the digest of
+// other users.
+func (service *DigestService) sendDigest(
+	ctx context.Context,
+	user *models.User,
+	frequency types.DigestFrequency,
+	now time.Time,
+) {
+	year, month, day := now.Date()
+	delivery := &models.DigestDelivery{
+		UserID:     user.ID,
+		Frequency:  frequency,
+		DigestDate: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		SentAt:     now,
+	}
+	logFields := []zap.Field{
+		zap.String("user_id", user.ID.String()),
+		zap.String("frequency", string(frequency)),
+	}
+
+	result := service.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(delivery)
+	if result.Error != nil {
+		service.Logger.Error("Digest delivery database creation failed",
+			append(logFields, zap.Error(result.Error))...)
+		return
+	}
+	if result.RowsAffected == 0 {
+		// Already delivered
+		return
+	}
+
+	sections, err := service.BuildDigest(ctx, user, now)
+	if err == nil && len(sections) > 0 {
+		err = service.MailService.SendDigest(ctx, user, frequency, sections)
+	}
+	if err == nil {
+		return
+	}
+
+	service.Logger.Error("Digest delivery failed", append(logFields, zap.Error(err))...)
+	result = service.DB.WithContext(ctx).Delete(delivery)
+	if result.Error != nil {
+		service.Logger.Error("Digest delivery database deletion failed",
+			append(logFields, zap.Error(result.Error))...)
+	}
+}
+
+func (service *DigestService) getDigestStudents(
+	ctx context.Context,
+	user *models.User,
+) ([]*models.User, error) {
+	if user.Role == types.UserRoleStudent {
+		return []*models.User{user}, nil
+	}
+
+	var links []*models.StudentGuardian
+	result := service.DB.WithContext(ctx).
+		Preload("Student").
+		Where("guardian_id = ?", user.ID).
+		Find(&links)
+	if result.Error != nil {
+		service.Logger.Error(
+			"Student guardians database retrieval failed",
+			zap.String("guardian_id", user.ID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	students := make([]*models.User, 0, len(links))
+	for _, link := range links {
+		students = append(students, &link.Student)
+	}
+
+	return students, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func fullName(user *models.User) string {
+	names := []string{user.FirstName, user.MiddleName, user.LastName}
+	return strings.Join(strings.Fields(strings.Join(names, " ")), " ")
+}
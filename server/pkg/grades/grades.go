@@ -0,0 +1,12 @@
+package gradesfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"gradesfx",
+	fx.Provide(
+		NewGradesRoutes,
+		NewGradesController,
+		NewGradeService,
+	),
+)
@@ -0,0 +1,91 @@
+package gradesfx
+
+import (
+	"net/http"
+
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type GradesControllerParams struct {
+	fx.In
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	GradeService GradeServiceInterface
+}
+
+type GradesController struct {
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	GradeService GradeServiceInterface
+}
+
+func NewGradesController(params GradesControllerParams) *GradesController {
+	return &GradesController{
+		Logger:       params.Logger,
+		AuthzService: params.AuthzService,
+		GradeService: params.GradeService,
+	}
+}
+
+// ======================== REQUEST BODY ========================
+
+type SetScoreBody struct {
+	Score *float64 `json:"score" binding:"omitempty,min=0"` // Null clears the score
+}
+
+// ======================== METHODS ========================
+
+func (controller *GradesController) SetScore(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	details := map[string]string{}
+
+	homeworkID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		details["id"] = "id is not uuid"
+	}
+	studentID, err := uuid.Parse(ctx.Param("studentId"))
+	if err != nil {
+		details["studentId"] = "studentId is not uuid"
+	}
+
+	if len(details) > 0 {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(details))
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionHomeworkGrade, homeworkID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	// Get validated body from context that set by RequestBodyValidator
+	validatedBody, _ := ctx.Get("validatedBody")
+	setScoreBody, _ := validatedBody.(*SetScoreBody)
+
+	grade, err := controller.GradeService.SetScore(
+		ctx.Request.Context(),
+		subject.UserID,
+		homeworkID,
+		studentID,
+		setScoreBody.Score,
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"grade": grade})
+}
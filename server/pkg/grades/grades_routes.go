@@ -0,0 +1,72 @@
+package gradesfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type GradesRoutesParams struct {
+	fx.In
+	Logger               *zap.Logger
+	Router               *gin.Engine
+	AuthMiddleware       *middlewarefx.AuthMiddleware
+	RequestBodyValidator *middlewarefx.RequestBodyValidator
+	GradesController     *GradesController
+}
+
+type GradesRoutes struct {
+	Logger               *zap.Logger
+	Router               *gin.Engine
+	AuthMiddleware       *middlewarefx.AuthMiddleware
+	RequestBodyValidator *middlewarefx.RequestBodyValidator
+	GradesController     *GradesController
+}
+
+func NewGradesRoutes(params GradesRoutesParams) *GradesRoutes {
+	return &GradesRoutes{
+		Logger:               params.Logger,
+		Router:               params.Router,
+		AuthMiddleware:       params.AuthMiddleware,
+		RequestBodyValidator: params.RequestBodyValidator,
+		GradesController:     params.GradesController,
+	}
+}
+
+func (routes *GradesRoutes) Setup() {
+	routes.Logger.Info("Setting up [Grades] routes.")
+
+	routes.Router.PUT(string(endpoints.SetHomeworkScoreV1)+"/:id/students/:studentId/score",
+		routes.AuthMiddleware.Handler(),
+		routes.RequestBodyValidator.Handler(SetScoreBody{}),
+		routes.GradesController.SetScore)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:  http.MethodPut,
+			Path:    string(endpoints.SetHomeworkScoreV1) + "/:id/students/:studentId/score",
+			ID:      "setHomeworkScore",
+			Tag:     "homework",
+			Summary: "Grade the homework of a student",
+			Auth:    openapifx.AuthUser,
+			PathParams: []openapifx.Param{
+				{Name: "id", Schema: uuid.UUID{}},
+				{Name: "studentId", Schema: uuid.UUID{}},
+			},
+			Body: SetScoreBody{},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"grade": models.HomeworkStudent{}}},
+			},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound},
+		},
+	}
+}
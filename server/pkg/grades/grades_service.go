@@ -0,0 +1,121 @@
+package gradesfx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GradeServiceParams struct {
+	fx.In
+	Logger       *zap.Logger
+	DB           *gorm.DB
+	AuditService auditfx.AuditServiceInterface
+}
+
+type GradeService struct {
+	Logger       *zap.Logger
+	DB           *gorm.DB
+	AuditService auditfx.AuditServiceInterface
+}
+
+type GradeServiceInterface interface {
+	SetScore(
+		ctx context.Context,
+		actorID uuid.UUID,
+		homeworkID uuid.UUID,
+		studentID uuid.UUID,
+		score *float64,
+	) (*models.HomeworkStudent, error)
+}
+
+// Verify interface implementation at compile time
+var _ GradeServiceInterface = (*GradeService)(nil)
+
+func NewGradeService(params GradeServiceParams) GradeServiceInterface {
+	return &GradeService{
+		Logger:       params.Logger,
+		DB:           params.DB,
+		AuditService: params.AuditService,
+	}
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// SetScore grades the homework of the student, nil clearing the score, and
+// records the change by the actor in the same transaction
+func (service *GradeService) SetScore(
+	ctx context.Context,
+	actorID uuid.UUID,
+	homeworkID uuid.UUID,
+	studentID uuid.UUID,
+	score *float64,
+) (*models.HomeworkStudent, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+	logFields := []zap.Field{
+		zap.String("homework_id", homeworkID.String()),
+		zap.String("student_id", studentID.String()),
+	}
+
+	var grade models.HomeworkStudent
+
+	err := service.DB.WithContext(ctx).Transaction(
+		func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				First(&grade, "homework_id = ? AND student_id = ?", homeworkID, studentID)
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return common.ErrHomeworkStudentNotFound
+			} else if result.Error != nil {
+				logger.Error("Homework student database retrieval failed",
+					append(logFields, zap.Error(result.Error))...)
+				return common.ErrDatabase
+			}
+
+			if sameScore(grade.Score, score) {
+				return nil
+			}
+			from := grade.Score
+
+			result = tx.Model(&models.HomeworkStudent{}).
+				Where("homework_id = ? AND student_id = ?", homeworkID, studentID).
+				Update("score", score)
+			if result.Error != nil {
+				logger.Error("Homework student score database update failed",
+					append(logFields, zap.Error(result.Error))...)
+				return common.ErrDatabase
+			}
+			grade.Score = score
+
+			return service.AuditService.RecordTx(tx, &models.AuditEvent{
+				Action:   types.AuditActionGradeChanged,
+				ActorID:  &actorID,
+				TargetID: &studentID,
+				Metadata: map[string]any{"homework_id": homeworkID, "from": from, "to": score},
+			})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grade, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func sameScore(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
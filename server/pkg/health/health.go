@@ -0,0 +1,12 @@
+package healthfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"healthfx",
+	fx.Provide(
+		NewHealthRoutes,
+		NewHealthController,
+		NewHealthService,
+	),
+)
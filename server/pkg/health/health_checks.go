@@ -0,0 +1,69 @@
+package healthfx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
+)
+
+// Timeouts of the checks, under the usual 5s timeout of a readiness probe
+const (
+	databaseCheckTimeout = 1 * time.Second
+	storageCheckTimeout  = 2 * time.Second
+	mailCheckTimeout     = 3 * time.Second
+)
+
+// databaseCheck pings a connection of the pool
+func databaseCheck(db *gorm.DB) Check {
+	return Check{
+		Name:    "database",
+		Timeout: databaseCheckTimeout,
+		Run: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// storageCheck asserts the bucket of the uploads exists
+func storageCheck(storageClient *minio.Client, bucketName string) Check {
+	return Check{
+		Name:    "storage",
+		Timeout: storageCheckTimeout,
+		Run: func(ctx context.Context) error {
+			exists, err := storageClient.BucketExists(ctx, bucketName)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("bucket %q does not exist", bucketName)
+			}
+			return nil
+		},
+	}
+}
+
+// mailCheck only dials the mail server. The mail client is not used, as it
+// holds a single connection shared with the mails being sent.
+func mailCheck(host string, port int) Check {
+	return Check{
+		Name:    "mail",
+		Timeout: mailCheckTimeout,
+		Run: func(ctx context.Context) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
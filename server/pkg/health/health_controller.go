@@ -0,0 +1,45 @@
+package healthfx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type HealthControllerParams struct {
+	fx.In
+	Logger        *zap.Logger
+	HealthService HealthServiceInterface
+}
+
+type HealthController struct {
+	Logger        *zap.Logger
+	HealthService HealthServiceInterface
+}
+
+func NewHealthController(params HealthControllerParams) *HealthController {
+	return &HealthController{
+		Logger:        params.Logger,
+		HealthService: params.HealthService,
+	}
+}
+
+// ======================== METHODS ========================
+
+// Liveness only tells the process serves requests, a failing dependency must
+// not get it restarted
+func (controller *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (controller *HealthController) Readiness(ctx *gin.Context) {
+	report := controller.HealthService.CheckReadiness(ctx.Request.Context())
+	if !report.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,64 @@
+package healthfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type HealthRoutesParams struct {
+	fx.In
+	Logger           *zap.Logger
+	Router           *gin.Engine
+	HealthController *HealthController
+}
+
+type HealthRoutes struct {
+	Logger           *zap.Logger
+	Router           *gin.Engine
+	HealthController *HealthController
+}
+
+func NewHealthRoutes(params HealthRoutesParams) *HealthRoutes {
+	return &HealthRoutes{
+		Logger:           params.Logger,
+		Router:           params.Router,
+		HealthController: params.HealthController,
+	}
+}
+
+func (routes *HealthRoutes) Setup() {
+	routes.Logger.Info("Setting up [Health] routes.")
+
+	// Unauthenticated, for the orchestrator
+	routes.Router.GET(string(endpoints.Liveness), routes.HealthController.Liveness)
+	routes.Router.GET(string(endpoints.Readiness), routes.HealthController.Readiness)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.Liveness),
+			ID:        "getLiveness",
+			Tag:       "health",
+			Summary:   "Tell the process serves requests",
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: openapifx.Object{"status": ""}}},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    string(endpoints.Readiness),
+			ID:      "getReadiness",
+			Tag:     "health",
+			Summary: "Check the dependencies of the server",
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: ReadinessReport{}},
+				{Status: http.StatusServiceUnavailable, Body: ReadinessReport{}, Description: "Unavailable or draining"},
+			},
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package healthfx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type HealthServiceParams struct {
+	fx.In
+	AppConfig     *configfx.AppConfig
+	Logger        *zap.Logger
+	DB            *gorm.DB
+	StorageClient *minio.Client
+}
+
+type HealthService struct {
+	Logger   *zap.Logger
+	Checks   []Check
+	draining atomic.Bool
+}
+
+type HealthServiceInterface interface {
+	CheckReadiness(ctx context.Context) *ReadinessReport
+	StartDraining()
+}
+
+// Verify interface implementation at compile time
+var _ HealthServiceInterface = (*HealthService)(nil)
+
+func NewHealthService(params HealthServiceParams) HealthServiceInterface {
+	checks := []Check{
+		databaseCheck(params.DB),
+		storageCheck(params.StorageClient, params.AppConfig.StorageBucketName),
+	}
+	if params.AppConfig.HealthCheckMail {
+		checks = append(checks, mailCheck(params.AppConfig.MailHost, params.AppConfig.MailPort))
+	}
+
+	return &HealthService{
+		Logger: params.Logger,
+		Checks: checks,
+	}
+}
+
+// Check is a dependency the server is not ready without
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type CheckStatus string
+
+const (
+	CheckStatusOK      CheckStatus = "ok"
+	CheckStatusFailed  CheckStatus = "failed"
+	CheckStatusTimeout CheckStatus = "timeout"
+)
+
+type CheckResult struct {
+	Status     CheckStatus `json:"status"`
+	DurationMs int64       `json:"duration_ms"`
+}
+
+type ReadinessStatus string
+
+const (
+	ReadinessStatusReady       ReadinessStatus = "ready"
+	ReadinessStatusUnavailable ReadinessStatus = "unavailable"
+	ReadinessStatusDraining    ReadinessStatus = "draining"
+)
+
+// ReadinessReport has no check results while draining
+type ReadinessReport struct {
+	Status ReadinessStatus         `json:"status"`
+	Checks map[string]*CheckResult `json:"checks,omitempty"`
+}
+
+func (report *ReadinessReport) Ready() bool {
+	return report.Status == ReadinessStatusReady
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// CheckReadiness runs every check concurrently, each within its own timeout.
+// The errors are logged, never reported, as the endpoint is public.
+func (service *HealthService) CheckReadiness(ctx context.Context) *ReadinessReport {
+	if service.draining.Load() {
+		return &ReadinessReport{Status: ReadinessStatusDraining}
+	}
+
+	results := make([]*CheckResult, len(service.Checks))
+
+	var wg sync.WaitGroup
+	for i, check := range service.Checks {
+		wg.Go(func() {
+			results[i] = service.runCheck(ctx, check)
+		})
+	}
+	wg.Wait()
+
+	report := &ReadinessReport{
+		Status: ReadinessStatusReady,
+		Checks: make(map[string]*CheckResult, len(service.Checks)),
+	}
+	for i, check := range service.Checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != CheckStatusOK {
+			report.Status = ReadinessStatusUnavailable
+		}
+	}
+
+	return report
+}
+
+// StartDraining fails the readiness from now on, for the server to be taken
+// out of the load balancer before it stops accepting connections
+func (service *HealthService) StartDraining() {
+	service.draining.Store(true)
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *HealthService) runCheck(ctx context.Context, check Check) *CheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(checkCtx)
+	result := &CheckResult{
+		Status:     CheckStatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Status = CheckStatusFailed
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+			result.Status = CheckStatusTimeout
+		}
+
+		service.Logger.Warn("Readiness check failed",
+			zap.String("check", check.Name),
+			zap.Duration("timeout", check.Timeout),
+			zap.Error(err),
+		)
+	}
+
+	return result
+}
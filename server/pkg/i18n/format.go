@@ -0,0 +1,26 @@
+package i18n
+
+import "time"
+
+// Layouts of the time of day by locale. The short time of the English CLDR
+// data of go-playground reads "0:05 am" after midnight.
+var timeLayouts = map[string]string{
+	"en": "3:04 PM",
+	"th": "15:04 น.",
+}
+
+// FormatDate formats the date of t in locale, e.g. "Mar 4, 2025" or
+// "4 มี.ค. 2025". Convert t to the time zone of the reader first.
+func FormatDate(locale string, t time.Time) string {
+	return translator(locale).FmtDateMedium(t)
+}
+
+// FormatDateTime is FormatDate with the time and the zone of t, e.g.
+// "Mar 4, 2025 5:05 PM (+07)"
+func FormatDateTime(locale string, t time.Time) string {
+	layout, ok := timeLayouts[locale]
+	if !ok {
+		layout = timeLayouts["en"]
+	}
+	return FormatDate(locale, t) + " " + t.Format(layout) + " (" + t.Format("MST") + ")"
+}
@@ -0,0 +1,85 @@
+// Package i18n carries the locale of a request in its context, and translates
+// the messages shown to users through the universal translator, with a
+// catalogue per locale.
+//
+// The router selects the locale from the Accept-Language header. Services
+// translate through FromContext so that tgsctl and jobs fall back to English.
+package i18n
+
+import (
+	"context"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/th"
+	ut "github.com/go-playground/universal-translator"
+	"golang.org/x/text/language"
+)
+
+const (
+	LocaleEnglish = "en"
+	LocaleThai    = "th"
+)
+
+// Locales are the supported locales, the first being the default
+var Locales = []string{LocaleEnglish, LocaleThai}
+
+var (
+	matcher = language.NewMatcher([]language.Tag{language.English, language.Thai})
+
+	universal = newUniversalTranslator()
+)
+
+type localeKey struct{}
+
+// WithLocale returns ctx carrying the locale of its request
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale ctx carries, or the default one
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return Locales[0]
+	}
+
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	return Locales[0]
+}
+
+// MatchAcceptLanguage returns the supported locale closest to an
+// Accept-Language header, the default one when none is close
+func MatchAcceptLanguage(header string) string {
+	tag, _ := language.MatchStrings(matcher, header)
+	base, _ := tag.Base()
+
+	return base.String()
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// newUniversalTranslator panics on an invalid catalogue, as it is static
+func newUniversalTranslator() *ut.UniversalTranslator {
+	universal := ut.New(en.New(), en.New(), th.New())
+
+	catalogues := map[string]map[string]string{
+		LocaleEnglish: validationCatalogueEnglish,
+		LocaleThai:    validationCatalogueThai,
+	}
+	for locale, catalogue := range catalogues {
+		translator, _ := universal.GetTranslator(locale)
+		for key, text := range catalogue {
+			if err := translator.Add(key, text, false); err != nil {
+				panic("i18n: " + locale + " catalogue: " + err.Error())
+			}
+		}
+	}
+
+	return universal
+}
+
+func translator(locale string) ut.Translator {
+	translator, _ := universal.GetTranslator(locale)
+	return translator // The fallback, English, when locale is not supported
+}
@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Kinds of a field, for the tags whose message depends on it e.g. min
+const (
+	kindString = "string"
+	kindNumber = "number"
+	kindItems  = "items"
+)
+
+// Tags with a message per kind of field
+var kindedTags = []string{"min", "max", "len"}
+
+// Tags that never fail, so have no message
+var silentTags = []string{"omitempty", "dive"}
+
+// Tags whose parameter is other fields, named by their Go name
+var fieldParamTags = []string{"required_with", "required_without"}
+
+// ValidationMessage translates the failed validation of a field to locale.
+// Alternatives such as len=0|alpha are joined with "or", the empty one only
+// making the field optional.
+func ValidationMessage(locale string, fieldError validator.FieldError) string {
+	translator := translator(locale)
+	field := fieldError.Field()
+
+	alternatives := strings.Split(fieldError.Tag(), "|")
+	if len(alternatives) == 1 {
+		return message(translator, field, fieldError.Tag(), fieldError.Param(), fieldError.Kind())
+	}
+
+	var messages []string
+	for _, alternative := range alternatives {
+		tag, param, _ := strings.Cut(alternative, "=")
+		if (tag == "len" || tag == "max") && param == "0" {
+			continue
+		}
+		messages = append(messages, message(translator, field, tag, param, fieldError.Kind()))
+	}
+
+	joined := messages[0]
+	for _, message := range messages[1:] {
+		joined, _ = translator.T("or", joined, message)
+	}
+	return joined
+}
+
+// HasValidationMessage reports whether locale has a message for tag, e.g. to
+// check that every tag of the binding structs is covered
+func HasValidationMessage(locale, tag string) bool {
+	if slices.Contains(silentTags, tag) {
+		return true
+	}
+
+	keys := []string{tag}
+	if slices.Contains(kindedTags, tag) {
+		keys = []string{tag + "." + kindString, tag + "." + kindNumber, tag + "." + kindItems}
+	}
+
+	translator := translator(locale)
+	if translator.Locale() != locale {
+		return false
+	}
+	for _, key := range keys {
+		if _, err := translator.T(key, "", ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func message(translator ut.Translator, field, tag, param string, kind reflect.Kind) string {
+	key := tag
+	if slices.Contains(kindedTags, tag) {
+		key = tag + "." + kindOf(kind)
+	}
+	if tag == "oneof" {
+		param = strings.Join(oneofValues(param), ", ")
+	}
+	if slices.Contains(fieldParamTags, tag) {
+		param = strings.Join(jsonNames(param), ", ")
+	}
+
+	text, err := translator.T(key, field, param)
+	if err != nil {
+		text, _ = translator.T("invalid", field)
+	}
+	return text
+}
+
+func kindOf(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return kindString
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return kindItems
+	default:
+		return kindNumber
+	}
+}
+
+// oneofValues splits the parameter of oneof, e.g. 'prefer not' other
+func oneofValues(param string) []string {
+	var values []string
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if quoted, ok := strings.CutPrefix(param, "'"); ok {
+			value, rest, _ := strings.Cut(quoted, "'")
+			values = append(values, value)
+			param = rest
+			continue
+		}
+		value, rest, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = rest
+	}
+	return values
+}
+
+// jsonNames names the fields of a tag parameter as in the JSON bodies, e.g.
+// QuietHoursEnd is quiet_hours_end
+func jsonNames(param string) []string {
+	var names []string
+	for field := range strings.FieldsSeq(param) {
+		var name strings.Builder
+		for i, r := range field {
+			if unicode.IsUpper(r) {
+				if i > 0 {
+					name.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			name.WriteRune(r)
+		}
+		names = append(names, name.String())
+	}
+	return names
+}
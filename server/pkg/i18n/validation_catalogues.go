@@ -0,0 +1,70 @@
+package i18n
+
+// Validation messages by tag, {0} being the field and {1} the parameter of
+// the tag. min, max and len have a message per kind of field.
+
+var validationCatalogueEnglish = map[string]string{
+	"invalid": "{0} is invalid",
+	"or":      "{0}, or {1}",
+
+	"required": "{0} is required",
+	"email":    "Please provide a valid email address",
+	"e164":     "Please provide a valid phone number in e164 format",
+	"alpha":    "{0} must contain only letters",
+	"number":   "{0} must be a valid number",
+	"oneof":    "{0} must be one of: {1}",
+	"jwt":      "{0} must be a valid token",
+	"uuid":     "{0} must be a valid UUID",
+	"url":      "{0} must be a valid URL",
+	"timezone": "{0} must be an IANA time zone, e.g. Asia/Bangkok",
+	"datetime": "{0} must be formatted as {1}",
+
+	"required_with":    "{0} is required when {1} is set",
+	"required_without": "{0} is required when {1} is not set",
+
+	"name":           "{0} must contain only letters, spaces, hyphens, apostrophes or periods",
+	"romanized_name": "{0} must contain only Latin letters, spaces, hyphens, apostrophes or periods",
+
+	"min.string": "{0} must be at least {1} characters long",
+	"min.number": "{0} must be at least {1}",
+	"min.items":  "{0} must contain at least {1} items",
+	"max.string": "{0} must not exceed {1} characters",
+	"max.number": "{0} must not exceed {1}",
+	"max.items":  "{0} must not contain more than {1} items",
+	"len.string": "{0} must be exactly {1} characters long",
+	"len.number": "{0} must be equal to {1}",
+	"len.items":  "{0} must contain exactly {1} items",
+}
+
+var validationCatalogueThai = map[string]string{
+	"invalid": "{0} ไม่ถูกต้อง",
+	"or":      "{0} หรือ {1}",
+
+	"required": "กรุณาระบุ {0}",
+	"email":    "กรุณาระบุอีเมลที่ถูกต้อง",
+	"e164":     "กรุณาระบุหมายเลขโทรศัพท์ในรูปแบบ e164",
+	"alpha":    "{0} ต้องประกอบด้วยตัวอักษรเท่านั้น",
+	"number":   "{0} ต้องเป็นตัวเลข",
+	"oneof":    "{0} ต้องเป็นค่าใดค่าหนึ่งต่อไปนี้: {1}",
+	"jwt":      "{0} ต้องเป็นโทเค็นที่ถูกต้อง",
+	"uuid":     "{0} ต้องเป็น UUID ที่ถูกต้อง",
+	"url":      "{0} ต้องเป็น URL ที่ถูกต้อง",
+	"timezone": "{0} ต้องเป็นเขตเวลา IANA เช่น Asia/Bangkok",
+	"datetime": "{0} ต้องอยู่ในรูปแบบ {1}",
+
+	"required_with":    "กรุณาระบุ {0} เมื่อระบุ {1}",
+	"required_without": "กรุณาระบุ {0} เมื่อไม่ได้ระบุ {1}",
+
+	"name":           "{0} ต้องประกอบด้วยตัวอักษร ช่องว่าง ยัติภังค์ อะพอสทรอฟี หรือจุดเท่านั้น",
+	"romanized_name": "{0} ต้องประกอบด้วยอักษรละติน ช่องว่าง ยัติภังค์ อะพอสทรอฟี หรือจุดเท่านั้น",
+
+	"min.string": "{0} ต้องมีความยาวอย่างน้อย {1} ตัวอักษร",
+	"min.number": "{0} ต้องมีค่าอย่างน้อย {1}",
+	"min.items":  "{0} ต้องมีอย่างน้อย {1} รายการ",
+	"max.string": "{0} ต้องมีความยาวไม่เกิน {1} ตัวอักษร",
+	"max.number": "{0} ต้องมีค่าไม่เกิน {1}",
+	"max.items":  "{0} ต้องมีไม่เกิน {1} รายการ",
+	"len.string": "{0} ต้องมีความยาว {1} ตัวอักษรพอดี",
+	"len.number": "{0} ต้องมีค่าเท่ากับ {1}",
+	"len.items":  "{0} ต้องมี {1} รายการพอดี",
+}
@@ -0,0 +1,12 @@
+package invitationsfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"invitationsfx",
+	fx.Provide(
+		NewInvitationsRoutes,
+		NewInvitationsController,
+		NewInvitationService,
+	),
+)
@@ -0,0 +1,178 @@
+package invitationsfx
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type InvitationsControllerParams struct {
+	fx.In
+	Logger            *zap.Logger
+	AuthzService      authzfx.AuthzServiceInterface
+	InvitationService InvitationServiceInterface
+}
+
+type InvitationsController struct {
+	Logger            *zap.Logger
+	AuthzService      authzfx.AuthzServiceInterface
+	InvitationService InvitationServiceInterface
+}
+
+func NewInvitationsController(params InvitationsControllerParams) *InvitationsController {
+	return &InvitationsController{
+		Logger:            params.Logger,
+		AuthzService:      params.AuthzService,
+		InvitationService: params.InvitationService,
+	}
+}
+
+// ======================== REQUEST BODY ========================
+
+type CreateInvitationBody struct {
+	Email     string         `json:"email"      binding:"required,email,max=255"`
+	Role      types.UserRole `json:"role"       binding:"required,oneof='student' 'teacher'"`
+	SchoolNum string         `json:"school_num" binding:"required,number,max=16"`
+}
+
+// ======================== METHODS ========================
+
+func (controller *InvitationsController) CreateInvitation(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	classID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	// Get validated body from context that set by RequestBodyValidator
+	validatedBody, _ := ctx.Get("validatedBody")
+	createInvitationBody, _ := validatedBody.(*CreateInvitationBody)
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionClassManage, classID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	invitation, err := controller.InvitationService.CreateInvitation(
+		ctx.Request.Context(),
+		subject.UserID,
+		classID,
+		createInvitationBody,
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"invitation": invitation})
+}
+
+// GetClassInvitations lists the invitations of a class, optionally by ?status=
+func (controller *InvitationsController) GetClassInvitations(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	classID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	status := types.InvitationStatus(ctx.Query("status"))
+	validStatuses := []types.InvitationStatus{
+		"",
+		types.InvitationStatusPending,
+		types.InvitationStatusAccepted,
+		types.InvitationStatusRevoked,
+		types.InvitationStatusExpired,
+	}
+	if !slices.Contains(validStatuses, status) {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{
+			"status": "status must be pending, accepted, revoked or expired",
+		}))
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionClassManage, classID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	invitations, err := controller.InvitationService.GetInvitationsByClassID(
+		ctx.Request.Context(),
+		classID,
+		status,
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+func (controller *InvitationsController) RevokeInvitation(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	invitationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionInvitationRevoke, invitationID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	invitation, err := controller.InvitationService.RevokeInvitation(ctx.Request.Context(), invitationID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"invitation": invitation})
+}
+
+// GetInvitationByToken lets the client pre-fill the registration form
+func (controller *InvitationsController) GetInvitationByToken(ctx *gin.Context) {
+	invitation, err := controller.InvitationService.GetInvitationByToken(
+		ctx.Request.Context(),
+		ctx.Param("invitationToken"),
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"invitation": invitation})
+}
@@ -0,0 +1,117 @@
+package invitationsfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type InvitationsRoutesParams struct {
+	fx.In
+	Logger                *zap.Logger
+	Router                *gin.Engine
+	AuthMiddleware        *middlewarefx.AuthMiddleware
+	RequestBodyValidator  *middlewarefx.RequestBodyValidator
+	InvitationsController *InvitationsController
+}
+
+type InvitationsRoutes struct {
+	Logger                *zap.Logger
+	Router                *gin.Engine
+	AuthMiddleware        *middlewarefx.AuthMiddleware
+	RequestBodyValidator  *middlewarefx.RequestBodyValidator
+	InvitationsController *InvitationsController
+}
+
+func NewInvitationsRoutes(params InvitationsRoutesParams) *InvitationsRoutes {
+	return &InvitationsRoutes{
+		Logger:                params.Logger,
+		Router:                params.Router,
+		AuthMiddleware:        params.AuthMiddleware,
+		RequestBodyValidator:  params.RequestBodyValidator,
+		InvitationsController: params.InvitationsController,
+	}
+}
+
+func (routes *InvitationsRoutes) Setup() {
+	routes.Logger.Info("Setting up [Invitations] routes.")
+
+	routes.Router.POST(string(endpoints.CreateInvitationV1)+"/:id/invitations",
+		routes.AuthMiddleware.Handler(),
+		routes.RequestBodyValidator.Handler(CreateInvitationBody{}),
+		routes.InvitationsController.CreateInvitation)
+
+	routes.Router.GET(string(endpoints.GetClassInvitationsV1)+"/:id/invitations",
+		routes.AuthMiddleware.Handler(),
+		routes.InvitationsController.GetClassInvitations)
+
+	routes.Router.DELETE(string(endpoints.RevokeInvitationV1)+"/:id",
+		routes.AuthMiddleware.Handler(),
+		routes.InvitationsController.RevokeInvitation)
+
+	routes.Router.GET(string(endpoints.GetInvitationByTokenV1)+"/:invitationToken",
+		routes.InvitationsController.GetInvitationByToken)
+}
+
+func Operations() []openapifx.Operation {
+	invitationResponse := openapifx.Object{"invitation": InvitationView{}}
+	idParams := []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}}
+
+	return []openapifx.Operation{
+		{
+			Method:     http.MethodPost,
+			Path:       string(endpoints.CreateInvitationV1) + "/:id/invitations",
+			ID:         "createInvitation",
+			Tag:        "classes",
+			Summary:    "Invite a student or teacher to a class",
+			Auth:       openapifx.AuthUser,
+			PathParams: idParams,
+			Body:       CreateInvitationBody{},
+			Responses:  []openapifx.Response{{Status: http.StatusCreated, Body: invitationResponse}},
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       string(endpoints.GetClassInvitationsV1) + "/:id/invitations",
+			ID:         "getClassInvitations",
+			Tag:        "classes",
+			Summary:    "List the invitations to a class",
+			Auth:       openapifx.AuthUser,
+			PathParams: idParams,
+			Query:      []openapifx.Param{{Name: "status", Schema: types.InvitationStatus("")}},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"invitations": []*InvitationView{}}},
+			},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       string(endpoints.RevokeInvitationV1) + "/:id",
+			ID:         "revokeInvitation",
+			Tag:        "invitations",
+			Summary:    "Revoke a pending invitation",
+			Auth:       openapifx.AuthUser,
+			PathParams: idParams,
+			Responses:  []openapifx.Response{{Status: http.StatusOK, Body: invitationResponse}},
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    string(endpoints.GetInvitationByTokenV1) + "/:invitationToken",
+			ID:      "getInvitationByToken",
+			Tag:     "invitations",
+			Summary: "Preview an invitation before registering with it",
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"invitation": InvitationPreview{}}},
+			},
+			Errors: []int{http.StatusNotFound},
+		},
+	}
+}
@@ -0,0 +1,512 @@
+package invitationsfx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvitationServiceParams struct {
+	fx.In
+	AppConfig   *configfx.AppConfig
+	Logger      *zap.Logger
+	DB          *gorm.DB
+	MailService *mailfx.MailService
+	Clock       clock.Clock
+}
+
+type InvitationService struct {
+	AppConfig   *configfx.AppConfig
+	Logger      *zap.Logger
+	DB          *gorm.DB
+	MailService *mailfx.MailService
+	Clock       clock.Clock
+}
+
+type InvitationServiceInterface interface {
+	CreateInvitation(ctx context.Context, inviterID uuid.UUID, classID uuid.UUID, body *CreateInvitationBody) (*InvitationView, error)
+	GetInvitationsByClassID(ctx context.Context, classID uuid.UUID, status types.InvitationStatus) ([]*InvitationView, error)
+	RevokeInvitation(ctx context.Context, invitationID uuid.UUID) (*InvitationView, error)
+	GetInvitationByToken(ctx context.Context, invitationToken string) (*InvitationPreview, error)
+	AcceptInvitation(ctx context.Context, invitationToken string, user *models.User) error
+}
+
+// Verify interface implementation at compile time
+var _ InvitationServiceInterface = (*InvitationService)(nil)
+
+// InvitationView is an invitation with its derived status
+type InvitationView struct {
+	*models.Invitation
+	Status types.InvitationStatus `json:"status"`
+}
+
+// InvitationPreview is what the invitee sees before registering
+type InvitationPreview struct {
+	Email     string         `json:"email"`
+	Role      types.UserRole `json:"role"`
+	SchoolNum string         `json:"school_num"`
+	ClassName string         `json:"class_name"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
+
+func NewInvitationService(params InvitationServiceParams) InvitationServiceInterface {
+	return &InvitationService{
+		AppConfig:   params.AppConfig,
+		Logger:      params.Logger,
+		DB:          params.DB,
+		MailService: params.MailService,
+		Clock:       params.Clock,
+	}
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// CreateInvitation records the invitation and mails the signed link to the invitee
+func (service *InvitationService) CreateInvitation(
+	ctx context.Context,
+	inviterID uuid.UUID,
+	classID uuid.UUID,
+	body *CreateInvitationBody,
+) (*InvitationView, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	class, err := service.getClass(ctx, service.DB.WithContext(ctx), classID)
+	if err != nil {
+		return nil, err
+	}
+
+	inviter := &models.User{}
+	if result := service.DB.WithContext(ctx).First(inviter, inviterID); result.Error != nil {
+		logger.Error(
+			"Inviter database retrieval failed",
+			zap.String("user_id", inviterID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	email := strings.ToLower(body.Email)
+
+	// Existing users are enrolled directly instead. Emails are unique across schools.
+	var count int64
+	result := service.DB.WithContext(tenant.Bypass(ctx)).Model(&models.User{}).Where("LOWER(email) = ?", email).Count(&count)
+	if result.Error != nil {
+		logger.Error("User database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+	if count > 0 {
+		logger.Debug(
+			"Invitation creation skipped",
+			zap.String("reason", "email_duplicated"),
+			zap.String("email", email),
+		)
+		return nil, common.ErrDuplicatedEmail
+	}
+
+	now := service.Clock.Now()
+	expiresIn := time.Duration(service.AppConfig.InvitationExpiresIn) * 24 * time.Hour
+
+	invitation := &models.Invitation{
+		ClassID:   classID,
+		SchoolID:  class.SchoolID,
+		InviterID: inviterID,
+		Email:     email,
+		Role:      body.Role,
+		SchoolNum: body.SchoolNum,
+		ExpiresAt: now.Add(expiresIn),
+	}
+
+	inviterName := strings.TrimSpace(inviter.FirstName + " " + inviter.LastName)
+
+	// The invitation is only kept once it is mailed, so that a failed mail does
+	// not block inviting the email again
+	err = service.DB.WithContext(ctx).Transaction(
+		func(tx *gorm.DB) error {
+			// The unique index cannot tell an expired invitation from a pending one, so
+			// the expired one is closed at its expiry, which it still reads as
+			result := tx.Model(&models.Invitation{}).
+				Where("class_id = ? AND LOWER(email) = ?", classID, email).
+				Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", now).
+				Update("revoked_at", gorm.Expr("expires_at"))
+			if result.Error != nil {
+				logger.Error("Invitation database update failed", zap.Error(result.Error))
+				return common.ErrDatabase
+			}
+
+			result = tx.Create(invitation)
+			if result.Error != nil {
+				// Unique index on the open invitations of a class
+				if strings.Contains(result.Error.Error(), "SQLSTATE 23505") {
+					logger.Debug(
+						"Invitation database creation skipped",
+						zap.String("reason", "invitation_pending"),
+						zap.String("class_id", classID.String()),
+						zap.String("email", email),
+					)
+					return common.ErrInvitationAlreadyPending
+				}
+
+				logger.Error("Invitation database creation failed", zap.Error(result.Error))
+				return common.ErrDatabase
+			}
+
+			invitationToken, err := common.GenerateJTWToken(
+				jwt.MapClaims{"typ": types.ActionTokenTypeInvitation, "invitation_id": invitation.ID, "email": email},
+				service.AppConfig.JWTSecret,
+				expiresIn)
+			if err != nil {
+				logger.Error("JWT invitation token generation failed", zap.Error(err))
+				return common.ErrTokenGeneration
+			}
+
+			return service.MailService.SendClassInvitation(ctx, invitation, class.Name, inviterName, invitationToken)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toView(invitation, now), nil
+}
+
+// GetInvitationsByClassID lists the invitations of a class, newest first.
+// An empty status lists all of them.
+func (service *InvitationService) GetInvitationsByClassID(
+	ctx context.Context,
+	classID uuid.UUID,
+	status types.InvitationStatus,
+) ([]*InvitationView, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	now := service.Clock.Now()
+	query := service.DB.WithContext(ctx).Where("class_id = ?", classID)
+
+	switch status {
+	case types.InvitationStatusPending:
+		query = query.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+	case types.InvitationStatusAccepted:
+		query = query.Where("accepted_at IS NOT NULL")
+	case types.InvitationStatusRevoked:
+		query = query.Where("revoked_at < expires_at")
+	case types.InvitationStatusExpired:
+		query = query.Where(
+			"accepted_at IS NULL AND (revoked_at IS NULL OR revoked_at >= expires_at) AND expires_at <= ?",
+			now,
+		)
+	}
+
+	var invitations []*models.Invitation
+	result := query.Order("created_at DESC").Find(&invitations)
+	if result.Error != nil {
+		logger.Error(
+			"Invitations database retrieval failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	views := make([]*InvitationView, 0, len(invitations))
+	for _, invitation := range invitations {
+		views = append(views, toView(invitation, now))
+	}
+
+	return views, nil
+}
+
+// RevokeInvitation makes a pending invitation unusable
+func (service *InvitationService) RevokeInvitation(
+	ctx context.Context,
+	invitationID uuid.UUID,
+) (*InvitationView, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	invitation := &models.Invitation{}
+
+	result := service.DB.WithContext(ctx).First(invitation, invitationID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, common.ErrInvitationNotFound
+	} else if result.Error != nil {
+		logger.Error(
+			"Invitation database retrieval failed",
+			zap.String("invitation_id", invitationID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	now := service.Clock.Now()
+
+	// Guarded by the update itself, the invitation may be accepted meanwhile
+	result = service.DB.WithContext(ctx).
+		Model(invitation).
+		Clauses(clause.Returning{}).
+		Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		logger.Error(
+			"Invitation database update failed",
+			zap.String("invitation_id", invitationID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+	if result.RowsAffected == 0 {
+		logger.Debug(
+			"Invitation revocation skipped",
+			zap.String("reason", "not_pending"),
+			zap.String("invitation_id", invitationID.String()),
+		)
+		return nil, common.ErrInvitationNotPending
+	}
+
+	return toView(invitation, now), nil
+}
+
+// GetInvitationByToken returns the pre-filled fields of a pending invitation
+func (service *InvitationService) GetInvitationByToken(
+	ctx context.Context,
+	invitationToken string,
+) (*InvitationPreview, error) {
+	invitationID, err := service.parseInvitationToken(invitationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// The link is opened before any login, the invitation tells the school
+	invitation, err := service.getPendingInvitation(service.DB.WithContext(tenant.Bypass(ctx)), invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tenant.WithSchool(ctx, invitation.SchoolID)
+	class, err := service.getClass(ctx, service.DB, invitation.ClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InvitationPreview{
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		SchoolNum: invitation.SchoolNum,
+		ClassName: class.Name,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation creates the user with the email, role and school number of
+// the invitation, and enrols them into the class. The user's password is hashed here.
+func (service *InvitationService) AcceptInvitation(
+	ctx context.Context,
+	invitationToken string,
+	user *models.User,
+) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	invitationID, err := service.parseInvitationToken(invitationToken)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := common.HashPassword(user.Password)
+	if err != nil {
+		logger.Error("Password hashing failed", zap.Error(err))
+		return common.ErrPasswordHashing
+	}
+
+	return service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locked so that the same link cannot register twice. The link is opened
+		// before any login, the invitation tells the school.
+		invitation, err := service.getPendingInvitation(
+			tx.WithContext(tenant.Bypass(ctx)).Clauses(clause.Locking{Strength: "UPDATE"}),
+			invitationID,
+		)
+		if err != nil {
+			return err
+		}
+		tx = tx.WithContext(tenant.WithSchool(ctx, invitation.SchoolID))
+
+		schoolNum := invitation.SchoolNum
+		user.Email = invitation.Email
+		user.Role = invitation.Role
+		user.SchoolNum = &schoolNum
+		user.SchoolID = &invitation.SchoolID
+		user.Password = hashed
+
+		if result := tx.Create(user); result.Error != nil {
+			if strings.Contains(result.Error.Error(), "SQLSTATE 23505") {
+				logger.Debug(
+					"User database creation skipped",
+					zap.String("reason", "email_duplicated"),
+					zap.String("email", user.Email),
+				)
+				return common.ErrDuplicatedEmail
+			}
+
+			logger.Error("User database creation failed", zap.Error(result.Error))
+			return common.ErrDatabase
+		}
+
+		if err := service.enrol(tx, invitation.ClassID, user); err != nil {
+			return err
+		}
+
+		result := tx.Model(invitation).Updates(map[string]any{
+			"accepted_at":      service.Clock.Now(),
+			"accepted_user_id": user.ID,
+		})
+		if result.Error != nil {
+			logger.Error(
+				"Invitation database update failed",
+				zap.String("invitation_id", invitationID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		logger.Info(
+			"Invitation accepted",
+			zap.String("invitation_id", invitationID.String()),
+			zap.String("user_id", user.ID.String()),
+		)
+
+		return nil
+	})
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *InvitationService) parseInvitationToken(invitationTokenStr string) (uuid.UUID, error) {
+	invitationToken, err := common.ParseJWTToken(invitationTokenStr, service.AppConfig.JWTSecret)
+	if err != nil {
+		service.Logger.Debug("Invitation token parse failed", zap.Error(err))
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return uuid.Nil, common.ErrActionTokenExpired
+		}
+		return uuid.Nil, common.ErrActionTokenParsing
+	}
+	if !invitationToken.Valid {
+		return uuid.Nil, common.ErrInvalidActionToken
+	}
+
+	claims, ok := invitationToken.Claims.(jwt.MapClaims)
+	if !ok {
+		service.Logger.Debug("Invitation token type assertion failed")
+		return uuid.Nil, common.ErrActionTokenClaimsRetrieval
+	}
+	if claims["typ"] != string(types.ActionTokenTypeInvitation) {
+		service.Logger.Debug("Invitation token kind mismatched", zap.Any("typ", claims["typ"]))
+		return uuid.Nil, common.ErrInvalidActionToken
+	}
+	invitationIDStr, _ := claims["invitation_id"].(string)
+	invitationID, err := uuid.Parse(invitationIDStr)
+	if err != nil {
+		service.Logger.Debug(
+			"Invitation token claims retrieval failed",
+			zap.String("key", "invitation_id"),
+		)
+		return uuid.Nil, common.ErrActionTokenClaimsRetrieval
+	}
+
+	return invitationID, nil
+}
+
+// getPendingInvitation fails unless the invitation can still be accepted
+func (service *InvitationService) getPendingInvitation(db *gorm.DB, invitationID uuid.UUID) (*models.Invitation, error) {
+	invitation := &models.Invitation{}
+
+	result := db.First(invitation, invitationID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, common.ErrInvitationNotFound
+	} else if result.Error != nil {
+		service.Logger.Error(
+			"Invitation database retrieval failed",
+			zap.String("invitation_id", invitationID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	status := invitation.Status(service.Clock.Now())
+	switch status {
+	case types.InvitationStatusPending:
+		return invitation, nil
+	case types.InvitationStatusExpired:
+		return nil, common.ErrActionTokenExpired
+	default:
+		service.Logger.Debug(
+			"Invitation acceptance skipped",
+			zap.String("reason", string(status)),
+			zap.String("invitation_id", invitationID.String()),
+		)
+		return nil, common.ErrInvitationNotPending
+	}
+}
+
+func (service *InvitationService) getClass(ctx context.Context, db *gorm.DB, classID uuid.UUID) (*models.Class, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	class := &models.Class{}
+
+	result := db.WithContext(ctx).First(class, classID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, common.ErrClassNotFound
+	} else if result.Error != nil {
+		logger.Error(
+			"Class database retrieval failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return class, nil
+}
+
+// enrol adds a student to the class students, or a teacher to the class teachers
+func (service *InvitationService) enrol(tx *gorm.DB, classID uuid.UUID, user *models.User) error {
+	var result *gorm.DB
+	if user.Role == types.UserRoleTeacher {
+		result = tx.Create(&models.ClassTeacher{ClassID: classID, TeacherID: user.ID})
+	} else {
+		result = tx.Create(&models.ClassStudent{ClassID: classID, StudentID: user.ID})
+		if result.Error == nil {
+			result = tx.Model(&models.Class{}).
+				Where("id = ?", classID).
+				Update("student_count", gorm.Expr("student_count + 1"))
+		}
+	}
+
+	if result.Error != nil {
+		service.Logger.Error(
+			"Class enrolment database creation failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase
+	}
+
+	return nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func toView(invitation *models.Invitation, now time.Time) *InvitationView {
+	return &InvitationView{Invitation: invitation, Status: invitation.Status(now)}
+}
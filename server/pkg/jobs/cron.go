@@ -0,0 +1,182 @@
+package jobsfx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes the next activation time of a job
+type Schedule interface {
+	// Next returns the first activation time strictly after t
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses either a standard 5-field cron expression
+// ("minute hour day-of-month month day-of-week", evaluated in the location
+// of the given time) or "@every <duration>", e.g. "@every 5m".
+//
+// Fields support '*', lists ("1,15"), ranges ("1-5") and steps ("*/10", "0-30/5").
+// Day-of-week is 0-6 starting on Sunday (7 is accepted as Sunday too).
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every duration: %w", err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("@every duration must be at least 1s, got %s", interval)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 cron fields, got %d in %q", len(fields), spec)
+	}
+
+	var schedule cronSchedule
+	var err error
+
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	// Both 0 and 7 mean Sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	// A field starting with '*', e.g. '*/2', is not restricted
+	schedule.domRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dowRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// ======================== @every ========================
+
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(time.Second).Add(s.interval)
+}
+
+// ======================== CRON ========================
+
+// Each field is a bitset of the allowed values
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// When both day fields are restricted, a day matching either one is
+	// accepted (standard cron behaviour)
+	domRestricted, dowRestricted bool
+}
+
+// Upper bound of the search, so that impossible dates (e.g. Feb 30) terminate
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for next.Before(limit) {
+		if s.month&(1<<uint(next.Month())) == 0 {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	return time.Time{}
+}
+
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func parseCronField(field string, minVal, maxVal int) (uint64, error) {
+	var bits uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*":
+			low, high = minVal, maxVal
+		case strings.Contains(rangePart, "-"):
+			lowStr, highStr, _ := strings.Cut(rangePart, "-")
+			var errLow, errHigh error
+			low, errLow = strconv.Atoi(lowStr)
+			high, errHigh = strconv.Atoi(highStr)
+			if errLow != nil || errHigh != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			low, high = value, value
+			if hasStep {
+				// "5/15" means from 5 to the max every 15
+				high = maxVal
+			}
+		}
+
+		if low < minVal || high > maxVal || low > high {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, minVal, maxVal)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
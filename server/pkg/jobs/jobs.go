@@ -0,0 +1,15 @@
+package jobsfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"jobsfx",
+	fx.Provide(
+		NewJobsRoutes,
+		NewJobsController,
+		NewJobService,
+		func(service *JobService) JobServiceInterface { return service },
+		AsJob(NewPendingUploadsCleanupJob),
+	),
+	fx.Invoke(registerHooks),
+)
@@ -0,0 +1,76 @@
+package jobsfx
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type JobsControllerParams struct {
+	fx.In
+	Logger     *zap.Logger
+	JobService JobServiceInterface
+}
+
+type JobsController struct {
+	Logger     *zap.Logger
+	JobService JobServiceInterface
+}
+
+func NewJobsController(params JobsControllerParams) *JobsController {
+	return &JobsController{
+		Logger:     params.Logger,
+		JobService: params.JobService,
+	}
+}
+
+// ======================== METHODS ========================
+
+func (controller *JobsController) GetJobs(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"jobs": controller.JobService.GetJobs()})
+}
+
+func (controller *JobsController) GetJobRuns(ctx *gin.Context) {
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{
+				"limit": "limit must be a positive number",
+			}))
+			return
+		}
+	}
+
+	runs, err := controller.JobService.GetJobRuns(ctx.Request.Context(), ctx.Query("job"), limit)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"job_runs": runs})
+}
+
+func (controller *JobsController) TriggerJob(ctx *gin.Context) {
+	// Get userID Context that set by AuthMiddleware
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		controller.Logger.Debug("User ID parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	run, err := controller.JobService.TriggerJob(ctx.Request.Context(), ctx.Param("name"), userID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"job_run": run})
+}
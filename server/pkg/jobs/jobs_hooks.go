@@ -0,0 +1,24 @@
+package jobsfx
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// =============== Private Methods ===============
+func registerHooks(lc fx.Lifecycle, jobService *JobService) {
+	lc.Append(
+		fx.Hook{
+			OnStart: func(context.Context) error {
+				// The scheduler runs in its own goroutine
+				jobService.Start()
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				jobService.Stop()
+				return nil
+			},
+		},
+	)
+}
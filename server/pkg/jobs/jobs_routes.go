@@ -0,0 +1,95 @@
+package jobsfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type JobsRoutesParams struct {
+	fx.In
+	Logger         *zap.Logger
+	Router         *gin.Engine
+	AuthMiddleware *middlewarefx.AuthMiddleware
+	JobsController *JobsController
+}
+
+type JobsRoutes struct {
+	Logger         *zap.Logger
+	Router         *gin.Engine
+	AuthMiddleware *middlewarefx.AuthMiddleware
+	JobsController *JobsController
+}
+
+func NewJobsRoutes(params JobsRoutesParams) *JobsRoutes {
+	return &JobsRoutes{
+		Logger:         params.Logger,
+		Router:         params.Router,
+		AuthMiddleware: params.AuthMiddleware,
+		JobsController: params.JobsController,
+	}
+}
+
+func (routes *JobsRoutes) Setup() {
+	routes.Logger.Info("Setting up [Jobs] routes.")
+
+	routes.Router.GET(string(endpoints.GetJobsV1),
+		routes.AuthMiddleware.HandlerWithRole(types.UserRoleAdmin),
+		routes.JobsController.GetJobs)
+
+	routes.Router.GET(string(endpoints.GetJobRunsV1),
+		routes.AuthMiddleware.HandlerWithRole(types.UserRoleAdmin),
+		routes.JobsController.GetJobRuns)
+
+	routes.Router.POST(string(endpoints.TriggerJobV1)+"/:name/trigger",
+		routes.AuthMiddleware.HandlerWithRole(types.UserRoleAdmin),
+		routes.JobsController.TriggerJob)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetJobsV1),
+			ID:        "getJobs",
+			Tag:       "jobs",
+			Summary:   "List the scheduled jobs",
+			Auth:      openapifx.AuthAdmin,
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: openapifx.Object{"jobs": []*JobInfo{}}}},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    string(endpoints.GetJobRunsV1),
+			ID:      "getJobRuns",
+			Tag:     "jobs",
+			Summary: "List the latest job runs",
+			Auth:    openapifx.AuthAdmin,
+			Query: []openapifx.Param{
+				{Name: "job", Schema: "", Description: "Name of the job"},
+				{Name: "limit", Schema: 0},
+			},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"job_runs": []*models.JobRun{}}},
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    string(endpoints.TriggerJobV1) + "/:name/trigger",
+			ID:      "triggerJob",
+			Tag:     "jobs",
+			Summary: "Run a job now",
+			Auth:    openapifx.AuthAdmin,
+			Responses: []openapifx.Response{
+				{Status: http.StatusAccepted, Body: openapifx.Object{"job_run": models.JobRun{}}},
+			},
+			Errors: []int{http.StatusNotFound, http.StatusConflict},
+		},
+	}
+}
@@ -0,0 +1,440 @@
+package jobsfx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pglock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Job is a unit of background work run by the scheduler.
+// Modules register jobs in the "jobs" value group, see AsJob.
+type Job interface {
+	Name() string
+	Schedule() string // See ParseSchedule
+	Run(ctx context.Context) error
+}
+
+// AsJob annotates a job constructor so that its result joins the "jobs" group
+func AsJob(constructor any) any {
+	return fx.Annotate(
+		constructor,
+		fx.As(new(Job)),
+		fx.ResultTags(`group:"jobs"`),
+	)
+}
+
+type JobServiceParams struct {
+	fx.In
+	Logger *zap.Logger
+	DB     *gorm.DB
+	Clock  clock.Clock
+	Jobs   []Job `group:"jobs"`
+}
+
+type JobService struct {
+	Logger  *zap.Logger
+	DB      *gorm.DB
+	Clock   clock.Clock
+	entries []*jobEntry
+
+	// Scheduler state
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	leaderConn *sql.Conn // Holds the leader advisory lock while not nil
+}
+
+type JobServiceInterface interface {
+	GetJobs() []*JobInfo
+	GetJobRuns(ctx context.Context, jobName string, limit int) ([]*models.JobRun, error)
+	TriggerJob(ctx context.Context, jobName string, triggeredBy uuid.UUID) (*models.JobRun, error)
+}
+
+// Verify interface implementation at compile time
+var _ JobServiceInterface = (*JobService)(nil)
+
+type jobEntry struct {
+	job      Job
+	schedule Schedule
+
+	mu      sync.Mutex
+	nextRun time.Time
+}
+
+// JobInfo describes a registered job
+type JobInfo struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	NextRun  time.Time `json:"next_run"`
+}
+
+const (
+	// How often a follower tries to become the leader
+	leaderCheckInterval = 30 * time.Second
+
+	// Namespaces of the Postgres advisory lock keys
+	leaderLockName = "tgs:jobs:leader"
+	jobLockPrefix  = "tgs:jobs:job:"
+
+	defaultJobRunsLimit = 50
+	maxJobRunsLimit     = 200
+)
+
+func NewJobService(params JobServiceParams) (*JobService, error) {
+	service := &JobService{
+		Logger: params.Logger,
+		DB:     params.DB,
+		Clock:  params.Clock,
+	}
+
+	names := make(map[string]bool)
+	for _, job := range params.Jobs {
+		if names[job.Name()] {
+			return nil, fmt.Errorf("duplicated job name %q", job.Name())
+		}
+		names[job.Name()] = true
+
+		schedule, err := ParseSchedule(job.Schedule())
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule of job %q: %w", job.Name(), err)
+		}
+
+		service.entries = append(service.entries, &jobEntry{job: job, schedule: schedule})
+	}
+
+	// Stable order for listing
+	sort.Slice(service.entries, func(i, j int) bool {
+		return service.entries[i].job.Name() < service.entries[j].job.Name()
+	})
+
+	return service, nil
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+func (service *JobService) GetJobs() []*JobInfo {
+	jobs := make([]*JobInfo, 0, len(service.entries))
+	for _, entry := range service.entries {
+		entry.mu.Lock()
+		jobs = append(jobs, &JobInfo{
+			Name:     entry.job.Name(),
+			Schedule: entry.job.Schedule(),
+			NextRun:  entry.nextRun,
+		})
+		entry.mu.Unlock()
+	}
+	return jobs
+}
+
+func (service *JobService) GetJobRuns(
+	ctx context.Context,
+	jobName string,
+	limit int,
+) ([]*models.JobRun, error) {
+	if limit <= 0 {
+		limit = defaultJobRunsLimit
+	}
+	limit = min(limit, maxJobRunsLimit)
+
+	query := service.DB.WithContext(ctx).Order("started_at DESC").Limit(limit)
+	if jobName != "" {
+		query = query.Where("job_name = ?", jobName)
+	}
+
+	runs := []*models.JobRun{}
+	if result := query.Find(&runs); result.Error != nil {
+		service.Logger.Error("Job runs database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	return runs, nil
+}
+
+// TriggerJob starts a manual run of the job in the background and returns the
+// run record right away. Manual runs bypass leader election but never overlap
+// with another run of the same job on any replica.
+func (service *JobService) TriggerJob(
+	ctx context.Context,
+	jobName string,
+	triggeredBy uuid.UUID,
+) (*models.JobRun, error) {
+	entry := service.findEntry(jobName)
+	if entry == nil {
+		return nil, common.ErrJobNotFound
+	}
+
+	// Detach from the request, the run must outlive it
+	runCtx := service.ctx
+	if runCtx == nil {
+		runCtx = context.Background() // Scheduler not started
+	}
+
+	return service.startRun(runCtx, entry, types.JobTriggerManual, &triggeredBy)
+}
+
+// ======================== SCHEDULER METHODS ========================
+
+// Start launches the scheduler loop. Only the replica holding the leader
+// advisory lock runs scheduled jobs.
+func (service *JobService) Start() {
+	service.ctx, service.cancel = context.WithCancel(context.Background())
+
+	service.wg.Add(1)
+	go func() {
+		defer service.wg.Done()
+		service.loop()
+	}()
+}
+
+// Stop cancels running jobs, waits for them and releases the leadership
+func (service *JobService) Stop() {
+	if service.cancel != nil {
+		service.cancel()
+	}
+
+	service.wg.Wait()
+	service.releaseLeadership()
+}
+
+// Tick refreshes the leadership and, on the leader, starts the scheduled runs
+// that are due. The first tick only computes the next runs. It returns when
+// the scheduler should tick again.
+func (service *JobService) Tick(ctx context.Context) time.Time {
+	service.refreshLeadership(ctx)
+
+	// Schedules are on the wall clock, in the time zone of the server
+	now := service.Clock.Now().Local()
+	wakeAt := now.Add(leaderCheckInterval)
+
+	for _, entry := range service.entries {
+		entry.mu.Lock()
+		if entry.nextRun.IsZero() {
+			entry.nextRun = entry.schedule.Next(now)
+		} else if !entry.nextRun.After(now) {
+			if service.leaderConn != nil {
+				service.wg.Add(1)
+				go func() {
+					defer service.wg.Done()
+					service.runScheduled(ctx, entry)
+				}()
+			}
+			entry.nextRun = entry.schedule.Next(now)
+		}
+		if !entry.nextRun.IsZero() && entry.nextRun.Before(wakeAt) {
+			wakeAt = entry.nextRun
+		}
+		entry.mu.Unlock()
+	}
+
+	return wakeAt
+}
+
+func (service *JobService) loop() {
+	for {
+		wakeAt := service.Tick(service.ctx)
+
+		select {
+		case <-service.ctx.Done():
+			return
+		case <-time.After(wakeAt.Sub(service.Clock.Now())):
+		}
+	}
+}
+
+func (service *JobService) runScheduled(ctx context.Context, entry *jobEntry) {
+	_, err := service.startRun(ctx, entry, types.JobTriggerSchedule, nil)
+	if err != nil && !errors.Is(err, common.ErrJobAlreadyRunning) {
+		service.Logger.Error("Scheduled job start failed",
+			zap.String("job_name", entry.job.Name()),
+			zap.Error(err),
+		)
+	}
+}
+
+// startRun takes the per-job advisory lock, records the run and executes the
+// job in a goroutine. The lock is held on a dedicated connection until the
+// job finishes.
+func (service *JobService) startRun(
+	ctx context.Context,
+	entry *jobEntry,
+	trigger types.JobTrigger,
+	triggeredBy *uuid.UUID,
+) (*models.JobRun, error) {
+	jobName := entry.job.Name()
+
+	conn, locked, err := service.tryAdvisoryLock(ctx, jobLockPrefix+jobName)
+	if err != nil {
+		service.Logger.Error("Job lock acquisition failed", zap.String("job_name", jobName), zap.Error(err))
+		return nil, common.ErrDatabase
+	}
+	if !locked {
+		service.Logger.Debug("Job run skipped",
+			zap.String("reason", "already_running"),
+			zap.String("job_name", jobName),
+		)
+		return nil, common.ErrJobAlreadyRunning
+	}
+
+	run := &models.JobRun{
+		JobName:     jobName,
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		Status:      types.JobRunStatusRunning,
+		StartedAt:   service.Clock.Now(),
+	}
+	if result := service.DB.WithContext(ctx).Create(run); result.Error != nil {
+		service.Logger.Error("Job run database creation failed", zap.String("job_name", jobName), zap.Error(result.Error))
+		service.releaseAdvisoryLock(conn, jobLockPrefix+jobName)
+		return nil, common.ErrDatabase
+	}
+
+	service.wg.Add(1)
+	go func() {
+		defer service.wg.Done()
+		defer service.releaseAdvisoryLock(conn, jobLockPrefix+jobName)
+
+		service.Logger.Info("Job run started",
+			zap.String("job_name", jobName),
+			zap.String("trigger", string(trigger)),
+		)
+
+		runErr := service.execute(ctx, entry.job)
+		service.finishRun(run, runErr)
+	}()
+
+	return run, nil
+}
+
+// execute runs the job across the schools and turns a panic into an error
+func (service *JobService) execute(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return job.Run(tenant.Bypass(ctx))
+}
+
+func (service *JobService) finishRun(run *models.JobRun, runErr error) {
+	finishedAt := service.Clock.Now()
+	updates := map[string]any{
+		"status":      types.JobRunStatusSucceeded,
+		"finished_at": finishedAt,
+	}
+
+	logFields := []zap.Field{
+		zap.String("job_name", run.JobName),
+		zap.Duration("duration", finishedAt.Sub(run.StartedAt)),
+	}
+	if runErr != nil {
+		updates["status"] = types.JobRunStatusFailed
+		updates["error"] = runErr.Error()
+		service.Logger.Error("Job run failed", append(logFields, zap.Error(runErr))...)
+	} else {
+		service.Logger.Info("Job run succeeded", logFields...)
+	}
+
+	// The scheduler context may be cancelled already, still record the outcome
+	result := service.DB.Model(run).Updates(updates)
+	if result.Error != nil {
+		service.Logger.Error("Job run database update failed",
+			zap.String("job_run_id", run.ID.String()),
+			zap.Error(result.Error),
+		)
+	}
+}
+
+// ======================== LEADER ELECTION ========================
+
+// refreshLeadership keeps the leader lock alive, or tries to acquire it
+func (service *JobService) refreshLeadership(ctx context.Context) {
+	if service.leaderConn != nil {
+		if err := service.leaderConn.PingContext(ctx); err == nil {
+			return
+		}
+
+		// The session is gone, and so is its lock
+		service.Logger.Warn("Job scheduler leadership lost")
+		_ = service.leaderConn.Close()
+		service.leaderConn = nil
+	}
+
+	conn, locked, err := service.tryAdvisoryLock(ctx, leaderLockName)
+	if err != nil {
+		service.Logger.Error("Job scheduler leader lock acquisition failed", zap.Error(err))
+		return
+	}
+	if locked {
+		service.Logger.Info("Job scheduler leadership acquired")
+		service.leaderConn = conn
+	}
+}
+
+func (service *JobService) releaseLeadership() {
+	if service.leaderConn == nil {
+		return
+	}
+	service.releaseAdvisoryLock(service.leaderConn, leaderLockName)
+	service.leaderConn = nil
+}
+
+// tryAdvisoryLock takes a session-level advisory lock on a dedicated
+// connection. On success the caller owns the connection and must release it.
+func (service *JobService) tryAdvisoryLock(ctx context.Context, name string) (*sql.Conn, bool, error) {
+	sqlDB, err := service.DB.DB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", pglock.Key(name)).Scan(&locked)
+	if err != nil {
+		// Whether the lock was taken is unknown
+		pglock.Discard(conn)
+		return nil, false, err
+	}
+	if !locked {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return conn, true, nil
+}
+
+func (service *JobService) releaseAdvisoryLock(conn *sql.Conn, name string) {
+	if err := pglock.Release(conn, name); err != nil {
+		service.Logger.Error("Advisory lock release failed", zap.String("lock", name), zap.Error(err))
+	}
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *JobService) findEntry(jobName string) *jobEntry {
+	for _, entry := range service.entries {
+		if entry.job.Name() == jobName {
+			return entry
+		}
+	}
+	return nil
+}
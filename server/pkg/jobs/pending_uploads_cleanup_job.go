@@ -0,0 +1,205 @@
+package jobsfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PendingUploadsCleanupJobParams struct {
+	fx.In
+	AppConfig     *configfx.AppConfig
+	Logger        *zap.Logger
+	DB            *gorm.DB
+	StorageClient *minio.Client
+	Clock         clock.Clock
+}
+
+// PendingUploadsCleanupJob deletes the expired pending uploads together with
+// their objects, then sweeps the orphaned objects, see PurgeOrphans.
+type PendingUploadsCleanupJob struct {
+	AppConfig     *configfx.AppConfig
+	Logger        *zap.Logger
+	DB            *gorm.DB
+	StorageClient *minio.Client
+	Clock         clock.Clock
+}
+
+const (
+	pendingObjectPrefix = "pending/"
+	avatarObjectPrefix  = "avatars/"
+
+	// Orphaned objects younger than this are left alone, the client may still
+	// be uploading them
+	orphanGracePeriod = time.Hour
+
+	cleanupBatchSize = 100
+)
+
+func NewPendingUploadsCleanupJob(params PendingUploadsCleanupJobParams) *PendingUploadsCleanupJob {
+	return &PendingUploadsCleanupJob{
+		AppConfig:     params.AppConfig,
+		Logger:        params.Logger,
+		DB:            params.DB,
+		StorageClient: params.StorageClient,
+		Clock:         params.Clock,
+	}
+}
+
+func (job *PendingUploadsCleanupJob) Name() string {
+	return "pending-uploads-cleanup"
+}
+
+func (job *PendingUploadsCleanupJob) Schedule() string {
+	return "0 0 * * *" // Daily at midnight
+}
+
+func (job *PendingUploadsCleanupJob) Run(ctx context.Context) error {
+	if err := job.deleteExpired(ctx); err != nil {
+		return err
+	}
+
+	_, err := job.PurgeOrphans(ctx)
+	return err
+}
+
+// PurgeOrphans deletes the pending objects without a pending upload row and
+// the avatars no user refers to, and returns how many objects were deleted
+func (job *PendingUploadsCleanupJob) PurgeOrphans(ctx context.Context) (int, error) {
+	pendingCount, err := job.sweepOrphans(ctx, pendingObjectPrefix, func(objectKey string) *gorm.DB {
+		return job.DB.WithContext(ctx).
+			Model(&models.PendingUpload{}).
+			Where("object_key = ?", strings.TrimPrefix(objectKey, pendingObjectPrefix))
+	})
+	if err != nil {
+		return pendingCount, err
+	}
+
+	avatarCount, err := job.sweepOrphans(ctx, avatarObjectPrefix, func(objectKey string) *gorm.DB {
+		return job.DB.WithContext(ctx).
+			Model(&models.User{}).
+			Where("avatar_key = ?", objectKey)
+	})
+
+	return pendingCount + avatarCount, err
+}
+
+// =============== Private Methods ===============
+
+func (job *PendingUploadsCleanupJob) deleteExpired(ctx context.Context) error {
+	deleted := 0
+
+	for {
+		expired := []*models.PendingUpload{}
+		result := job.DB.WithContext(ctx).
+			Where("expire_at < ?", job.Clock.Now()).
+			Limit(cleanupBatchSize).
+			Find(&expired)
+		if result.Error != nil {
+			job.Logger.Error("Pending upload database retrieval failed", zap.Error(result.Error))
+			return fmt.Errorf("retrieve expired pending uploads: %w", result.Error)
+		}
+		if len(expired) == 0 {
+			break
+		}
+
+		for _, pendingUpload := range expired {
+			// Remove the object first, a leftover row is retried on the next run
+			pendingKey := pendingObjectPrefix + pendingUpload.ObjectKey
+			if err := job.removeObject(ctx, pendingKey); err != nil {
+				return err
+			}
+
+			result := job.DB.WithContext(ctx).
+				Where("object_key = ? AND user_id = ?", pendingUpload.ObjectKey, pendingUpload.UserID).
+				Delete(&models.PendingUpload{})
+			if result.Error != nil {
+				job.Logger.Error("Pending upload database deletion failed",
+					zap.String("object_key", pendingUpload.ObjectKey),
+					zap.Error(result.Error),
+				)
+				return fmt.Errorf("delete pending upload: %w", result.Error)
+			}
+			deleted++
+		}
+	}
+
+	job.Logger.Info("Expired pending uploads deleted", zap.Int("count", deleted))
+	return nil
+}
+
+// sweepOrphans deletes the objects under prefix that are older than the grace
+// period and not matched by the references query
+func (job *PendingUploadsCleanupJob) sweepOrphans(
+	ctx context.Context,
+	prefix string,
+	references func(objectKey string) *gorm.DB,
+) (int, error) {
+	objects := job.StorageClient.ListObjects(ctx, job.AppConfig.StorageBucketName, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	cutoff := job.Clock.Now().Add(-orphanGracePeriod)
+	swept := 0
+
+	for object := range objects {
+		if object.Err != nil {
+			job.Logger.Error("Object storage listing failed", zap.String("prefix", prefix), zap.Error(object.Err))
+			return swept, fmt.Errorf("list %s objects: %w", prefix, object.Err)
+		}
+		if object.LastModified.After(cutoff) {
+			continue
+		}
+
+		var count int64
+		if result := references(object.Key).Count(&count); result.Error != nil {
+			job.Logger.Error("Object reference database retrieval failed",
+				zap.String("object_key", object.Key),
+				zap.Error(result.Error),
+			)
+			return swept, fmt.Errorf("check object reference: %w", result.Error)
+		}
+		if count > 0 {
+			continue
+		}
+
+		if err := job.removeObject(ctx, object.Key); err != nil {
+			return swept, err
+		}
+		swept++
+	}
+
+	job.Logger.Info("Orphaned objects deleted", zap.String("prefix", prefix), zap.Int("count", swept))
+	return swept, nil
+}
+
+func (job *PendingUploadsCleanupJob) removeObject(ctx context.Context, objectKey string) error {
+	err := job.StorageClient.RemoveObject(ctx,
+		job.AppConfig.StorageBucketName,
+		objectKey,
+		minio.RemoveObjectOptions{})
+	if err != nil {
+		var minioErr minio.ErrorResponse
+		if errors.As(err, &minioErr) && minioErr.Code == "NoSuchKey" {
+			return nil // Never uploaded
+		}
+
+		job.Logger.Error("Object storage deletion failed",
+			zap.String("object_key", objectKey),
+			zap.Error(err),
+		)
+		return fmt.Errorf("remove object %q: %w", objectKey, err)
+	}
+	return nil
+}
@@ -5,6 +5,10 @@ import (
 	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
@@ -13,18 +17,30 @@ import (
 
 type DatabaseParams struct {
 	fx.In
-	AppConfig *configfx.AppConfig
-	Logger    *zap.Logger
+	AppConfig      *configfx.AppConfig
+	Logger         *zap.Logger
+	TracerProvider trace.TracerProvider
+	Clock          clock.Clock
 }
 
 func NewDatabase(params DatabaseParams) (*gorm.DB, error) {
 	dsn := params.AppConfig.GetDBConfig()
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// created_at and updated_at are set by the clock of the services
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{NowFunc: params.Clock.Now})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database session: %w", err)
 	}
 
+	// Scope queries to the school of the request
+	if err := db.Use(tenant.Plugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenant plugin: %w", err)
+	}
+
+	if err := db.Use(tracingfx.GormPlugin{TracerProvider: params.TracerProvider}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	// Get underlying SQL DB to check connection and get stats
 	sqlDB, err := db.DB()
 	if err != nil {
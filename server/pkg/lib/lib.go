@@ -1,6 +1,9 @@
 package libfx
 
-import "go.uber.org/fx"
+import (
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"go.uber.org/fx"
+)
 
 var Module = fx.Module(
 	"libfx",
@@ -10,5 +13,6 @@ var Module = fx.Module(
 		NewRouter,
 		NewMailClient,
 		NewStorageClient,
+		clock.New,
 	),
 )
@@ -19,9 +19,11 @@ func NewLogger(params LoggerParams) (*zap.Logger, error) {
 
 	switch params.FlagConfig.Environment {
 	case "production":
+		// JSON, Info for the access logs
 		cfg = zap.NewProductionConfig()
-		cfg.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+		cfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
 		cfg.OutputPaths = []string{"stdout"}
+		cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	case "test":
 		cfg = zap.NewDevelopmentConfig()
 		cfg.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
@@ -32,8 +34,10 @@ func NewLogger(params LoggerParams) (*zap.Logger, error) {
 		cfg.OutputPaths = []string{"stdout"}
 	}
 
-	// Customize the time format to match Go's standard log
-	cfg.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("2006/01/02 15:04:05")
+	// Customize the console time format to match Go's standard log
+	if cfg.Encoding == "console" {
+		cfg.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("2006/01/02 15:04:05")
+	}
 
 	logger, err := cfg.Build()
 	if err != nil {
@@ -1,34 +1,38 @@
 package libfx
 
 import (
-	"fmt"
+	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
-const (
-	reset  = "\033[0m"
-	red    = "\033[31m"
-	green  = "\033[32m"
-	yellow = "\033[33m"
-	blue   = "\033[34m"
-	cyan   = "\033[36m"
-)
+// Longest X-Request-ID accepted from the client
+const maxRequestIDLength = 128
 
 type RouterParam struct {
 	fx.In
-	AppConfig  *configfx.AppConfig
-	FlagConfig *configfx.FlagConfig
-	Logger     *zap.Logger
+	AppConfig      *configfx.AppConfig
+	FlagConfig     *configfx.FlagConfig
+	Logger         *zap.Logger
+	Metrics        *metricsfx.Metrics
+	TracerProvider trace.TracerProvider
 }
 
 func NewRouter(params RouterParam) *gin.Engine {
@@ -56,15 +60,24 @@ func NewRouter(params RouterParam) *gin.Engine {
 		"Pragma",
 		"Referer",
 		"Referrer-Policy",
+		logging.RequestIDHeader,
 	}
-	config.ExposeHeaders = []string{"Content-Length"} // Headers exposed to the client
+	config.ExposeHeaders = []string{"Content-Length", logging.RequestIDHeader} // Headers exposed to the client
 	config.AllowCredentials = true
 	config.MaxAge = 12 * time.Hour // Cache preflight requests for 12 hours
 
-	// Apply middleware
+	// Apply middleware, the span and request logger first so that every
+	// response, even a recovered panic, is traced and logged with its request ID
+	router.Use(tracingfx.Middleware(params.TracerProvider))
+	router.Use(requestLoggerMiddleware(params.Logger))
+	router.Use(metricsMiddleware(params.Metrics))
 	router.Use(cors.New(config))
-	router.Use(gin.Recovery())
-	router.Use(ginLoggerMiddleware(params.Logger))
+	router.Use(gin.CustomRecovery(func(ctx *gin.Context, _ any) {
+		common.RespondError(ctx, common.ErrInternal)
+	}))
+	router.NoRoute(func(ctx *gin.Context) {
+		common.RespondError(ctx, common.ErrRouteNotFound)
+	})
 
 	// Use field name specified for JSON in validation
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -75,6 +88,10 @@ func NewRouter(params RouterParam) *gin.Engine {
 			}
 			return name
 		})
+
+		if err := common.RegisterNameValidations(v); err != nil {
+			params.Logger.Fatal("Name validations registration failed", zap.Error(err))
+		}
 	}
 
 	params.Logger.Info("Router initialization succeeded")
@@ -82,52 +99,93 @@ func NewRouter(params RouterParam) *gin.Engine {
 	return router
 }
 
-// Custom Gin Middleware for Logger
-func ginLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
+// requestLoggerMiddleware accepts or generates the request ID, scopes a logger
+// and the locale to the request in its context and writes the access log once
+// it is served
+func requestLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		start := time.Now()
-		path := ctx.Request.URL.Path
-		query := ctx.Request.URL.RawQuery
+
+		requestID := ctx.GetHeader(logging.RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(logging.RequestIDHeader, requestID)
+
+		// Route template, empty when no route matched
+		route := ctx.FullPath()
+		requestLogger := logger.With(zap.String("request_id", requestID), zap.String("route", route))
+
+		// Correlate the logs with the trace, when sampled
+		if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.IsSampled() {
+			requestLogger = requestLogger.With(zap.String("trace_id", spanContext.TraceID().String()))
+		}
+
+		reqCtx := logging.WithRequestID(ctx.Request.Context(), requestID)
+		reqCtx = i18n.WithLocale(reqCtx, i18n.MatchAcceptLanguage(ctx.GetHeader("Accept-Language")))
+		ctx.Request = ctx.Request.WithContext(logging.WithLogger(reqCtx, requestLogger))
 
 		ctx.Next()
 
-		end := time.Now()
-		latency := end.Sub(start)
 		statusCode := ctx.Writer.Status()
-		method := ctx.Request.Method
-
-		// Determine status code color
-		var statusColor string
-		switch {
-		case statusCode >= 200 && statusCode < 300:
-			statusColor = green
-		case statusCode >= 300 && statusCode < 400:
-			statusColor = cyan
-		case statusCode >= 400 && statusCode < 500:
-			statusColor = yellow
-		default: // 5xx or other
-			statusColor = red
+		fields := []zap.Field{
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.String("query", ctx.Request.URL.RawQuery),
+			zap.Int("status", statusCode),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", ctx.ClientIP()),
+			zap.Int("response_size", ctx.Writer.Size()),
 		}
 
-		// Construct the log message with ANSI colors for console output
-		logMessage := fmt.Sprintf("%s |%s %3d %s| %13v | %15s |%s %-7s %s %s %s",
-			end.Format("2006/01/02 - 15:04:05"), // Timestamp
-			statusColor, statusCode, reset,      // Status code with color
-			latency,
-			ctx.ClientIP(),
-			blue, method, reset, // Method with color
-			path,
-			query,
-		)
-
+		// Set by AuthMiddleware on authenticated routes
+		if userID := ctx.GetString("user_id"); userID != "" {
+			fields = append(fields, zap.String("user_id", userID))
+		}
 		if len(ctx.Errors) > 0 {
-			// Log error with the formatted message
-			for _, e := range ctx.Errors.Errors() {
-				logger.Error(logMessage + " " + e)
-			}
+			fields = append(fields, zap.Strings("errors", ctx.Errors.Errors()))
+		}
+
+		if statusCode >= http.StatusInternalServerError || len(ctx.Errors) > 0 {
+			requestLogger.Error("Request served", fields...)
 		} else {
-			// Log info with the formatted message
-			logger.Info(logMessage)
+			requestLogger.Info("Request served", fields...)
 		}
 	}
 }
+
+// metricsMiddleware observes the duration of the request by route template,
+// so that path parameters do not blow up the label cardinality
+func metricsMiddleware(metrics *metricsfx.Metrics) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// Client supplied request IDs are logged as is, so only short IDs of safe
+// characters are accepted
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphanumeric && !strings.ContainsRune("-_.:", r) {
+			return false
+		}
+	}
+
+	return true
+}
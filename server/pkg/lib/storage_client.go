@@ -2,24 +2,35 @@ package libfx
 
 import (
 	"fmt"
+	"net/http"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
 type StorageClientParams struct {
 	fx.In
-	FlagConfig *configfx.FlagConfig
-	AppConfig  *configfx.AppConfig
-	Logger     *zap.Logger
+	FlagConfig     *configfx.FlagConfig
+	AppConfig      *configfx.AppConfig
+	Logger         *zap.Logger
+	Metrics        *metricsfx.Metrics
+	TracerProvider trace.TracerProvider
 }
 
 func NewStorageClient(params StorageClientParams) (*minio.Client, error) {
 	useSecure := params.FlagConfig.Environment == "production"
 
+	transport, err := minio.DefaultTransport(useSecure)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating storage transport: %w", err)
+	}
+
 	minioClient, err := minio.New(
 		params.AppConfig.StorageEndpoint,
 		&minio.Options{
@@ -29,6 +40,13 @@ func NewStorageClient(params StorageClientParams) (*minio.Client, error) {
 				"",
 			),
 			Secure: useSecure,
+			Transport: &tracingfx.Transport{
+				Base:           &metricsfx.StorageTransport{Base: transport, Metrics: params.Metrics},
+				TracerProvider: params.TracerProvider,
+				SpanName: func(req *http.Request) string {
+					return "storage." + metricsfx.StorageOperation(req)
+				},
+			},
 		},
 	)
 	if err != nil {
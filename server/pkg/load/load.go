@@ -0,0 +1,12 @@
+package loadfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"loadfx",
+	fx.Provide(
+		NewLoadRoutes,
+		NewLoadController,
+		NewLoadService,
+	),
+)
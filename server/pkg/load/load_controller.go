@@ -0,0 +1,230 @@
+package loadfx
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/dates"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type LoadControllerParams struct {
+	fx.In
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	LoadService  LoadServiceInterface
+}
+
+type LoadController struct {
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	LoadService  LoadServiceInterface
+}
+
+func NewLoadController(params LoadControllerParams) *LoadController {
+	return &LoadController{
+		Logger:       params.Logger,
+		AuthzService: params.AuthzService,
+		LoadService:  params.LoadService,
+	}
+}
+
+// ======================== REQUEST BODY ========================
+
+type PreviewAssignmentsBody struct {
+	Assignments []ProposedAssignment `json:"assignments" binding:"required,min=1,max=10,dive"`
+}
+
+type ProposedAssignment struct {
+	HomeworkID uuid.UUID  `json:"homework_id" binding:"required"`
+	ClassID    uuid.UUID  `json:"class_id"    binding:"required"`
+	AssignedAt *time.Time `json:"assigned_at"` // Now when null
+	DueAt      time.Time  `json:"due_at"      binding:"required"`
+}
+
+// ======================== METHODS ========================
+
+// GetClassLoad accepts ?from=YYYY-MM-DD, today in the time zone of the
+// school by default, and ?days=, DefaultLoadDays by default
+func (controller *LoadController) GetClassLoad(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	classID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	details := map[string]string{}
+
+	var from *dates.Date
+	if raw := ctx.Query("from"); raw != "" {
+		date, err := dates.ParseDate(raw)
+		if err != nil {
+			details["from"] = "from must be a date formatted as YYYY-MM-DD"
+		}
+		from = &date
+	}
+
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(DefaultLoadDays)))
+	if err != nil || days < 1 || days > MaxLoadDays {
+		details["days"] = fmt.Sprintf("days must be a number between 1 and %d", MaxLoadDays)
+	}
+
+	if len(details) > 0 {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(details))
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionClassLoadRead, classID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	load, err := controller.LoadService.GetClassLoad(ctx.Request.Context(), classID, from, days)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"load": load})
+}
+
+// GetAssignmentSlots accepts ?homework_id=, required, and ?from= and ?days= as
+// GetClassLoad, the window in which the homework is assigned and due
+func (controller *LoadController) GetAssignmentSlots(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	classID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	details := map[string]string{}
+
+	homeworkID, err := uuid.Parse(ctx.Query("homework_id"))
+	if err != nil {
+		details["homework_id"] = "homework_id is required and must be a uuid"
+	}
+
+	var from *dates.Date
+	if raw := ctx.Query("from"); raw != "" {
+		date, err := dates.ParseDate(raw)
+		if err != nil {
+			details["from"] = "from must be a date formatted as YYYY-MM-DD"
+		}
+		from = &date
+	}
+
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(DefaultLoadDays)))
+	if err != nil || days < 1 || days > MaxLoadDays {
+		details["days"] = fmt.Sprintf("days must be a number between 1 and %d", MaxLoadDays)
+	}
+
+	if len(details) > 0 {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(details))
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionClassAssignmentsEdit, classID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionHomeworkAssign, homeworkID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	slots, err := controller.LoadService.GetAssignmentSlots(ctx.Request.Context(), classID, homeworkID, from, days)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"assignment_slots": slots})
+}
+
+// PreviewAssignments is the what-if of assignments, made in memory. The user
+// must be able to edit the assignments of every class of the proposals, and
+// to assign every homework of them.
+func (controller *LoadController) PreviewAssignments(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	// Get validated body from context that set by RequestBodyValidator
+	validatedBody, _ := ctx.Get("validatedBody")
+	previewAssignmentsBody, _ := validatedBody.(*PreviewAssignmentsBody)
+
+	authorized := []uuid.UUID{}
+	for _, proposal := range previewAssignmentsBody.Assignments {
+		if slices.Contains(authorized, proposal.ClassID) {
+			continue
+		}
+		err = controller.AuthzService.Authorize(
+			ctx.Request.Context(),
+			subject,
+			authzfx.PermissionClassAssignmentsEdit,
+			proposal.ClassID,
+		)
+		if err != nil {
+			common.HandleBusinessLogicErr(ctx, err)
+			return
+		}
+		authorized = append(authorized, proposal.ClassID)
+	}
+	authorizedHomework := []uuid.UUID{}
+	for _, proposal := range previewAssignmentsBody.Assignments {
+		if slices.Contains(authorizedHomework, proposal.HomeworkID) {
+			continue
+		}
+		err = controller.AuthzService.Authorize(
+			ctx.Request.Context(),
+			subject,
+			authzfx.PermissionHomeworkAssign,
+			proposal.HomeworkID,
+		)
+		if err != nil {
+			common.HandleBusinessLogicErr(ctx, err)
+			return
+		}
+		authorizedHomework = append(authorizedHomework, proposal.HomeworkID)
+	}
+
+	whatIf, err := controller.LoadService.PreviewAssignments(ctx.Request.Context(), previewAssignmentsBody.Assignments)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"what_if": whatIf})
+}
@@ -0,0 +1,111 @@
+package loadfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/dates"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type LoadRoutesParams struct {
+	fx.In
+	Logger               *zap.Logger
+	Router               *gin.Engine
+	AuthMiddleware       *middlewarefx.AuthMiddleware
+	RequestBodyValidator *middlewarefx.RequestBodyValidator
+	LoadController       *LoadController
+}
+
+type LoadRoutes struct {
+	Logger               *zap.Logger
+	Router               *gin.Engine
+	AuthMiddleware       *middlewarefx.AuthMiddleware
+	RequestBodyValidator *middlewarefx.RequestBodyValidator
+	LoadController       *LoadController
+}
+
+func NewLoadRoutes(params LoadRoutesParams) *LoadRoutes {
+	return &LoadRoutes{
+		Logger:               params.Logger,
+		Router:               params.Router,
+		AuthMiddleware:       params.AuthMiddleware,
+		RequestBodyValidator: params.RequestBodyValidator,
+		LoadController:       params.LoadController,
+	}
+}
+
+func (routes *LoadRoutes) Setup() {
+	routes.Logger.Info("Setting up [Load] routes.")
+
+	routes.Router.GET(string(endpoints.GetClassLoadV1)+"/:id/load",
+		routes.AuthMiddleware.Handler(),
+		routes.LoadController.GetClassLoad)
+
+	routes.Router.GET(string(endpoints.GetAssignmentSlotsV1)+"/:id/load/slots",
+		routes.AuthMiddleware.Handler(),
+		routes.LoadController.GetAssignmentSlots)
+
+	routes.Router.POST(string(endpoints.PreviewAssignmentsV1),
+		routes.AuthMiddleware.Handler(),
+		routes.RequestBodyValidator.Handler(PreviewAssignmentsBody{}),
+		routes.LoadController.PreviewAssignments)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:     http.MethodGet,
+			Path:       string(endpoints.GetClassLoadV1) + "/:id/load",
+			ID:         "getClassLoad",
+			Tag:        "classes",
+			Summary:    "Get the daily man-hours of every student of a class, from every teacher",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Query: []openapifx.Param{
+				{Name: "from", Schema: dates.Date{}, Description: "Today in the time zone of the school by default"},
+				{Name: "days", Schema: 0, Description: "1 to 31, 7 by default"},
+			},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"load": ClassLoad{}}},
+			},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       string(endpoints.GetAssignmentSlotsV1) + "/:id/load/slots",
+			ID:         "getAssignmentSlots",
+			Tag:        "classes",
+			Summary:    "Propose when to assign a homework to a class and when it is due, lowering the peak load",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Query: []openapifx.Param{
+				{Name: "homework_id", Schema: uuid.UUID{}, Description: "Required"},
+				{Name: "from", Schema: dates.Date{}, Description: "Earliest assignment, today in the time zone of the school by default"},
+				{Name: "days", Schema: 0, Description: "1 to 31, 7 by default"},
+			},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"assignment_slots": AssignmentSlots{}}},
+			},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    string(endpoints.PreviewAssignmentsV1),
+			ID:      "previewAssignments",
+			Tag:     "classes",
+			Summary: "Preview the daily man-hours of the students if the assignments were made, saving nothing",
+			Auth:    openapifx.AuthUser,
+			Body:    PreviewAssignmentsBody{},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"what_if": WhatIf{}}},
+			},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound},
+		},
+	}
+}
@@ -0,0 +1,694 @@
+package loadfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/dates"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LoadServiceParams struct {
+	fx.In
+	AppConfig *configfx.AppConfig
+	Logger    *zap.Logger
+	DB        *gorm.DB
+	Clock     clock.Clock
+}
+
+type LoadService struct {
+	AppConfig *configfx.AppConfig
+	Logger    *zap.Logger
+	DB        *gorm.DB
+	Clock     clock.Clock
+}
+
+type LoadServiceInterface interface {
+	GetClassLoad(ctx context.Context, classID uuid.UUID, from *dates.Date, days int) (*ClassLoad, error)
+	PreviewAssignments(ctx context.Context, proposals []ProposedAssignment) (*WhatIf, error)
+	GetAssignmentSlots(
+		ctx context.Context,
+		classID uuid.UUID,
+		homeworkID uuid.UUID,
+		from *dates.Date,
+		days int,
+	) (*AssignmentSlots, error)
+}
+
+// Verify interface implementation at compile time
+var _ LoadServiceInterface = (*LoadService)(nil)
+
+func NewLoadService(params LoadServiceParams) LoadServiceInterface {
+	return &LoadService{
+		AppConfig: params.AppConfig,
+		Logger:    params.Logger,
+		DB:        params.DB,
+		Clock:     params.Clock,
+	}
+}
+
+// Number of days of a load, from the first one
+const (
+	DefaultLoadDays = 7
+	MaxLoadDays     = 31
+)
+
+// Days shown after the latest proposed due date of a class in a what-if, in
+// which the alternative due dates are looked for, and their number
+const (
+	WhatIfExtraDays        = 7
+	MaxAlternativeDueDates = 5
+)
+
+// Number of slots proposed for a homework
+const MaxAssignmentSlots = 5
+
+// Days of the week without school, the holidays of each school being in
+// school_holidays
+var weekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// ClassLoad is the committed man-hours of every student of a class per day,
+// from the assignments of every class they are in, whoever the teacher. Only
+// the man-hours are shown, not the homework of the other teachers.
+type ClassLoad struct {
+	ClassID  uuid.UUID     `json:"class_id"`
+	Timezone string        `json:"timezone"` // Of the school, in which the days are
+	Budget   float64       `json:"budget"`   // Daily man-hours of the students without their own
+	Dates    []dates.Date  `json:"dates"`
+	Students []StudentLoad `json:"students"`
+	Summary  []DaySummary  `json:"summary"` // One per date
+}
+
+type StudentLoad struct {
+	StudentID uuid.UUID `json:"student_id"`
+	Name      string    `json:"name"`
+	Budget    float64   `json:"budget"`    // Daily man-hours
+	ManHours  []float64 `json:"man_hours"` // One per date
+}
+
+// DaySummary is the load of the students of the class on a date, showing the
+// hotspots at a glance
+type DaySummary struct {
+	Date               dates.Date `json:"date"`
+	Median             float64    `json:"median"`
+	Max                float64    `json:"max"`
+	OverloadedStudents int        `json:"overloaded_students"` // Over their budget
+}
+
+// WhatIf is the load the proposed assignments would put on the students,
+// nothing being saved. A student is pushed over the budget when over it on a
+// day they were not before the proposals.
+type WhatIf struct {
+	PushedOverBudget int               `json:"pushed_over_budget"` // Students, by all the proposals
+	Proposals        []ProposalOutcome `json:"proposals"`          // In the order of the request
+	Classes          []ClassLoad       `json:"classes"`            // Projected, one per class of the proposals
+}
+
+// ProposalOutcome is the effect of one proposed assignment, the others being
+// made as proposed
+type ProposalOutcome struct {
+	HomeworkID          uuid.UUID    `json:"homework_id"`
+	ClassID             uuid.UUID    `json:"class_id"`
+	PushedOverBudget    int          `json:"pushed_over_budget"` // Students of the class
+	Accepted            bool         `json:"accepted"`           // Pushes no student over the budget
+	AlternativeDueDates []dates.Date `json:"alternative_due_dates"`
+}
+
+// AssignmentSlots are the best windows to assign a homework to a class in,
+// the best first, see workload.Optimize
+type AssignmentSlots struct {
+	ClassID    uuid.UUID        `json:"class_id"`
+	HomeworkID uuid.UUID        `json:"homework_id"`
+	ManHours   float64          `json:"man_hours"`
+	Slots      []AssignmentSlot `json:"slots"`
+}
+
+type AssignmentSlot struct {
+	AssignedAt       time.Time `json:"assigned_at"` // Start of the day in the time zone of the school
+	DueAt            time.Time `json:"due_at"`      // End of the day in the time zone of the school
+	PeakManHours     float64   `json:"peak_man_hours"`
+	PushedOverBudget int       `json:"pushed_over_budget"` // Students
+}
+
+// Class of the proposals of a what-if, with its window
+type whatIfClass struct {
+	class    *models.Class
+	location *time.Location
+	students []*models.User
+	start    dates.Date
+	days     int
+}
+
+// Row of the assignments of the students query
+type studentItemRow struct {
+	StudentID  uuid.UUID
+	HomeworkID uuid.UUID
+	ClassID    uuid.UUID
+	ManHours   float64
+	AssignedAt *time.Time
+	DueAt      time.Time
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// GetClassLoad returns the load of the class for days from the date, today in
+// the time zone of the school when nil
+func (service *LoadService) GetClassLoad(
+	ctx context.Context,
+	classID uuid.UUID,
+	from *dates.Date,
+	days int,
+) (*ClassLoad, error) {
+	class, location, err := service.getClassLocation(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	students, err := service.getClassStudents(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := dates.DateOf(service.Clock.Now().In(location))
+	if from != nil {
+		start = *from
+	}
+
+	itemsByStudent, err := service.getStudentItems(ctx, students, start, days, location)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := studentBudgets(students, service.AppConfig.DailyManHoursBudget)
+	return buildClassLoad(class, location, students, itemsByStudent, start, days, budgets), nil
+}
+
+// PreviewAssignments projects the load of the students of the classes if the
+// assignments were made as proposed, in memory. A proposal for an existing
+// assignment replaces it, as a new due date would. The alternative due dates
+// of a proposal are the closest school days of the window of its class that
+// would push no student over their budget.
+func (service *LoadService) PreviewAssignments(
+	ctx context.Context,
+	proposals []ProposedAssignment,
+) (*WhatIf, error) {
+	if details := validateProposals(proposals); len(details) > 0 {
+		return nil, common.ErrValidation.WithDetails(details)
+	}
+
+	homeworkIDs := []uuid.UUID{}
+	for _, proposal := range proposals {
+		if !slices.Contains(homeworkIDs, proposal.HomeworkID) {
+			homeworkIDs = append(homeworkIDs, proposal.HomeworkID)
+		}
+	}
+	manHours, err := service.getHomeworkManHours(ctx, homeworkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := service.Clock.Now()
+	classes := []*whatIfClass{}
+	classByID := map[uuid.UUID]*whatIfClass{}
+	for _, proposal := range proposals {
+		if _, ok := classByID[proposal.ClassID]; ok {
+			continue
+		}
+
+		class, location, err := service.getClassLocation(ctx, proposal.ClassID)
+		if err != nil {
+			return nil, err
+		}
+		students, err := service.getClassStudents(ctx, class.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		classByID[class.ID] = &whatIfClass{
+			class:    class,
+			location: location,
+			students: students,
+			start:    dates.DateOf(now.In(location)),
+		}
+		classes = append(classes, classByID[class.ID])
+	}
+
+	details := map[string]string{}
+	for i, proposal := range proposals {
+		class := classByID[proposal.ClassID]
+		daysUntilDue := class.start.DaysUntil(dates.DateOf(proposal.DueAt.In(class.location)))
+		if daysUntilDue < 0 || daysUntilDue >= MaxLoadDays {
+			details[fmt.Sprintf("assignments[%d].due_at", i)] = fmt.Sprintf(
+				"due_at must be within %d days from today in the time zone of the school", MaxLoadDays)
+			continue
+		}
+		class.days = max(class.days, min(daysUntilDue+1+WhatIfExtraDays, MaxLoadDays))
+	}
+	if len(details) > 0 {
+		return nil, common.ErrValidation.WithDetails(details)
+	}
+
+	proposedItems := make([]workload.Item, len(proposals))
+	proposedByStudent := map[uuid.UUID][]workload.Item{}
+	for i, proposal := range proposals {
+		proposedItems[i] = workload.Item{
+			HomeworkID: proposal.HomeworkID,
+			ClassID:    proposal.ClassID,
+			ManHours:   manHours[proposal.HomeworkID],
+			AssignedAt: proposal.AssignedAt,
+			DueAt:      proposal.DueAt,
+		}
+		for _, student := range classByID[proposal.ClassID].students {
+			proposedByStudent[student.ID] = append(proposedByStudent[student.ID], proposedItems[i])
+		}
+	}
+
+	whatIf := &WhatIf{
+		Proposals: make([]ProposalOutcome, len(proposals)),
+		Classes:   make([]ClassLoad, 0, len(classes)),
+	}
+	pushedStudents := map[uuid.UUID]bool{}
+	for _, class := range classes {
+		current, err := service.getStudentItems(ctx, class.students, class.start, class.days, class.location)
+		if err != nil {
+			return nil, err
+		}
+		itemsByStudent := current
+		for _, proposal := range proposedItems {
+			itemsByStudent = withoutAssignment(itemsByStudent, proposal.HomeworkID, proposal.ClassID)
+		}
+
+		studentIDs := make([]uuid.UUID, 0, len(class.students))
+		projected := make(map[uuid.UUID][]workload.Item, len(class.students))
+		for _, student := range class.students {
+			studentIDs = append(studentIDs, student.ID)
+			projected[student.ID] = append(slices.Clone(itemsByStudent[student.ID]), proposedByStudent[student.ID]...)
+		}
+
+		from := class.start.StartIn(class.location)
+		budgets := studentBudgets(class.students, service.AppConfig.DailyManHoursBudget)
+		calendar, err := service.getSchoolCalendar(ctx, class.class.SchoolID, class.start, class.days, class.location)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, studentID := range workload.PushedOverBudget(
+			studentIDs, current, projected, from, class.days, budgets,
+		) {
+			pushedStudents[studentID] = true
+		}
+		whatIf.Classes = append(whatIf.Classes,
+			*buildClassLoad(class.class, class.location, class.students, projected, class.start, class.days, budgets))
+
+		for i, item := range proposedItems {
+			if item.ClassID != class.class.ID {
+				continue
+			}
+
+			others := withoutAssignment(projected, item.HomeworkID, item.ClassID)
+			pushed := workload.PushedOverBudget(studentIDs, others, projected, from, class.days, budgets)
+
+			alternatives := []dates.Date{}
+			for _, day := range workload.AlternativeDueDays(
+				studentIDs, others, item, from, class.days, budgets, calendar, MaxAlternativeDueDates,
+			) {
+				alternatives = append(alternatives, dates.DateOf(day))
+			}
+
+			whatIf.Proposals[i] = ProposalOutcome{
+				HomeworkID:          item.HomeworkID,
+				ClassID:             item.ClassID,
+				PushedOverBudget:    len(pushed),
+				Accepted:            len(pushed) == 0,
+				AlternativeDueDates: alternatives,
+			}
+		}
+	}
+	whatIf.PushedOverBudget = len(pushedStudents)
+
+	return whatIf, nil
+}
+
+// GetAssignmentSlots proposes when to assign the homework to the class and
+// when it is due, for days from the date, today in the time zone of the
+// school when nil. An existing assignment of the homework to the class is
+// left out of the load, as if it was rescheduled.
+func (service *LoadService) GetAssignmentSlots(
+	ctx context.Context,
+	classID uuid.UUID,
+	homeworkID uuid.UUID,
+	from *dates.Date,
+	days int,
+) (*AssignmentSlots, error) {
+	class, location, err := service.getClassLocation(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	manHours, err := service.getHomeworkManHours(ctx, []uuid.UUID{homeworkID})
+	if err != nil {
+		return nil, err
+	}
+
+	students, err := service.getClassStudents(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := dates.DateOf(service.Clock.Now().In(location))
+	if from != nil {
+		start = *from
+	}
+
+	itemsByStudent, err := service.getStudentItems(ctx, students, start, days, location)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := service.getSchoolCalendar(ctx, class.SchoolID, start, days, location)
+	if err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+
+	optimized := workload.Optimize(workload.Problem{
+		StudentIDs:     studentIDs,
+		ItemsByStudent: withoutAssignment(itemsByStudent, homeworkID, class.ID),
+		ManHours:       manHours[homeworkID],
+		From:           start.StartIn(location),
+		Days:           days,
+		Budgets:        studentBudgets(students, service.AppConfig.DailyManHoursBudget),
+		Calendar:       calendar,
+	}, MaxAssignmentSlots)
+
+	slots := &AssignmentSlots{
+		ClassID:    class.ID,
+		HomeworkID: homeworkID,
+		ManHours:   manHours[homeworkID],
+		Slots:      make([]AssignmentSlot, 0, len(optimized)),
+	}
+	for _, slot := range optimized {
+		slots.Slots = append(slots.Slots, AssignmentSlot{
+			AssignedAt:       slot.AssignedOn,
+			DueAt:            dates.DueAt(dates.DateOf(slot.DueOn), location),
+			PeakManHours:     round(slot.PeakManHours),
+			PushedOverBudget: slot.PushedOverBudget,
+		})
+	}
+
+	return slots, nil
+}
+
+// ======================== HELPER METHODS ========================
+
+// getHomeworkManHours returns the man-hours of the distinct homework, by ID
+func (service *LoadService) getHomeworkManHours(
+	ctx context.Context,
+	homeworkIDs []uuid.UUID,
+) (map[uuid.UUID]float64, error) {
+	homework := []models.Homework{}
+	result := service.DB.WithContext(ctx).Select("id", "man_hours").Where("id IN ?", homeworkIDs).Find(&homework)
+	if result.Error != nil {
+		logging.FromContext(ctx, service.Logger).Error(
+			"Homework database retrieval failed",
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+	if len(homework) < len(homeworkIDs) {
+		return nil, common.ErrHomeworkNotFound
+	}
+
+	manHours := make(map[uuid.UUID]float64, len(homework))
+	for _, homework := range homework {
+		manHours[homework.ID] = homework.ManHours
+	}
+	return manHours, nil
+}
+
+// getClassLocation returns the class, if the user can see it, and the time
+// zone of its school
+func (service *LoadService) getClassLocation(
+	ctx context.Context,
+	classID uuid.UUID,
+) (*models.Class, *time.Location, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	class := &models.Class{}
+	result := service.DB.WithContext(ctx).First(class, classID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil, common.ErrClassNotFound
+	} else if result.Error != nil {
+		logger.Error(
+			"Class database retrieval failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, nil, common.ErrDatabase
+	}
+
+	school := &models.School{}
+	result = service.DB.WithContext(ctx).Select("id", "timezone").First(school, class.SchoolID)
+	if result.Error != nil {
+		logger.Error(
+			"School database retrieval failed",
+			zap.String("school_id", class.SchoolID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, nil, common.ErrDatabase
+	}
+
+	return class, school.Location(), nil
+}
+
+// getClassStudents returns the active students of the class, by name
+func (service *LoadService) getClassStudents(ctx context.Context, classID uuid.UUID) ([]*models.User, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	students := []*models.User{}
+	result := service.DB.WithContext(ctx).
+		Joins("JOIN class_students AS cs ON cs.student_id = users.id").
+		Where("cs.class_id = ? AND users.deactivated_at IS NULL", classID).
+		Order("users.last_name, users.first_name, users.id").
+		Find(&students)
+	if result.Error != nil {
+		logger.Error(
+			"Class students database retrieval failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return students, nil
+}
+
+// getStudentItems returns the assignments weighing on the students in the
+// days from start, of every class they are in
+func (service *LoadService) getStudentItems(
+	ctx context.Context,
+	students []*models.User,
+	start dates.Date,
+	days int,
+	location *time.Location,
+) (map[uuid.UUID][]workload.Item, error) {
+	itemsByStudent := make(map[uuid.UUID][]workload.Item, len(students))
+	if len(students) == 0 {
+		return itemsByStudent, nil
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+	windowStart := start.StartIn(location)
+	windowEnd := start.AddDays(days).StartIn(location)
+
+	var rows []studentItemRow
+	result := service.DB.WithContext(ctx).
+		Table("assignments AS a").
+		Select("cs.student_id, a.homework_id, a.class_id, h.man_hours, a.assigned_at, a.due_at").
+		Joins("JOIN class_students AS cs ON cs.class_id = a.class_id").
+		Joins("JOIN homework AS h ON h.id = a.homework_id").
+		Joins("JOIN classes AS c ON c.id = a.class_id").
+		Where("cs.student_id IN ? AND a.due_at >= ? AND (a.assigned_at IS NULL OR a.assigned_at < ?)",
+			studentIDs, windowStart, windowEnd).
+		Scopes(tenant.Column("c.school_id")).
+		Order("a.due_at, a.homework_id, a.class_id").
+		Scan(&rows)
+	if result.Error != nil {
+		logging.FromContext(ctx, service.Logger).Error(
+			"Student assignments database retrieval failed",
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	for _, row := range rows {
+		itemsByStudent[row.StudentID] = append(itemsByStudent[row.StudentID], workload.Item{
+			HomeworkID: row.HomeworkID,
+			ClassID:    row.ClassID,
+			ManHours:   row.ManHours,
+			AssignedAt: row.AssignedAt,
+			DueAt:      row.DueAt,
+		})
+	}
+
+	return itemsByStudent, nil
+}
+
+// getSchoolCalendar returns the school days of the school in the days from
+// start
+func (service *LoadService) getSchoolCalendar(
+	ctx context.Context,
+	schoolID uuid.UUID,
+	start dates.Date,
+	days int,
+	location *time.Location,
+) (workload.Calendar, error) {
+	holidays := []models.SchoolHoliday{}
+	result := service.DB.WithContext(ctx).
+		Where("school_id = ? AND date >= ? AND date < ?", schoolID, start.String(), start.AddDays(days).String()).
+		Order("date").
+		Find(&holidays)
+	if result.Error != nil {
+		logging.FromContext(ctx, service.Logger).Error(
+			"School holidays database retrieval failed",
+			zap.String("school_id", schoolID.String()),
+			zap.Error(result.Error),
+		)
+		return workload.Calendar{}, common.ErrDatabase
+	}
+
+	calendar := workload.Calendar{Weekend: weekend, Holidays: make([]time.Time, 0, len(holidays))}
+	for _, holiday := range holidays {
+		calendar.Holidays = append(calendar.Holidays, dates.DateOf(holiday.Date).StartIn(location))
+	}
+	return calendar, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// studentBudgets returns the daily man-hours budgets of the students, their
+// own or the default one
+func studentBudgets(students []*models.User, defaultBudget float64) workload.Budgets {
+	budgets := workload.Budgets{Default: defaultBudget, ByStudent: map[uuid.UUID]float64{}}
+	for _, student := range students {
+		if student.DailyManHoursBudget != nil {
+			budgets.ByStudent[student.ID] = *student.DailyManHoursBudget
+		}
+	}
+	return budgets
+}
+
+func buildClassLoad(
+	class *models.Class,
+	location *time.Location,
+	students []*models.User,
+	itemsByStudent map[uuid.UUID][]workload.Item,
+	start dates.Date,
+	days int,
+	budgets workload.Budgets,
+) *ClassLoad {
+	studentIDs := make([]uuid.UUID, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+	heatmap := workload.BuildHeatmap(studentIDs, itemsByStudent, start.StartIn(location), days, budgets)
+
+	load := &ClassLoad{
+		ClassID:  class.ID,
+		Timezone: location.String(),
+		Budget:   budgets.Default,
+		Dates:    make([]dates.Date, days),
+		Students: make([]StudentLoad, len(students)),
+		Summary:  make([]DaySummary, days),
+	}
+
+	for i, day := range heatmap.Days {
+		load.Dates[i] = dates.DateOf(day)
+		load.Summary[i] = DaySummary{
+			Date:               load.Dates[i],
+			Median:             round(heatmap.Summary[i].Median),
+			Max:                round(heatmap.Summary[i].Max),
+			OverloadedStudents: heatmap.Summary[i].Overloaded,
+		}
+	}
+
+	for s, student := range students {
+		manHours := make([]float64, days)
+		for i, hours := range heatmap.ManHours[s] {
+			manHours[i] = round(hours)
+		}
+		load.Students[s] = StudentLoad{
+			StudentID: student.ID,
+			Name:      fullName(student),
+			Budget:    budgets.Of(student.ID),
+			ManHours:  manHours,
+		}
+	}
+
+	return load
+}
+
+// validateProposals checks the proposals on their own, by field
+func validateProposals(proposals []ProposedAssignment) map[string]string {
+	details := map[string]string{}
+	for i, proposal := range proposals {
+		if proposal.AssignedAt != nil && !proposal.DueAt.After(*proposal.AssignedAt) {
+			details[fmt.Sprintf("assignments[%d].due_at", i)] = "due_at must be after assigned_at"
+		}
+		for _, previous := range proposals[:i] {
+			if previous.HomeworkID == proposal.HomeworkID && previous.ClassID == proposal.ClassID {
+				details[fmt.Sprintf("assignments[%d]", i)] = "homework is already proposed to the class"
+				break
+			}
+		}
+	}
+	return details
+}
+
+// withoutAssignment returns the items of the students but those of the
+// homework assigned to the class
+func withoutAssignment(
+	itemsByStudent map[uuid.UUID][]workload.Item,
+	homeworkID uuid.UUID,
+	classID uuid.UUID,
+) map[uuid.UUID][]workload.Item {
+	filtered := make(map[uuid.UUID][]workload.Item, len(itemsByStudent))
+	for studentID, items := range itemsByStudent {
+		filtered[studentID] = slices.DeleteFunc(slices.Clone(items), func(item workload.Item) bool {
+			return item.HomeworkID == homeworkID && item.ClassID == classID
+		})
+	}
+	return filtered
+}
+
+// round rounds man-hours to the hundredth, as the homework are
+func round(manHours float64) float64 {
+	return math.Round(manHours*100) / 100
+}
+
+func fullName(user *models.User) string {
+	names := []string{user.FirstName, user.MiddleName, user.LastName}
+	return strings.Join(strings.Fields(strings.Join(names, " ")), " ")
+}
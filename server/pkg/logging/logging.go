@@ -0,0 +1,52 @@
+// Package logging carries the logger of a request in its context.
+//
+// The router middleware scopes a logger to each request with its request_id
+// and route, and AuthMiddleware adds the user_id. Services log through
+// FromContext so that their logs can be correlated with the access log.
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Header accepted from the client and echoed in every response
+const RequestIDHeader = "X-Request-ID"
+
+type loggerKey struct{}
+
+type requestIDKey struct{}
+
+// WithLogger returns ctx carrying the request scoped logger
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger ctx carries, or fallback outside of a request
+// e.g. in jobs and tgsctl
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if ctx == nil {
+		return fallback
+	}
+
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// WithRequestID returns ctx carrying the ID of its request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID of the request of ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
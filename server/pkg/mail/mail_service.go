@@ -1,73 +1,193 @@
 package mailfx
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"html/template"
+	htmltemplate "html/template"
+	"os"
+	texttemplate "text/template"
+	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
 	gomail "github.com/wneessen/go-mail"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type MailServiceParams struct {
 	fx.In
-	FlagConfig *configfx.FlagConfig
-	AppConfig  *configfx.AppConfig
-	Logger     *zap.Logger
-	MailClient *gomail.Client
+	FlagConfig         *configfx.FlagConfig
+	AppConfig          *configfx.AppConfig
+	Logger             *zap.Logger
+	DB                 *gorm.DB
+	MailClient         *gomail.Client
+	Metrics            *metricsfx.Metrics
+	TracerProvider     trace.TracerProvider
+	PreferencesService preferencesfx.PreferencesServiceInterface
+	Clock              clock.Clock
 }
 
 type MailService struct {
 	FlagConfig                  *configfx.FlagConfig
 	AppConfig                   *configfx.AppConfig
 	Logger                      *zap.Logger
+	DB                          *gorm.DB
 	MailClient                  *gomail.Client
-	RegistrationWarningTpl      *template.Template
-	RegistrationVerificationTpl *template.Template
-	ResetPwdTpl                 *template.Template
+	Metrics                     *metricsfx.Metrics
+	Tracer                      trace.Tracer
+	PreferencesService          preferencesfx.PreferencesServiceInterface
+	Clock                       clock.Clock
+	RegistrationWarningTpl      *MailTemplate
+	RegistrationVerificationTpl *MailTemplate
+	ResetPwdTpl                 *MailTemplate
+	DigestTpl                   *MailTemplate
+	ReminderTpl                 *MailTemplate
+	RosterInvitationTpl         *MailTemplate
+	ClassInvitationTpl          *MailTemplate
+}
+
+// MailTemplate pairs an HTML body with its plain-text alternative.
+// Text is nil when the template has no explicit .txt file, in which case
+// the alternative is derived from the rendered HTML.
+type MailTemplate struct {
+	Name string // Recorded in the outbox
+	HTML *htmltemplate.Template
+	Text *texttemplate.Template
 }
 
 const (
-	sender  = "noreply@touchgrassscheduler.com"
 	appName = "Touch-Grass-Scheduler"
+
+	templatesDir = "pkg/mail/templates"
+	logoPath     = "pkg/mail/assets/logo.png"
+	logoCID      = "logo" // Referenced as "cid:logo" in the HTML templates
 )
 
+// templateFuncs are the functions available to every mail template (HTML and
+// text), formatting dates in the time zone and locale of the recipient
+func templateFuncs(preferences *models.UserPreferences) map[string]any {
+	location := preferences.Location()
+
+	return map[string]any{
+		"formatDate": func(t time.Time) string {
+			return i18n.FormatDate(preferences.Locale, t.In(location))
+		},
+		"formatDateTime": func(t time.Time) string {
+			return i18n.FormatDateTime(preferences.Locale, t.In(location))
+		},
+		"formatHours": func(hours float64) string {
+			return fmt.Sprintf("%.1f", hours)
+		},
+	}
+}
+
+// DigestSection is the upcoming homework of one student in a digest
+type DigestSection struct {
+	StudentName string
+	Days        []workload.Day
+}
+
+// Data of the templates that can be rendered again from the outbox, none of
+// them has a one-time link
+type registrationWarningData struct {
+	UserFirstName     string
+	UserEmail         string
+	AppName           string
+	ForgotPasswordURL string
+}
+
+type digestData struct {
+	UserFirstName string
+	AppName       string
+	Frequency     types.DigestFrequency
+	DaysAhead     int
+	DailyBudget   float64
+	Sections      []DigestSection
+}
+
+type reminderData struct {
+	UserFirstName string
+	AppName       string
+	Title         string
+	Lines         []string
+}
+
 // ======================== METHODS ========================
 
 func NewMailService(params MailServiceParams) *MailService {
-	registrationWarningTpl, err := template.
-		ParseFiles("pkg/mail/templates/registration_warning.html")
+	registrationWarningTpl, err := parseMailTemplate("registration_warning")
 	if err != nil {
 		params.Logger.Fatal("Error parsing Registration Warning Template", zap.Error(err))
 	}
 
-	registrationVerificationTpl, err := template.
-		ParseFiles("pkg/mail/templates/registration_verification.html")
+	registrationVerificationTpl, err := parseMailTemplate("registration_verification")
 	if err != nil {
 		params.Logger.Fatal("Error parsing Registration Verification Template", zap.Error(err))
 	}
 
-	resetPwdTpl, err := template.ParseFiles("pkg/mail/templates/reset_password.html")
+	resetPwdTpl, err := parseMailTemplate("reset_password")
 	if err != nil {
 		params.Logger.Fatal("Error parsing Reset Password Template", zap.Error(err))
 	}
 
+	digestTpl, err := parseMailTemplate("digest")
+	if err != nil {
+		params.Logger.Fatal("Error parsing Digest Template", zap.Error(err))
+	}
+
+	reminderTpl, err := parseMailTemplate("reminder")
+	if err != nil {
+		params.Logger.Fatal("Error parsing Reminder Template", zap.Error(err))
+	}
+
+	rosterInvitationTpl, err := parseMailTemplate("roster_invitation")
+	if err != nil {
+		params.Logger.Fatal("Error parsing Roster Invitation Template", zap.Error(err))
+	}
+
+	classInvitationTpl, err := parseMailTemplate("class_invitation")
+	if err != nil {
+		params.Logger.Fatal("Error parsing Class Invitation Template", zap.Error(err))
+	}
+
 	return &MailService{
 		FlagConfig:                  params.FlagConfig,
 		AppConfig:                   params.AppConfig,
 		Logger:                      params.Logger,
+		DB:                          params.DB,
 		MailClient:                  params.MailClient,
+		Metrics:                     params.Metrics,
+		Tracer:                      params.TracerProvider.Tracer(tracingfx.TracerName),
+		PreferencesService:          params.PreferencesService,
+		Clock:                       params.Clock,
 		RegistrationWarningTpl:      registrationWarningTpl,
 		RegistrationVerificationTpl: registrationVerificationTpl,
 		ResetPwdTpl:                 resetPwdTpl,
+		DigestTpl:                   digestTpl,
+		ReminderTpl:                 reminderTpl,
+		RosterInvitationTpl:         rosterInvitationTpl,
+		ClassInvitationTpl:          classInvitationTpl,
 	}
 }
 
-func (service *MailService) SendRegistrationWarning(user *models.User) error {
+func (service *MailService) SendRegistrationWarning(ctx context.Context, user *models.User) error {
 	// For non-production environment
 	if service.FlagConfig.Environment != "production" {
 		service.Logger.Info(
@@ -79,12 +199,7 @@ func (service *MailService) SendRegistrationWarning(user *models.User) error {
 
 	subject := "Did you try to sign up for Touch-Grass-Scheduler?"
 
-	data := &struct {
-		UserFirstName     string
-		UserEmail         string
-		AppName           string
-		ForgotPasswordURL string
-	}{
+	data := &registrationWarningData{
 		UserFirstName: user.FirstName,
 		UserEmail:     user.Email,
 		AppName:       appName,
@@ -94,7 +209,7 @@ func (service *MailService) SendRegistrationWarning(user *models.User) error {
 		),
 	}
 
-	err := service.setBodyAndSend(user.Email, sender, subject, service.RegistrationWarningTpl, data)
+	err := service.setBodyAndSend(ctx, user.Email, &user.ID, subject, service.RegistrationWarningTpl, data)
 	if err != nil {
 		return err
 	}
@@ -103,6 +218,7 @@ func (service *MailService) SendRegistrationWarning(user *models.User) error {
 }
 
 func (service *MailService) SendRegistrationVerification(
+	ctx context.Context,
 	email string,
 	registrationToken string,
 ) error {
@@ -132,7 +248,7 @@ func (service *MailService) SendRegistrationVerification(
 		),
 	}
 
-	err := service.setBodyAndSend(email, sender, subject, service.RegistrationVerificationTpl, data)
+	err := service.setBodyAndSend(ctx, email, nil, subject, service.RegistrationVerificationTpl, data)
 	if err != nil {
 		return err
 	}
@@ -140,7 +256,7 @@ func (service *MailService) SendRegistrationVerification(
 	return nil
 }
 
-func (service *MailService) SendResetPwd(user *models.User, resetPwdToken string) error {
+func (service *MailService) SendResetPwd(ctx context.Context, user *models.User, resetPwdToken string) error {
 	// For non-production environment
 	if service.FlagConfig.Environment != "production" {
 		service.Logger.Info(
@@ -170,7 +286,167 @@ func (service *MailService) SendResetPwd(user *models.User, resetPwdToken string
 		),
 	}
 
-	err := service.setBodyAndSend(user.Email, sender, subject, service.ResetPwdTpl, data)
+	err := service.setBodyAndSend(ctx, user.Email, &user.ID, subject, service.ResetPwdTpl, data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *MailService) SendDigest(
+	ctx context.Context,
+	user *models.User,
+	frequency types.DigestFrequency,
+	sections []DigestSection,
+) error {
+	// For non-production environment
+	if service.FlagConfig.Environment != "production" {
+		service.Logger.Info(
+			"Mail sending interception",
+			zap.String("mail_type", "digest"),
+			zap.String("user_id", user.ID.String()),
+			zap.Int("section_count", len(sections)),
+		)
+		return nil
+	}
+
+	subject := fmt.Sprintf("Your %s homework digest from %s", frequency, appName)
+
+	data := &digestData{
+		UserFirstName: user.FirstName,
+		AppName:       appName,
+		Frequency:     frequency,
+		DaysAhead:     service.AppConfig.DigestDaysAhead,
+		DailyBudget:   service.AppConfig.DailyManHoursBudget,
+		Sections:      sections,
+	}
+
+	err := service.setBodyAndSend(ctx, user.Email, &user.ID, subject, service.DigestTpl, data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendReminder sends a due-date reminder or an overdue escalation.
+// Each line is rendered as its own paragraph.
+func (service *MailService) SendReminder(ctx context.Context, user *models.User, title string, lines []string) error {
+	// For non-production environment
+	if service.FlagConfig.Environment != "production" {
+		service.Logger.Info(
+			"Mail sending interception",
+			zap.String("mail_type", "reminder"),
+			zap.String("user_id", user.ID.String()),
+			zap.String("title", title),
+		)
+		return nil
+	}
+
+	data := &reminderData{
+		UserFirstName: user.FirstName,
+		AppName:       appName,
+		Title:         title,
+		Lines:         lines,
+	}
+
+	err := service.setBodyAndSend(ctx, user.Email, &user.ID, title, service.ReminderTpl, data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendRosterInvitation invites a student imported from a roster to set their password
+func (service *MailService) SendRosterInvitation(
+	ctx context.Context,
+	user *models.User,
+	className string,
+	setPwdToken string,
+) error {
+	// For non-production environment
+	if service.FlagConfig.Environment != "production" {
+		service.Logger.Info(
+			"Mail sending interception",
+			zap.String("mail_type", "roster_invitation"),
+			zap.String("user_id", user.ID.String()),
+			zap.String("set_password_token", setPwdToken),
+		)
+		return nil
+	}
+
+	subject := fmt.Sprintf("You are invited to %s", appName)
+
+	data := &struct {
+		UserFirstName string
+		AppName       string
+		ClassName     string
+		ExpiresIn     int
+		SetPwdURL     string
+	}{
+		UserFirstName: user.FirstName,
+		AppName:       appName,
+		ClassName:     className,
+		ExpiresIn:     service.AppConfig.InvitationExpiresIn, // days
+		SetPwdURL: fmt.Sprintf("%s/%s/%s",
+			service.AppConfig.ClientURL,
+			endpoints.ClientSetPwd,
+			setPwdToken,
+		),
+	}
+
+	err := service.setBodyAndSend(ctx, user.Email, &user.ID, subject, service.RosterInvitationTpl, data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendClassInvitation invites an email into a class, with a link to register through
+func (service *MailService) SendClassInvitation(
+	ctx context.Context,
+	invitation *models.Invitation,
+	className string,
+	inviterName string,
+	invitationToken string,
+) error {
+	// For non-production environment
+	if service.FlagConfig.Environment != "production" {
+		service.Logger.Info(
+			"Mail sending interception",
+			zap.String("mail_type", "class_invitation"),
+			zap.String("invitation_id", invitation.ID.String()),
+			zap.String("invitation_token", invitationToken),
+		)
+		return nil
+	}
+
+	subject := fmt.Sprintf("You are invited to %s on %s", className, appName)
+
+	data := &struct {
+		AppName       string
+		InviterName   string
+		ClassName     string
+		Role          string
+		ExpiresIn     int
+		InvitationURL string
+	}{
+		AppName:     appName,
+		InviterName: inviterName,
+		ClassName:   className,
+		Role:        string(invitation.Role),
+		ExpiresIn:   service.AppConfig.InvitationExpiresIn, // days
+		InvitationURL: fmt.Sprintf("%s/%s/%s",
+			service.AppConfig.ClientURL,
+			endpoints.ClientInvitation,
+			invitationToken,
+		),
+	}
+
+	err := service.setBodyAndSend(ctx, invitation.Email, nil, subject, service.ClassInvitationTpl, data)
 	if err != nil {
 		return err
 	}
@@ -178,40 +454,289 @@ func (service *MailService) SendResetPwd(user *models.User, resetPwdToken string
 	return nil
 }
 
+// ResendMail sends a mail from the outbox again, e.g. after a delivery failure,
+// rendered from its template and data. A mail with a one-time link cannot be
+// re-sent, its link is never stored and may be stale, the flow that sent it
+// issues a new one.
+func (service *MailService) ResendMail(ctx context.Context, mailID uuid.UUID) error {
+	mail := &models.MailOutbox{}
+
+	result := service.DB.WithContext(ctx).First(mail, mailID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		service.Logger.Debug(
+			"Mail outbox database retrieval skipped",
+			zap.String("reason", "mail_not_found"),
+			zap.String("mail_id", mailID.String()),
+		)
+		return common.ErrMailNotFound
+	} else if result.Error != nil {
+		service.Logger.Error(
+			"Mail outbox database retrieval failed",
+			zap.String("mail_id", mailID.String()),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase
+	}
+
+	tpl, data, ok := service.resendableTemplate(mail.Template)
+	if !ok || mail.Data == nil {
+		service.Logger.Debug(
+			"Mail resending skipped",
+			zap.String("reason", "one_time_link"),
+			zap.String("mail_id", mailID.String()),
+			zap.String("mail_type", mail.Template),
+		)
+		return common.ErrMailNotResendable
+	}
+
+	// For non-production environment
+	if service.FlagConfig.Environment != "production" {
+		service.Logger.Info(
+			"Mail sending interception",
+			zap.String("mail_type", mail.Template),
+			zap.String("mail_id", mailID.String()),
+		)
+		return nil
+	}
+
+	if err := json.Unmarshal(mail.Data, data); err != nil {
+		service.Logger.Error(
+			"Error decoding the mail data",
+			zap.String("mail_id", mailID.String()),
+			zap.Error(err),
+		)
+		return common.ErrMailHTMLSetting
+	}
+
+	htmlBody, textBody, err := service.render(ctx, mail.UserID, tpl, data)
+	if err != nil {
+		return err
+	}
+
+	mail.Attempts++
+
+	return service.send(ctx, mail, htmlBody, textBody)
+}
+
 // ======================== HELPER METHODS ========================
 
+// setBodyAndSend renders the mail for the reciever, userID is nil for an
+// email without an account yet. The outbox keeps the data only when the mail
+// can be rendered again, see resendableTemplate.
 func (service *MailService) setBodyAndSend(
-	reciever, sender, subject string,
-	tpl *template.Template,
+	ctx context.Context,
+	reciever string,
+	userID *uuid.UUID,
+	subject string,
+	tpl *MailTemplate,
 	data any,
 ) error {
+	htmlBody, textBody, err := service.render(ctx, userID, tpl, data)
+	if err != nil {
+		return err
+	}
+
+	mail := &models.MailOutbox{
+		Recipient: reciever,
+		UserID:    userID,
+		Subject:   subject,
+		Template:  tpl.Name,
+		Attempts:  1,
+	}
+
+	if _, _, ok := service.resendableTemplate(tpl.Name); ok {
+		if mail.Data, err = json.Marshal(data); err != nil {
+			service.Logger.Error("Error encoding the mail data", zap.Error(err))
+			return common.ErrMailHTMLSetting
+		}
+	}
+
+	return service.send(ctx, mail, htmlBody, textBody)
+}
+
+// render renders the dates in the preferences of the user, the default ones
+// when nil
+func (service *MailService) render(
+	ctx context.Context,
+	userID *uuid.UUID,
+	tpl *MailTemplate,
+	data any,
+) (string, string, error) {
+	preferences := models.DefaultUserPreferences(uuid.Nil)
+	if userID != nil {
+		preferences = service.preferencesOf(ctx, *userID)
+	}
+
+	htmlBody, textBody, err := renderMailTemplate(tpl, data, templateFuncs(preferences))
+	if err != nil {
+		service.Logger.Error("Error rendering the message body", zap.Error(err))
+		return "", "", common.ErrMailHTMLSetting
+	}
+
+	return htmlBody, textBody, nil
+}
+
+// resendableTemplate returns the template named name with a pointer to its
+// data, when a mail can be rendered again from the outbox
+func (service *MailService) resendableTemplate(name string) (*MailTemplate, any, bool) {
+	switch name {
+	case service.RegistrationWarningTpl.Name:
+		return service.RegistrationWarningTpl, &registrationWarningData{}, true
+	case service.DigestTpl.Name:
+		return service.DigestTpl, &digestData{}, true
+	case service.ReminderTpl.Name:
+		return service.ReminderTpl, &reminderData{}, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// preferencesOf returns the preferences of a user, the default ones when
+// they cannot be read so that the mail is still sent
+func (service *MailService) preferencesOf(ctx context.Context, userID uuid.UUID) *models.UserPreferences {
+	preferences, err := service.PreferencesService.GetPreferences(ctx, userID)
+	if err != nil {
+		return models.DefaultUserPreferences(userID) // Logged by the service
+	}
+	return preferences
+}
+
+// send delivers the mail with its bodies and records the outcome in the outbox
+func (service *MailService) send(ctx context.Context, mail *models.MailOutbox, htmlBody, textBody string) error {
+	ctx, span := service.Tracer.Start(ctx, "mail.send", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
 	var err error
 
 	msg := gomail.NewMsg()
 
-	err = msg.To(reciever)
+	err = msg.To(mail.Recipient)
 	if err != nil {
 		service.Logger.Error("", zap.Error(err))
 		return err
 	}
 
-	err = msg.From(sender)
+	err = msg.FromFormat(service.AppConfig.MailSenderName, service.AppConfig.MailSenderAddress)
 	if err != nil {
 		service.Logger.Error("", zap.Error(err))
 		return err
 	}
 
-	msg.Subject(subject)
-
-	if err := msg.SetBodyHTMLTemplate(tpl, data); err != nil {
-		service.Logger.Error("Error setting HTML body to the message", zap.Error(err))
-		return common.ErrMailHTMLSetting
+	if service.AppConfig.MailReplyTo != "" {
+		err = msg.ReplyTo(service.AppConfig.MailReplyTo)
+		if err != nil {
+			service.Logger.Error("", zap.Error(err))
+			return err
+		}
 	}
 
-	if err := service.MailClient.DialAndSend(msg); err != nil {
-		service.Logger.Error("Error sending message", zap.Error(err))
+	msg.Subject(mail.Subject)
+
+	// multipart/alternative, the last part is the one preferred by clients
+	msg.SetBodyString(gomail.TypeTextPlain, textBody)
+	msg.AddAlternativeString(gomail.TypeTextHTML, htmlBody)
+
+	// Inline the logo so that clients blocking remote images still render it
+	msg.EmbedFile(logoPath, gomail.WithFileName("logo.png"), gomail.WithFileContentID(logoCID))
+
+	sendErr := service.MailClient.DialAndSendWithContext(ctx, msg)
+	service.recordOutbox(ctx, mail, sendErr)
+
+	if sendErr != nil {
+		span.RecordError(sendErr)
+		span.SetStatus(codes.Error, "mail sending failed")
+		service.Metrics.MailSends.WithLabelValues("failed").Inc()
+		service.Logger.Error("Error sending message", zap.Error(sendErr))
 		return common.ErrMailSending
 	}
+	service.Metrics.MailSends.WithLabelValues("sent").Inc()
 
 	return nil
 }
+
+// recordOutbox saves the mail with its delivery outcome. A failure here is
+// only logged, the mail may already be sent.
+func (service *MailService) recordOutbox(ctx context.Context, mail *models.MailOutbox, sendErr error) {
+	if sendErr != nil {
+		errMsg := sendErr.Error()
+		mail.Status = types.MailStatusFailed
+		mail.Error = &errMsg
+	} else {
+		now := service.Clock.Now()
+		mail.Status = types.MailStatusSent
+		mail.Error = nil
+		mail.SentAt = &now
+	}
+
+	if result := service.DB.WithContext(ctx).Save(mail); result.Error != nil {
+		service.Logger.Error(
+			"Mail outbox database update failed",
+			zap.String("recipient", mail.Recipient),
+			zap.Error(result.Error),
+		)
+	}
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// parseMailTemplate loads <name>.html and, if present, its <name>.txt sibling
+func parseMailTemplate(name string) (*MailTemplate, error) {
+	// Bound to the preferences of each recipient when rendering
+	funcs := templateFuncs(models.DefaultUserPreferences(uuid.Nil))
+
+	htmlTpl, err := htmltemplate.New(name + ".html").
+		Funcs(funcs).
+		ParseFiles(fmt.Sprintf("%s/%s.html", templatesDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing html template: %w", err)
+	}
+
+	mailTpl := &MailTemplate{Name: name, HTML: htmlTpl}
+
+	textPath := fmt.Sprintf("%s/%s.txt", templatesDir, name)
+	if _, err := os.Stat(textPath); errors.Is(err, os.ErrNotExist) {
+		return mailTpl, nil
+	}
+
+	textTpl, err := texttemplate.New(name + ".txt").
+		Funcs(funcs).
+		ParseFiles(textPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing text template: %w", err)
+	}
+	mailTpl.Text = textTpl
+
+	return mailTpl, nil
+}
+
+// renderMailTemplate renders the HTML body and its plain-text alternative,
+// with the funcs of the recipient. The parsed templates are only cloned, never
+// executed, so that they can be cloned again.
+func renderMailTemplate(tpl *MailTemplate, data any, funcs map[string]any) (string, string, error) {
+	htmlTpl, err := tpl.HTML.Clone()
+	if err != nil {
+		return "", "", err
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := htmlTpl.Funcs(funcs).Execute(&htmlBuf, data); err != nil {
+		return "", "", err
+	}
+
+	// No explicit text template, derive it from the rendered HTML
+	if tpl.Text == nil {
+		return htmlBuf.String(), PlainTextFromHTML(htmlBuf.String()), nil
+	}
+
+	textTpl, err := tpl.Text.Clone()
+	if err != nil {
+		return "", "", err
+	}
+
+	var textBuf bytes.Buffer
+	if err := textTpl.Funcs(funcs).Execute(&textBuf, data); err != nil {
+		return "", "", err
+	}
+
+	return htmlBuf.String(), textBuf.String(), nil
+}
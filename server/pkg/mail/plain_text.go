@@ -0,0 +1,102 @@
+package mailfx
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	inlineSpaces = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainTextFromHTML derives a readable plain-text version of an HTML mail.
+// Styles, scripts and the document head are dropped, block elements become
+// line breaks, list items get a dash and links keep their target URL.
+func PlainTextFromHTML(htmlStr string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlStr))
+
+	type openLink struct {
+		href  string
+		start int // Offset of the link text in the builder
+	}
+
+	var sb strings.Builder
+	var links []openLink // Stack of open <a> tags
+	skipDepth := 0       // Inside <head>, <style> or <script>
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "head", "style", "script":
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+			case "br", "p", "div", "tr", "table", "ul", "ol", "h1", "h2", "h3", "h4", "h5", "h6":
+				sb.WriteString("\n")
+			case "hr":
+				sb.WriteString("\n\n")
+			case "li":
+				sb.WriteString("\n- ")
+			case "a":
+				links = append(links, openLink{href: attr(token, "href"), start: sb.Len()})
+			case "img":
+				if alt := attr(token, "alt"); alt != "" && skipDepth == 0 {
+					sb.WriteString(alt)
+				}
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "head", "style", "script":
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			case "p", "div", "tr", "table", "ul", "ol", "h1", "h2", "h3", "h4", "h5", "h6":
+				sb.WriteString("\n")
+			case "a":
+				if len(links) == 0 {
+					continue
+				}
+				link := links[len(links)-1]
+				links = links[:len(links)-1]
+
+				// Keep the URL unless the link text already shows it
+				if link.href != "" && !strings.Contains(sb.String()[link.start:], link.href) {
+					sb.WriteString(" (" + link.href + ")")
+				}
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				sb.WriteString(strings.ReplaceAll(token.Data, "\n", " "))
+			}
+		}
+	}
+
+	// Normalize whitespace line by line
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(inlineSpaces.ReplaceAllString(line, " "))
+	}
+	text := strings.Join(lines, "\n")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text) + "\n"
+}
+
+func attr(token html.Token, key string) string {
+	for _, a := range token.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package metricsfx
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"metricsfx",
+	fx.Provide(NewMetrics),
+	fx.Invoke(registerDBStats, registerHooks),
+)
+
+const namespace = "tgs"
+
+// Metrics holds every collector, registered to its own registry
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	HTTPRequestDuration *prometheus.HistogramVec // method, route, status
+
+	StorageRequests        *prometheus.CounterVec   // operation, code
+	StorageRequestDuration *prometheus.HistogramVec // operation
+
+	MailSends *prometheus.CounterVec // outcome
+
+	Registrations *prometheus.CounterVec // method
+	Logins        *prometheus.CounterVec // outcome
+}
+
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	metrics := &Metrics{
+		Registry: registry,
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of the HTTP requests by route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		StorageRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "storage_requests_total",
+			Help:      "Requests to the object storage by operation and HTTP status code, \"error\" when none was received.",
+		}, []string{"operation", "code"}),
+		StorageRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "storage_request_duration_seconds",
+			Help:      "Duration of the requests to the object storage by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		MailSends: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mail_sends_total",
+			Help:      "Mails sent by outcome, sent or failed.",
+		}, []string{"outcome"}),
+		Registrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "registrations_total",
+			Help:      "Registered users by method, link or invitation.",
+		}, []string{"method"}),
+		Logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by outcome, succeeded or failed.",
+		}, []string{"outcome"}),
+	}
+
+	registry.MustRegister(
+		metrics.HTTPRequestDuration,
+		metrics.StorageRequests,
+		metrics.StorageRequestDuration,
+		metrics.MailSends,
+		metrics.Registrations,
+		metrics.Logins,
+	)
+
+	return metrics
+}
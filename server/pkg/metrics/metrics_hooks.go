@@ -0,0 +1,58 @@
+package metricsfx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// registerDBStats exports the stats of the connection pool
+func registerDBStats(metrics *Metrics, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying database: %w", err)
+	}
+
+	return metrics.Registry.Register(collectors.NewDBStatsCollector(sqlDB, "postgres"))
+}
+
+// registerHooks serves /metrics on its own port, never exposed with the API
+func registerHooks(
+	lc fx.Lifecycle,
+	appConfig *configfx.AppConfig,
+	metrics *Metrics,
+	logger *zap.Logger,
+) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+	srv := &http.Server{
+		Addr:    ":" + strconv.Itoa(appConfig.MetricsPort),
+		Handler: mux,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				err := srv.ListenAndServe()
+				if err != nil && err != http.ErrServerClosed {
+					logger.Error("Failed to start metrics server", zap.Error(err))
+				}
+			}()
+
+			logger.Info("Metrics server started", zap.Int("port", appConfig.MetricsPort))
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return srv.Shutdown(ctx)
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package metricsfx
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StorageTransport records every request of the storage client
+type StorageTransport struct {
+	Base    http.RoundTripper
+	Metrics *Metrics
+}
+
+func (transport *StorageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := StorageOperation(req)
+	start := time.Now()
+
+	res, err := transport.Base.RoundTrip(req)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(res.StatusCode)
+	}
+	transport.Metrics.StorageRequests.WithLabelValues(operation, code).Inc()
+	transport.Metrics.StorageRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	return res, err
+}
+
+// StorageOperation names the S3 operation of a request, presigned URLs are
+// used by the client directly and never pass through here
+func StorageOperation(req *http.Request) string {
+	query := req.URL.Query()
+
+	switch req.Method {
+	case http.MethodHead:
+		return "stat"
+	case http.MethodGet:
+		switch {
+		case query.Has("location"):
+			return "get_bucket_location"
+		case query.Has("list-type"):
+			return "list_objects"
+		default:
+			return "get_object"
+		}
+	case http.MethodPut:
+		return "put_object"
+	case http.MethodDelete:
+		return "remove_object"
+	case http.MethodPost:
+		if query.Has("delete") {
+			return "remove_objects"
+		}
+	}
+
+	return strings.ToLower(req.Method)
+}
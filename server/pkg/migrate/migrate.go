@@ -0,0 +1,11 @@
+package migratefx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"migratefx",
+	fx.Provide(
+		NewMigrator,
+	),
+	fx.Invoke(registerHooks),
+)
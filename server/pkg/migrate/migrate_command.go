@@ -0,0 +1,68 @@
+package migratefx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const CommandUsage = `Commands:
+  up          Apply every pending migration
+  down [n]    Revert the last n migrations (default 1)
+  status      List the migrations and whether they are applied`
+
+// ErrInvalidCommand is returned for unknown commands or invalid arguments
+var ErrInvalidCommand = errors.New("invalid migrate command")
+
+// RunCommand runs a "migrate" subcommand and writes its report to out.
+// Shared by every binary that exposes the migrations.
+func (migrator *Migrator) RunCommand(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrInvalidCommand
+	}
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Applied %d migration(s)\n", count)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("%w: n must be a positive number", ErrInvalidCommand)
+			}
+		}
+
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Reverted %d migration(s)\n", count)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(out, "%04d  %-32s  %s\n", status.Version, status.Name, applied)
+		}
+
+	default:
+		return ErrInvalidCommand
+	}
+
+	return nil
+}
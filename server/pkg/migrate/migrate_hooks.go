@@ -0,0 +1,30 @@
+package migratefx
+
+import (
+	"context"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"go.uber.org/fx"
+)
+
+// =============== Private Methods ===============
+func registerHooks(lc fx.Lifecycle, appConfig *configfx.AppConfig, migrator *Migrator) {
+	lc.Append(
+		fx.Hook{
+			// Runs before the jobs and the HTTP server are started
+			OnStart: func(ctx context.Context) error {
+				if appConfig.MigrateOnStart {
+					if _, err := migrator.Up(ctx); err != nil {
+						return err
+					}
+				}
+
+				if appConfig.SchemaVersionCheck {
+					return migrator.CheckVersion(ctx)
+				}
+
+				return nil
+			},
+		},
+	)
+}
@@ -0,0 +1,328 @@
+package migratefx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/migrations"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pglock"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type MigratorParams struct {
+	fx.In
+	Logger *zap.Logger
+	DB     *gorm.DB
+}
+
+// Migrator applies the embedded migrations. Every migration runs in its own
+// transaction together with its schema_migrations row, and a Postgres advisory
+// lock keeps replicas from migrating at the same time.
+type Migrator struct {
+	Logger     *zap.Logger
+	DB         *gorm.DB
+	migrations []*Migration
+}
+
+// MigrationStatus is a known migration and whether it is applied
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// Row of the schema_migrations table
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+const migrationsLockName = "tgs:schema_migrations"
+
+const createMigrationsTableSQL = `CREATE TABLE IF NOT EXISTS "schema_migrations" (
+    "version" BIGINT PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "applied_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+func NewMigrator(params MigratorParams) (*Migrator, error) {
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		Logger:     params.Logger,
+		DB:         params.DB,
+		migrations: loaded,
+	}, nil
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// Up applies every pending migration and returns how many were applied
+func (migrator *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+
+	err := migrator.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := migrator.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := migrator.checkKnown(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range migrator.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			migrator.Logger.Info("Migration applying",
+				zap.Int64("version", migration.Version),
+				zap.String("name", migration.Name),
+			)
+
+			err := migrator.inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					`INSERT INTO "schema_migrations" ("version", "name") VALUES ($1, $2)`,
+					migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down reverts the last `steps` applied migrations and returns how many were reverted
+func (migrator *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+
+	err := migrator.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := migrator.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := migrator.checkKnown(applied); err != nil {
+			return err
+		}
+
+		for i := len(migrator.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := migrator.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			migrator.Logger.Info("Migration reverting",
+				zap.Int64("version", migration.Version),
+				zap.String("name", migration.Name),
+			)
+
+			err := migrator.inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					`DELETE FROM "schema_migrations" WHERE "version" = $1`,
+					migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Status lists the known migrations, followed by any applied migration
+// this binary does not know about
+func (migrator *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	applied, err := migrator.getAppliedReadOnly(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(migrator.migrations))
+	known := make(map[int64]bool)
+	for _, migration := range migrator.migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &row.AppliedAt
+		}
+		statuses = append(statuses, status)
+		known[migration.Version] = true
+	}
+
+	for _, row := range applied {
+		if !known[row.Version] {
+			statuses = append(statuses, &MigrationStatus{
+				Version:   row.Version,
+				Name:      row.Name,
+				AppliedAt: &row.AppliedAt,
+			})
+		}
+	}
+
+	return statuses, nil
+}
+
+// CheckVersion fails unless exactly the known migrations are applied
+func (migrator *Migrator) CheckVersion(ctx context.Context) error {
+	applied, err := migrator.getAppliedReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	if err := migrator.checkKnown(applied); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, migration := range migrator.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("schema version mismatch: %d pending migration(s), latest is %d",
+			pending, migrator.LatestVersion())
+	}
+
+	return nil
+}
+
+// LatestVersion is the version of the newest known migration, 0 if none
+func (migrator *Migrator) LatestVersion() int64 {
+	if len(migrator.migrations) == 0 {
+		return 0
+	}
+	return migrator.migrations[len(migrator.migrations)-1].Version
+}
+
+// ======================== HELPER METHODS ========================
+
+// withLock runs fn on a dedicated connection holding the migrations advisory
+// lock, waiting for other replicas to finish first
+func (migrator *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	sqlDB, err := migrator.DB.DB()
+	if err != nil {
+		return fmt.Errorf("get underlying database: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get database connection: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", pglock.Key(migrationsLockName)); err != nil {
+		// Whether the lock was taken is unknown
+		pglock.Discard(conn)
+		return fmt.Errorf("acquire migrations lock: %w", err)
+	}
+	defer func() {
+		if err := pglock.Release(conn, migrationsLockName); err != nil {
+			migrator.Logger.Error("Migrations lock release failed", zap.Error(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createMigrationsTableSQL); err != nil {
+		return fmt.Errorf("create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (migrator *Migrator) inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
+
+func (migrator *Migrator) getApplied(ctx context.Context, conn *sql.Conn) (map[int64]*appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx,
+		`SELECT "version", "name", "applied_at" FROM "schema_migrations" ORDER BY "version"`)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]*appliedMigration)
+	for rows.Next() {
+		row := &appliedMigration{}
+		if err := rows.Scan(&row.Version, &row.Name, &row.AppliedAt); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[row.Version] = row
+	}
+
+	return applied, rows.Err()
+}
+
+// getAppliedReadOnly is getApplied without creating the table, for read-only callers
+func (migrator *Migrator) getAppliedReadOnly(ctx context.Context) (map[int64]*appliedMigration, error) {
+	sqlDB, err := migrator.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get underlying database: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	var exists bool
+	err = conn.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("check schema_migrations table: %w", err)
+	}
+	if !exists {
+		return map[int64]*appliedMigration{}, nil
+	}
+
+	return migrator.getApplied(ctx, conn)
+}
+
+// checkKnown fails if the database has a migration this binary does not know,
+// i.e. it was migrated by a newer version of the server
+func (migrator *Migrator) checkKnown(applied map[int64]*appliedMigration) error {
+	known := make(map[int64]bool, len(migrator.migrations))
+	for _, migration := range migrator.migrations {
+		known[migration.Version] = true
+	}
+
+	for version := range applied {
+		if !known[version] {
+			return fmt.Errorf("schema version mismatch: migration %d is applied but unknown to this server", version)
+		}
+	}
+
+	return nil
+}
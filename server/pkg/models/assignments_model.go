@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Assignment struct {
+	TeacherID  uuid.UUID  `gorm:"type:uuid;primaryKey"                          json:"teacher_id"`
+	ClassID    uuid.UUID  `gorm:"type:uuid;primaryKey"                          json:"class_id"`
+	HomeworkID uuid.UUID  `gorm:"type:uuid;primaryKey"                          json:"homework_id"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz;default:current_timestamp"    json:"created_at"`
+	AssignedAt *time.Time `gorm:"type:timestamptz;null;default:null"            json:"assigned_at"`
+	DueAt      *time.Time `gorm:"type:timestamptz;null;default:null"            json:"due_at"`
+
+	// Tells GORM that 'HomeworkID' and 'ClassID' above refer to these models
+	Homework Homework `gorm:"foreignKey:HomeworkID"`
+	Class    Class    `gorm:"foreignKey:ClassID"`
+}
+
+func (Assignment) TableName() string {
+	return "assignments"
+}
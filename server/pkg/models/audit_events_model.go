@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+// AuditEvent is append-only, the table rejects updates and deletes
+type AuditEvent struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Action    types.AuditAction `gorm:"type:varchar(64);not null"                      json:"action"`
+	ActorID   *uuid.UUID        `gorm:"type:uuid;null;default:null"                    json:"actor_id"` // Nil when unauthenticated or from tgsctl
+	TargetID  *uuid.UUID        `gorm:"type:uuid;null;default:null"                    json:"target_id"`
+	Metadata  map[string]any    `gorm:"type:jsonb;serializer:json;not null"            json:"metadata"`
+	CreatedAt time.Time         `gorm:"type:timestamptz;not null"                      json:"created_at"`
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,32 @@
+package models
+
+import "github.com/google/uuid"
+
+type Class struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name         string    `gorm:"type:varchar(128);not null"                     json:"name"`
+	StudentCount int       `gorm:"type:integer;default:0"                         json:"student_count"`
+	SchoolID     uuid.UUID `gorm:"type:uuid;not null"                             json:"school_id"`
+}
+
+func (Class) TableName() string {
+	return "classes"
+}
+
+type ClassStudent struct {
+	ClassID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"class_id"`
+	StudentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"student_id"`
+}
+
+func (ClassStudent) TableName() string {
+	return "class_students"
+}
+
+type ClassTeacher struct {
+	ClassID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"class_id"`
+	TeacherID uuid.UUID `gorm:"type:uuid;primaryKey" json:"teacher_id"`
+}
+
+func (ClassTeacher) TableName() string {
+	return "class_teachers"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+type DigestDelivery struct {
+	UserID     uuid.UUID             `gorm:"type:uuid;primaryKey"                    json:"user_id"`
+	Frequency  types.DigestFrequency `gorm:"type:digest_frequency;primaryKey"        json:"frequency"`
+	DigestDate time.Time             `gorm:"type:date;primaryKey"                    json:"digest_date"` // Midnight UTC of the date in the time zone of the user
+	SentAt     time.Time             `gorm:"type:timestamptz;not null;default:now()" json:"sent_at"`
+}
+
+func (DigestDelivery) TableName() string {
+	return "digest_deliveries"
+}
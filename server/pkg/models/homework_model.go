@@ -0,0 +1,25 @@
+package models
+
+import "github.com/google/uuid"
+
+type Homework struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name        string     `gorm:"type:varchar(128);not null"                     json:"name"`
+	ManHours    float64    `gorm:"type:numeric(3,2);not null"                     json:"man_hours"`
+	Description *string    `gorm:"type:varchar(1024);null;default:null"           json:"description"`
+	Score       *float64   `gorm:"type:double precision;null;default:null"        json:"score"`
+	BookID      *uuid.UUID `gorm:"type:uuid;null;default:null"                    json:"book_id"`
+}
+
+func (Homework) TableName() string {
+	return "homework"
+}
+
+type HomeworkTeacher struct {
+	HomeworkID uuid.UUID `gorm:"type:uuid;primaryKey" json:"homework_id"`
+	TeacherID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"teacher_id"`
+}
+
+func (HomeworkTeacher) TableName() string {
+	return "homework_teachers"
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HomeworkStudent struct {
+	HomeworkID  uuid.UUID  `gorm:"type:uuid;primaryKey"                    json:"homework_id"`
+	StudentID   uuid.UUID  `gorm:"type:uuid;primaryKey"                    json:"student_id"`
+	Score       *float64   `gorm:"type:double precision;null;default:null" json:"score"`
+	SubmittedAt *time.Time `gorm:"type:timestamptz;null;default:null"      json:"submitted_at"`
+}
+
+func (HomeworkStudent) TableName() string {
+	return "homework_students"
+}
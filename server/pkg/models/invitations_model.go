@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+type Invitation struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ClassID        uuid.UUID      `gorm:"type:uuid;not null"                             json:"class_id"`
+	SchoolID       uuid.UUID      `gorm:"type:uuid;not null"                             json:"school_id"` // Of the class
+	InviterID      uuid.UUID      `gorm:"type:uuid;not null"                             json:"inviter_id"`
+	Email          string         `gorm:"type:varchar(255);not null"                     json:"email"`
+	Role           types.UserRole `gorm:"type:role;not null"                             json:"role"`
+	SchoolNum      string         `gorm:"type:varchar(16);not null"                      json:"school_num"`
+	CreatedAt      time.Time      `gorm:"type:timestamptz;not null"                      json:"created_at"`
+	ExpiresAt      time.Time      `gorm:"type:timestamptz;not null"                      json:"expires_at"`
+	AcceptedAt     *time.Time     `gorm:"type:timestamptz;null;default:null"             json:"accepted_at"`
+	AcceptedUserID *uuid.UUID     `gorm:"type:uuid;null;default:null"                    json:"accepted_user_id"`
+	RevokedAt      *time.Time     `gorm:"type:timestamptz;null;default:null"             json:"revoked_at"`
+}
+
+func (Invitation) TableName() string {
+	return "invitations"
+}
+
+// Status is derived from the timestamps, relative to now. An invitation closed
+// at or after its expiry, when invited again, stays expired.
+func (invitation *Invitation) Status(now time.Time) types.InvitationStatus {
+	switch {
+	case invitation.AcceptedAt != nil:
+		return types.InvitationStatusAccepted
+	case invitation.RevokedAt != nil && invitation.RevokedAt.Before(invitation.ExpiresAt):
+		return types.InvitationStatusRevoked
+	case !now.Before(invitation.ExpiresAt):
+		return types.InvitationStatusExpired
+	default:
+		return types.InvitationStatusPending
+	}
+}
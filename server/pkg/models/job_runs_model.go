@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+type JobRun struct {
+	ID          uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	JobName     string             `gorm:"type:varchar(64);not null"                      json:"job_name"`
+	Trigger     types.JobTrigger   `gorm:"type:job_trigger;not null"                      json:"trigger"`
+	TriggeredBy *uuid.UUID         `gorm:"type:uuid;null;default:null"                    json:"triggered_by"` // Admin who triggered a manual run
+	Status      types.JobRunStatus `gorm:"type:job_run_status;not null"                   json:"status"`
+	StartedAt   time.Time          `gorm:"type:timestamptz;not null"                      json:"started_at"`
+	FinishedAt  *time.Time         `gorm:"type:timestamptz;null;default:null"             json:"finished_at"`
+	Error       *string            `gorm:"type:text;null;default:null"                    json:"error"`
+}
+
+func (JobRun) TableName() string {
+	return "job_runs"
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+// MailOutbox keeps the template and data of a mail rather than its bodies,
+// which may carry one-time links
+type MailOutbox struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Recipient string           `gorm:"type:varchar(255);not null"                     json:"recipient"`
+	UserID    *uuid.UUID       `gorm:"type:uuid;null;default:null"                    json:"user_id"` // Nil for an email without an account
+	Subject   string           `gorm:"type:varchar(255);not null"                     json:"subject"`
+	Template  string           `gorm:"type:varchar(64);not null"                      json:"template"`
+	Data      json.RawMessage  `gorm:"type:jsonb;serializer:json;null;default:null"   json:"-"` // Nil when the mail has a one-time link
+	Status    types.MailStatus `gorm:"type:mail_status;not null"                      json:"status"`
+	Error     *string          `gorm:"type:text;null;default:null"                    json:"error"`
+	Attempts  int              `gorm:"type:integer;not null;default:1"                json:"attempts"`
+	CreatedAt time.Time        `gorm:"type:timestamptz;not null"                      json:"created_at"`
+	SentAt    *time.Time       `gorm:"type:timestamptz;null;default:null"             json:"sent_at"`
+}
+
+func (MailOutbox) TableName() string {
+	return "mail_outbox"
+}
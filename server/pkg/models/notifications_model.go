@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null"                             json:"user_id"`
+	Title     string     `gorm:"type:varchar(255);not null"                     json:"title"`
+	Body      string     `gorm:"type:varchar(2048);not null"                    json:"body"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;not null"                      json:"created_at"`
+	ReadAt    *time.Time `gorm:"type:timestamptz;null;default:null"             json:"read_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+type ReminderDelivery struct {
+	HomeworkID  uuid.UUID                 `gorm:"type:uuid;primaryKey"                       json:"homework_id"`
+	ClassID     uuid.UUID                 `gorm:"type:uuid;primaryKey"                       json:"class_id"`
+	StudentID   uuid.UUID                 `gorm:"type:uuid;primaryKey"                       json:"student_id"` // uuid.Nil for class-wide reminders
+	RecipientID uuid.UUID                 `gorm:"type:uuid;primaryKey"                       json:"recipient_id"`
+	Kind        string                    `gorm:"type:varchar(32);primaryKey"                json:"kind"`
+	Channel     types.NotificationChannel `gorm:"type:notification_channel;primaryKey"       json:"channel"`
+	SentAt      time.Time                 `gorm:"type:timestamptz;not null;default:now()"    json:"sent_at"`
+}
+
+func (ReminderDelivery) TableName() string {
+	return "reminder_deliveries"
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchoolHoliday is a day without school besides the weekends
+type SchoolHoliday struct {
+	SchoolID uuid.UUID `gorm:"type:uuid;primaryKey"        json:"school_id"`
+	Date     time.Time `gorm:"type:date;primaryKey"        json:"date"` // Midnight UTC, the calendar date counts
+	Name     string    `gorm:"type:varchar(128);not null"  json:"name"`
+}
+
+func (SchoolHoliday) TableName() string {
+	return "school_holidays"
+}
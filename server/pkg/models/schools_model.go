@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type School struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name        string    `gorm:"type:varchar(128);not null"                     json:"name"`
+	ClassCount  int       `gorm:"type:integer;default:0"                         json:"class_count"`
+	BuildingNum string    `gorm:"type:varchar(16);not null"                      json:"building_num"`
+	Moo         *int16    `gorm:"type:smallint;null;default:null"                json:"moo"`
+	Soi         *string   `gorm:"type:varchar(32);null;default:null"             json:"soi"`
+	Road        string    `gorm:"type:varchar(32);not null"                      json:"road"`
+	SubDistrict string    `gorm:"type:varchar(32);not null"                      json:"sub_district"`
+	District    string    `gorm:"type:varchar(32);not null"                      json:"district"`
+	Province    string    `gorm:"type:varchar(32);not null"                      json:"province"`
+	Timezone    string    `gorm:"type:varchar(64);not null;default:'Asia/Bangkok'" json:"timezone"` // IANA, the days of due dates
+}
+
+func (School) TableName() string {
+	return "schools"
+}
+
+// Location is the time zone of the school, the default one if it no longer
+// exists in the time zone database
+func (school *School) Location() *time.Location {
+	return locationOrDefault(school.Timezone)
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func locationOrDefault(name string) *time.Location {
+	if location, err := time.LoadLocation(name); err == nil && name != "" {
+		return location
+	}
+
+	location, _ := time.LoadLocation(DefaultTimezone)
+	return location
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+type StudentGuardian struct {
+	StudentID  uuid.UUID              `gorm:"type:uuid;primaryKey"              json:"student_id"`
+	GuardianID uuid.UUID              `gorm:"type:uuid;primaryKey"              json:"guardian_id"`
+	Type       types.RelationshipType `gorm:"type:relationship_type;not null"   json:"type"`
+
+	// Tells GORM that 'StudentID' above refers to 'User' model
+	Student User `gorm:"foreignKey:StudentID"`
+}
+
+func (StudentGuardian) TableName() string {
+	return "student_guardians"
+}
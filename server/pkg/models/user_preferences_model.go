@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+	_ "time/tzdata" // Time zones do not depend on the host having them installed
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/google/uuid"
+)
+
+// Preferences of the users who never set them
+const (
+	DefaultTimezone        = "Asia/Bangkok" // Of the schools too
+	DefaultLocale          = "en"
+	DefaultDigestFrequency = types.DigestFrequencyWeekly
+)
+
+const quietHoursLayout = "15:04"
+
+type UserPreferences struct {
+	UserID          uuid.UUID             `gorm:"type:uuid;primaryKey"                            json:"-"`
+	Timezone        string                `gorm:"type:varchar(64);not null"                       json:"timezone"` // IANA, e.g. Asia/Bangkok
+	Locale          string                `gorm:"type:varchar(8);not null"                        json:"locale"`
+	Channels        NotificationChannels  `gorm:"type:jsonb;serializer:json;not null"             json:"channels"`
+	QuietHoursStart *string               `gorm:"type:varchar(5);null;default:null"               json:"quiet_hours_start"` // HH:MM in Timezone
+	QuietHoursEnd   *string               `gorm:"type:varchar(5);null;default:null"               json:"quiet_hours_end"`
+	DigestFrequency types.DigestFrequency `gorm:"type:digest_frequency;not null;default:'weekly'" json:"digest_frequency"`
+	UpdatedAt       time.Time             `gorm:"type:timestamptz;not null"                       json:"updated_at"`
+}
+
+func (UserPreferences) TableName() string {
+	return "user_preferences"
+}
+
+// NotificationChannels are the channels enabled for each kind of notification
+type NotificationChannels struct {
+	Reminder   ChannelSet `json:"reminder"`
+	Escalation ChannelSet `json:"escalation"`
+}
+
+type ChannelSet struct {
+	Email bool `json:"email"`
+	InApp bool `json:"in_app"`
+}
+
+// DefaultUserPreferences are the preferences of a user who never set them,
+// every channel enabled
+func DefaultUserPreferences(userID uuid.UUID) *UserPreferences {
+	all := ChannelSet{Email: true, InApp: true}
+
+	return &UserPreferences{
+		UserID:          userID,
+		Timezone:        DefaultTimezone,
+		Locale:          DefaultLocale,
+		Channels:        NotificationChannels{Reminder: all, Escalation: all},
+		DigestFrequency: DefaultDigestFrequency,
+	}
+}
+
+// ======================== METHODS ========================
+
+// Location is the time zone of the user, the default one if it no longer
+// exists in the time zone database
+func (preferences *UserPreferences) Location() *time.Location {
+	return locationOrDefault(preferences.Timezone)
+}
+
+// Wants reports whether the user wants the notifications of kind over channel
+func (preferences *UserPreferences) Wants(kind types.NotificationKind, channel types.NotificationChannel) bool {
+	var set ChannelSet
+	switch kind {
+	case types.NotificationKindReminder:
+		set = preferences.Channels.Reminder
+	case types.NotificationKindEscalation:
+		set = preferences.Channels.Escalation
+	default:
+		return true
+	}
+
+	switch channel {
+	case types.NotificationChannelEmail:
+		return set.Email
+	case types.NotificationChannelInApp:
+		return set.InApp
+	default:
+		return true
+	}
+}
+
+// InQuietHours reports whether t falls in the quiet hours of the user, which
+// may span midnight, e.g. 22:00 to 07:00. The end is exclusive.
+func (preferences *UserPreferences) InQuietHours(t time.Time) bool {
+	if preferences.QuietHoursStart == nil || preferences.QuietHoursEnd == nil {
+		return false
+	}
+
+	start, err := time.Parse(quietHoursLayout, *preferences.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(quietHoursLayout, *preferences.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(preferences.Location())
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return startMinute <= minute && minute < endMinute
+	}
+	return minute >= startMinute || minute < endMinute
+}
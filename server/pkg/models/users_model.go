@@ -12,34 +12,44 @@ import (
 )
 
 type User struct {
-	ID         uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	Role       types.UserRole   `gorm:"type:role;not null"                             json:"role"`
-	FirstName  string           `gorm:"type:varchar(128);not null"                     json:"first_name"`
-	MiddleName string           `gorm:"type:varchar(128);null;default:''"              json:"middle_name"`
-	LastName   string           `gorm:"type:varchar(128);null;default:''"              json:"last_name"`
-	Phone      string           `gorm:"type:varchar(15);not null"                      json:"phone"`
-	Gender     types.UserGender `gorm:"type:gender;not null"                           json:"gender"`
-	Email      string           `gorm:"type:varchar(255);not null;unique"              json:"email"`
-	Password   string           `gorm:"type:varchar(60);not null"                      json:"password"`
-	AvatarKey  *string          `gorm:"type:varchar(512);null;default:null"            json:"avatar_key"`
-	SchoolNum  *string          `gorm:"type:varchar(16);null;default:null"             json:"school_num"`
+	ID            uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Role          types.UserRole   `gorm:"type:role;not null"                             json:"role"`
+	FirstName     string           `gorm:"type:varchar(128);not null"                     json:"first_name"`
+	MiddleName    string           `gorm:"type:varchar(128);null;default:''"              json:"middle_name"`
+	LastName      string           `gorm:"type:varchar(128);null;default:''"              json:"last_name"`
+	RomanizedName *string          `gorm:"type:varchar(384);null;default:null"            json:"romanized_name"` // Latin spelling, for search and sorting
+	Phone         string           `gorm:"type:varchar(15);not null"                      json:"phone"`
+	Gender        types.UserGender `gorm:"type:gender;not null"                           json:"gender"`
+	Email         string           `gorm:"type:varchar(255);not null;unique"              json:"email"`
+	Password      string           `gorm:"type:varchar(60);not null"                      json:"password"`
+	AvatarKey     *string          `gorm:"type:varchar(512);null;default:null"            json:"avatar_key"`
+	SchoolNum     *string          `gorm:"type:varchar(16);null;default:null"             json:"school_num"`
+	Bio           *string          `gorm:"type:varchar(500);null;default:null"            json:"bio"`
+	DeactivatedAt *time.Time       `gorm:"type:timestamptz;null;default:null"             json:"deactivated_at"`
+	SchoolID      *uuid.UUID       `gorm:"type:uuid;null;default:null"                    json:"school_id"` // Nil for platform admins and users without a school
+
+	DailyManHoursBudget *float64 `gorm:"type:numeric(4,2);null;default:null" json:"daily_man_hours_budget"` // Of a student, the default one when nil
 }
 
 // PublicUser Remove sensitive fields e.g. password
 type PublicUser struct {
-	ID         uuid.UUID        `json:"id"`
-	Role       types.UserRole   `json:"role"`
-	FirstName  string           `json:"first_name"`
-	MiddleName string           `json:"middle_name"`
-	LastName   string           `json:"last_name"`
-	Phone      string           `json:"phone"`
-	Gender     types.UserGender `json:"gender"`
-	Email      string           `json:"email"`
-	AvartarURL *string          `json:"avatar_url"`
-	SchoolNum  *string          `json:"school_num"`
+	ID            uuid.UUID        `json:"id"`
+	Role          types.UserRole   `json:"role"`
+	FirstName     string           `json:"first_name"`
+	MiddleName    string           `json:"middle_name"`
+	LastName      string           `json:"last_name"`
+	RomanizedName *string          `json:"romanized_name"`
+	Phone         string           `json:"phone"`
+	Gender        types.UserGender `json:"gender"`
+	Email         string           `json:"email"`
+	AvartarURL    *string          `json:"avatar_url"`
+	SchoolNum     *string          `json:"school_num"`
+	Bio           *string          `json:"bio"`
+	SchoolID      *uuid.UUID       `json:"school_id"`
 }
 
 func (user *User) ToPublic(
+	ctx context.Context,
 	logger *zap.Logger,
 	storageClient *minio.Client,
 	bucketName string,
@@ -48,7 +58,7 @@ func (user *User) ToPublic(
 	var avatarURL *string = nil
 
 	if user.AvatarKey != nil {
-		ctx, cancle := context.WithTimeout(context.Background(), 2*time.Second)
+		ctx, cancle := context.WithTimeout(ctx, 2*time.Second)
 		defer cancle()
 
 		signedURL, err := storageClient.PresignedGetObject(
@@ -74,16 +84,19 @@ func (user *User) ToPublic(
 	}
 
 	publicUser := &PublicUser{
-		ID:         user.ID,
-		Role:       user.Role,
-		FirstName:  user.FirstName,
-		MiddleName: user.MiddleName,
-		LastName:   user.LastName,
-		Phone:      user.Phone,
-		Gender:     user.Gender,
-		Email:      user.Email,
-		AvartarURL: avatarURL,
-		SchoolNum:  user.SchoolNum,
+		ID:            user.ID,
+		Role:          user.Role,
+		FirstName:     user.FirstName,
+		MiddleName:    user.MiddleName,
+		LastName:      user.LastName,
+		RomanizedName: user.RomanizedName,
+		Phone:         user.Phone,
+		Gender:        user.Gender,
+		Email:         user.Email,
+		AvartarURL:    avatarURL,
+		SchoolNum:     user.SchoolNum,
+		Bio:           user.Bio,
+		SchoolID:      user.SchoolID,
 	}
 
 	return publicUser, nil
@@ -0,0 +1,12 @@
+package notificationsfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"notificationsfx",
+	fx.Provide(
+		NewNotificationsRoutes,
+		NewNotificationsController,
+		NewNotificationService,
+	),
+)
@@ -0,0 +1,80 @@
+package notificationsfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type NotificationsControllerParams struct {
+	fx.In
+	Logger              *zap.Logger
+	NotificationService NotificationServiceInterface
+}
+
+type NotificationsController struct {
+	Logger              *zap.Logger
+	NotificationService NotificationServiceInterface
+}
+
+func NewNotificationsController(params NotificationsControllerParams) *NotificationsController {
+	return &NotificationsController{
+		Logger:              params.Logger,
+		NotificationService: params.NotificationService,
+	}
+}
+
+// ======================== METHODS ========================
+
+func (controller *NotificationsController) GetMyNotifications(ctx *gin.Context) {
+	// Get userID Context that set by AuthMiddleware
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		controller.Logger.Debug("User ID parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	notifications, err := controller.NotificationService.GetNotificationsByUserID(
+		ctx.Request.Context(),
+		userID,
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"notifications": notifications})
+}
+
+func (controller *NotificationsController) MarkNotificationRead(ctx *gin.Context) {
+	// Get userID Context that set by AuthMiddleware
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		controller.Logger.Debug("User ID parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	notificationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	notification, err := controller.NotificationService.MarkNotificationRead(
+		ctx.Request.Context(),
+		userID,
+		notificationID,
+	)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"notification": notification})
+}
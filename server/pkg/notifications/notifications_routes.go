@@ -0,0 +1,79 @@
+package notificationsfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type NotificationsRoutesParams struct {
+	fx.In
+	Logger                  *zap.Logger
+	Router                  *gin.Engine
+	AuthMiddleware          *middlewarefx.AuthMiddleware
+	NotificationsController *NotificationsController
+}
+
+type NotificationsRoutes struct {
+	Logger                  *zap.Logger
+	Router                  *gin.Engine
+	AuthMiddleware          *middlewarefx.AuthMiddleware
+	NotificationsController *NotificationsController
+}
+
+func NewNotificationsRoutes(params NotificationsRoutesParams) *NotificationsRoutes {
+	return &NotificationsRoutes{
+		Logger:                  params.Logger,
+		Router:                  params.Router,
+		AuthMiddleware:          params.AuthMiddleware,
+		NotificationsController: params.NotificationsController,
+	}
+}
+
+func (routes *NotificationsRoutes) Setup() {
+	routes.Logger.Info("Setting up [Notifications] routes.")
+
+	routes.Router.GET(string(endpoints.GetMyNotificationsV1),
+		routes.AuthMiddleware.Handler(),
+		routes.NotificationsController.GetMyNotifications)
+
+	routes.Router.PATCH(string(endpoints.MarkNotificationReadV1)+"/:id/read",
+		routes.AuthMiddleware.Handler(),
+		routes.NotificationsController.MarkNotificationRead)
+}
+
+func Operations() []openapifx.Operation {
+	return []openapifx.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    string(endpoints.GetMyNotificationsV1),
+			ID:      "getMyNotifications",
+			Tag:     "notifications",
+			Summary: "List the in-app notifications of the signed in user",
+			Auth:    openapifx.AuthUser,
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"notifications": []*models.Notification{}}},
+			},
+		},
+		{
+			Method:     http.MethodPatch,
+			Path:       string(endpoints.MarkNotificationReadV1) + "/:id/read",
+			ID:         "markNotificationRead",
+			Tag:        "notifications",
+			Summary:    "Mark a notification of the signed in user as read",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: openapifx.Object{"notification": models.Notification{}}},
+			},
+			Errors: []int{http.StatusNotFound},
+		},
+	}
+}
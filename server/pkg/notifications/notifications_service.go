@@ -0,0 +1,150 @@
+package notificationsfx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type NotificationServiceParams struct {
+	fx.In
+	Logger *zap.Logger
+	DB     *gorm.DB
+	Clock  clock.Clock
+}
+
+type NotificationService struct {
+	Logger *zap.Logger
+	DB     *gorm.DB
+	Clock  clock.Clock
+}
+
+type NotificationServiceInterface interface {
+	CreateNotification(ctx context.Context, userID uuid.UUID, title, body string) error
+	GetNotificationsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) (*models.Notification, error)
+}
+
+// Verify interface implementation at compile time
+var _ NotificationServiceInterface = (*NotificationService)(nil)
+
+func NewNotificationService(params NotificationServiceParams) NotificationServiceInterface {
+	return &NotificationService{
+		Logger: params.Logger,
+		DB:     params.DB,
+		Clock:  params.Clock,
+	}
+}
+
+// Maximum number of notifications returned to a user
+const notificationListLimit = 50
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+func (service *NotificationService) CreateNotification(
+	ctx context.Context,
+	userID uuid.UUID,
+	title, body string,
+) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	notification := &models.Notification{
+		UserID:    userID,
+		Title:     title,
+		Body:      body,
+		CreatedAt: service.Clock.Now(),
+	}
+
+	result := service.DB.WithContext(ctx).Create(notification)
+	if result.Error != nil {
+		logger.Error(
+			"Notification database creation failed",
+			zap.String("user_id", userID.String()),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase
+	}
+
+	return nil
+}
+
+func (service *NotificationService) GetNotificationsByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Notification, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	notifications := []*models.Notification{}
+
+	result := service.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(notificationListLimit).
+		Find(&notifications)
+	if result.Error != nil {
+		logger.Error(
+			"Notifications database retrieval failed",
+			zap.String("user_id", userID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return notifications, nil
+}
+
+func (service *NotificationService) MarkNotificationRead(
+	ctx context.Context,
+	userID, notificationID uuid.UUID,
+) (*models.Notification, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	var notification *models.Notification
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only the owner can mark the notification, keep the first read time
+		result := tx.Model(&models.Notification{}).
+			Where("id = ? AND user_id = ? AND read_at IS NULL", notificationID, userID).
+			Update("read_at", service.Clock.Now())
+		if result.Error != nil {
+			logger.Error(
+				"Notification database update failed",
+				zap.String("notification_id", notificationID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		result = tx.Where("id = ? AND user_id = ?", notificationID, userID).First(&notification)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			logger.Debug(
+				"Notification database update skipped",
+				zap.String("reason", "notification_not_found"),
+				zap.String("notification_id", notificationID.String()),
+			)
+			return common.ErrNotificationNotFound
+		} else if result.Error != nil {
+			logger.Error(
+				"Notification database retrieval failed",
+				zap.String("notification_id", notificationID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,56 @@
+package openapifx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type DocsControllerParams struct {
+	fx.In
+	Logger   *zap.Logger
+	Document *Document
+}
+
+type DocsController struct {
+	Logger   *zap.Logger
+	Document *Document
+}
+
+func NewDocsController(params DocsControllerParams) *DocsController {
+	return &DocsController{
+		Logger:   params.Logger,
+		Document: params.Document,
+	}
+}
+
+// Swagger UI, loaded from a CDN so that the server has no assets to serve
+const docsPage = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Touch Grass Scheduler API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/` + string(endpoints.GetOpenAPIDocument) + `", dom_id: "#swagger-ui", withCredentials: true })
+  </script>
+</body>
+</html>
+`
+
+// ======================== METHODS ========================
+
+func (controller *DocsController) GetDocs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
+
+func (controller *DocsController) GetOpenAPIDocument(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, controller.Document)
+}
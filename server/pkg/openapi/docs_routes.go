@@ -0,0 +1,59 @@
+package openapifx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type DocsRoutesParams struct {
+	fx.In
+	Logger         *zap.Logger
+	Router         *gin.Engine
+	DocsController *DocsController
+}
+
+type DocsRoutes struct {
+	Logger         *zap.Logger
+	Router         *gin.Engine
+	DocsController *DocsController
+}
+
+func NewDocsRoutes(params DocsRoutesParams) *DocsRoutes {
+	return &DocsRoutes{
+		Logger:         params.Logger,
+		Router:         params.Router,
+		DocsController: params.DocsController,
+	}
+}
+
+func (routes *DocsRoutes) Setup() {
+	routes.Logger.Info("Setting up [Docs] routes.")
+
+	routes.Router.GET(string(endpoints.GetDocs), routes.DocsController.GetDocs)
+	routes.Router.GET(string(endpoints.GetOpenAPIDocument), routes.DocsController.GetOpenAPIDocument)
+}
+
+func Operations() []Operation {
+	return []Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetDocs),
+			ID:        "getDocs",
+			Tag:       "docs",
+			Summary:   "Browse the API documentation",
+			Responses: []Response{{Status: http.StatusOK, Body: "", ContentType: "text/html"}},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetOpenAPIDocument),
+			ID:        "getOpenAPIDocument",
+			Tag:       "docs",
+			Summary:   "Get this OpenAPI document",
+			Responses: []Response{{Status: http.StatusOK, Body: map[string]any{}}},
+		},
+	}
+}
@@ -0,0 +1,68 @@
+// Package openapifx generates the OpenAPI 3 document of the API.
+//
+// Every package with routes lists them as Operations next to its routes, the
+// paths built from the same endpoint enums. The schemas of the bodies are
+// reflected from the Go types: the json tags name the properties, and the
+// binding tags of the request bodies give the required properties and their
+// constraints. The document is served at /api/docs, and the TypeScript client
+// of the web client is generated from it (see cmd/openapi).
+package openapifx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"openapifx",
+	fx.Provide(
+		NewDocsRoutes,
+		NewDocsController,
+	),
+)
+
+// Operation describes a route
+type Operation struct {
+	Method string // e.g. http.MethodGet
+	Path   string // As registered, e.g. string(endpoints.GetUserByIDV1) + "/:id"
+	ID     string // Unique, the method name in the TypeScript client
+	Tag    string
+
+	Summary string
+	Auth    Auth
+
+	PathParams []Param // Undeclared path parameters are strings
+	Query      []Param
+	Body       any    // JSON body, e.g. RegisterBody{}
+	Form       Object // Multipart form, with File values for files
+
+	Responses []Response // Successful ones
+	Errors    []int      // Business errors, e.g. 404, the usual ones are added
+}
+
+type Auth int
+
+const (
+	AuthNone  Auth = iota
+	AuthUser       // Any signed in user, further checked by the permission policy
+	AuthAdmin      // Platform admins only
+)
+
+// Param is a path or query parameter, Schema being a value of its type
+type Param struct {
+	Name        string
+	Schema      any
+	Description string
+	Required    bool
+}
+
+type Response struct {
+	Status      int
+	Body        any    // Nil when empty
+	ContentType string // Defaults to application/json
+	Description string // Defaults to the status text
+}
+
+// Object is an inline object, most often the gin.H wrapping a response,
+// e.g. Object{"user": models.PublicUser{}}. Every property is required.
+type Object map[string]any
+
+// File is a file of a multipart form
+type File struct{}
@@ -0,0 +1,254 @@
+package openapifx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+)
+
+type Document struct {
+	OpenAPI    string                        `json:"openapi"`
+	Info       Info                          `json:"info"`
+	Paths      map[string]map[string]*PathOp `json:"paths"`
+	Components Components                    `json:"components"`
+
+	// Paths and methods in the order of the operations
+	order []pathMethod
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// PathOp is the Operation Object of the specification
+type PathOp struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseOp `json:"responses"`
+	Security    []map[string][]string  `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// ResponseOp is the Response Object of the specification
+type ResponseOp struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type pathMethod struct {
+	path   string
+	method string
+}
+
+const (
+	securitySchemeName = "accessToken"
+	accessTokenCookie  = "accessToken" // Set by the login and register APIs
+
+	contentTypeJSON      = "application/json"
+	contentTypeMultipart = "multipart/form-data"
+)
+
+// Gin path parameters, e.g. :id
+var pathParamRegex = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// NewDocument fails on duplicated routes or operation IDs, and on types
+// without a schema
+func NewDocument(title, version string, operations []Operation) (*Document, error) {
+	registry := newSchemaRegistry()
+	errorContent := map[string]*MediaType{
+		contentTypeJSON:               {Schema: registry.valueSchema(common.ErrorResponse{})},
+		common.ContentTypeProblemJSON: {Schema: registry.valueSchema(common.ProblemDetails{})},
+	}
+
+	document := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]map[string]*PathOp{},
+		Components: Components{
+			Schemas: registry.components,
+			SecuritySchemes: map[string]*SecurityScheme{
+				securitySchemeName: {Type: "apiKey", In: "cookie", Name: accessTokenCookie},
+			},
+		},
+	}
+
+	ids := map[string]bool{}
+	for _, operation := range operations {
+		if operation.ID == "" || ids[operation.ID] {
+			return nil, fmt.Errorf("openapi: operation ID %q is empty or duplicated", operation.ID)
+		}
+		ids[operation.ID] = true
+
+		path := SpecPath(operation.Path)
+		method := strings.ToLower(operation.Method)
+		if document.Paths[path] == nil {
+			document.Paths[path] = map[string]*PathOp{}
+		}
+		if document.Paths[path][method] != nil {
+			return nil, fmt.Errorf("openapi: %s %s is described twice", operation.Method, path)
+		}
+
+		pathOp, err := buildPathOp(registry, operation, errorContent)
+		if err != nil {
+			return nil, err
+		}
+		document.Paths[path][method] = pathOp
+		document.order = append(document.order, pathMethod{path: path, method: method})
+	}
+
+	if registry.err != nil {
+		return nil, registry.err
+	}
+
+	return document, nil
+}
+
+// SpecPath converts a gin path, e.g. api/v1/users/:id, to /api/v1/users/{id}
+func SpecPath(ginPath string) string {
+	return "/" + strings.TrimPrefix(pathParamRegex.ReplaceAllString(ginPath, "{$1}"), "/")
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func buildPathOp(
+	registry *schemaRegistry,
+	operation Operation,
+	errorContent map[string]*MediaType,
+) (*PathOp, error) {
+	pathOp := &PathOp{
+		OperationID: operation.ID,
+		Summary:     operation.Summary,
+		Responses:   map[string]*ResponseOp{},
+	}
+	if operation.Tag != "" {
+		pathOp.Tags = []string{operation.Tag}
+	}
+
+	// Path parameters, in the order of the path
+	declared := map[string]Param{}
+	for _, param := range operation.PathParams {
+		declared[param.Name] = param
+	}
+	for _, match := range pathParamRegex.FindAllStringSubmatch(operation.Path, -1) {
+		param, ok := declared[match[1]]
+		if !ok {
+			param = Param{Name: match[1], Schema: ""}
+		}
+		delete(declared, match[1])
+
+		pathOp.Parameters = append(pathOp.Parameters, &Parameter{
+			Name:        param.Name,
+			In:          "path",
+			Description: param.Description,
+			Required:    true,
+			Schema:      registry.valueSchema(param.Schema),
+		})
+	}
+	if len(declared) > 0 {
+		return nil, fmt.Errorf("openapi: %s declares parameters missing from %s", operation.ID, operation.Path)
+	}
+
+	for _, param := range operation.Query {
+		pathOp.Parameters = append(pathOp.Parameters, &Parameter{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      registry.valueSchema(param.Schema),
+		})
+	}
+
+	switch {
+	case operation.Body != nil && operation.Form != nil:
+		return nil, errors.New("openapi: " + operation.ID + " has both a JSON body and a form")
+	case operation.Body != nil:
+		pathOp.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{contentTypeJSON: {Schema: registry.valueSchema(operation.Body)}},
+		}
+	case operation.Form != nil:
+		pathOp.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{contentTypeMultipart: {Schema: registry.valueSchema(operation.Form)}},
+		}
+	}
+
+	if len(operation.Responses) == 0 {
+		return nil, errors.New("openapi: " + operation.ID + " has no successful response")
+	}
+	for _, response := range operation.Responses {
+		responseOp := &ResponseOp{Description: response.Description}
+		if responseOp.Description == "" {
+			responseOp.Description = http.StatusText(response.Status)
+		}
+		if response.Body != nil {
+			contentType := response.ContentType
+			if contentType == "" {
+				contentType = contentTypeJSON
+			}
+			responseOp.Content = map[string]*MediaType{contentType: {Schema: registry.valueSchema(response.Body)}}
+		}
+		pathOp.Responses[strconv.Itoa(response.Status)] = responseOp
+	}
+
+	// Errors every operation of the kind may respond with
+	errorStatuses := slices.Clone(operation.Errors)
+	if len(pathOp.Parameters) > 0 || pathOp.RequestBody != nil {
+		errorStatuses = append(errorStatuses, http.StatusBadRequest)
+	}
+	if operation.Auth != AuthNone {
+		errorStatuses = append(errorStatuses, http.StatusUnauthorized)
+		pathOp.Security = []map[string][]string{{securitySchemeName: {}}}
+	}
+	if operation.Auth == AuthAdmin {
+		errorStatuses = append(errorStatuses, http.StatusForbidden)
+	}
+	errorStatuses = append(errorStatuses, http.StatusInternalServerError)
+
+	// The envelope, or RFC 7807 problem details when accepted
+	for _, status := range errorStatuses {
+		pathOp.Responses[strconv.Itoa(status)] = &ResponseOp{
+			Description: http.StatusText(status),
+			Content:     errorContent,
+		}
+	}
+
+	return pathOp, nil
+}
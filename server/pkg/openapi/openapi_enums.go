@@ -0,0 +1,62 @@
+package openapifx
+
+import (
+	"reflect"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+)
+
+// enums lists the values of the string enums in the bodies, which reflection
+// cannot see. An enum missing here is a plain string.
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[types.UserRole](): values(
+		types.UserRoleStudent,
+		types.UserRoleTeacher,
+		types.UserRoleGuardian,
+		types.UserRoleAdmin,
+		types.UserRoleSchoolAdmin,
+	),
+	reflect.TypeFor[types.UserGender](): values(
+		types.UserGenderMale,
+		types.UserGenderFemale,
+		types.UserGenderOther,
+		types.UserGenderPNTS,
+	),
+	reflect.TypeFor[types.DigestFrequency](): values(
+		types.DigestFrequencyNone,
+		types.DigestFrequencyDaily,
+		types.DigestFrequencyWeekly,
+	),
+	reflect.TypeFor[types.InvitationStatus](): values(
+		types.InvitationStatusPending,
+		types.InvitationStatusAccepted,
+		types.InvitationStatusRevoked,
+		types.InvitationStatusExpired,
+	),
+	reflect.TypeFor[types.JobTrigger](): values(
+		types.JobTriggerSchedule,
+		types.JobTriggerManual,
+	),
+	reflect.TypeFor[types.JobRunStatus](): values(
+		types.JobRunStatusRunning,
+		types.JobRunStatusSucceeded,
+		types.JobRunStatusFailed,
+	),
+	reflect.TypeFor[types.AuditAction](): values(
+		types.AuditActionLoginSucceeded,
+		types.AuditActionLoginFailed,
+		types.AuditActionPasswordReset,
+		types.AuditActionRoleChanged,
+		types.AuditActionProfileUpdated,
+		types.AuditActionUserRead,
+		types.AuditActionGradeChanged,
+	),
+}
+
+func values[T ~string](enumValues ...T) []string {
+	strs := make([]string, len(enumValues))
+	for i, value := range enumValues {
+		strs[i] = string(value)
+	}
+	return strs
+}
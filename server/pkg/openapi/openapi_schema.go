@@ -0,0 +1,363 @@
+package openapifx
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/dates"
+	"github.com/google/uuid"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	// Declaration order of the properties, kept for the TypeScript client
+	propertyOrder []string
+}
+
+const componentsPrefix = "#/components/schemas/"
+
+// RefName returns the component a $ref schema points to
+func (schema *Schema) RefName() string {
+	return strings.TrimPrefix(schema.Ref, componentsPrefix)
+}
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	dateType = reflect.TypeFor[dates.Date]()
+	uuidType = reflect.TypeFor[uuid.UUID]()
+	fileType = reflect.TypeFor[File]()
+)
+
+// Same splitting of the oneof parameter as the validator
+var oneofValuesRegex = regexp.MustCompile(`'[^']*'|\S+`)
+
+// schemaRegistry reflects the schemas, the named structs and enums of which
+// become components
+type schemaRegistry struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+	err        error
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: map[string]*Schema{},
+		types:      map[string]reflect.Type{},
+	}
+}
+
+// valueSchema is the schema of the type of value, or of the Object
+func (registry *schemaRegistry) valueSchema(value any) *Schema {
+	if object, ok := value.(Object); ok {
+		return registry.objectSchema(object)
+	}
+	return registry.typeSchema(reflect.TypeOf(value))
+}
+
+func (registry *schemaRegistry) objectSchema(object Object) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		schema.Properties[name] = registry.valueSchema(object[name])
+		schema.propertyOrder = append(schema.propertyOrder, name)
+	}
+	schema.Required = names
+
+	return schema
+}
+
+func (registry *schemaRegistry) typeSchema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case dateType:
+		return &Schema{Type: "string", Format: "date"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case fileType:
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	if values, ok := enums[t]; ok {
+		return registry.component(t, func() *Schema {
+			return &Schema{Type: "string", Enum: values}
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(registry.typeSchema(t.Elem()))
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: registry.typeSchema(elem(t))}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: registry.typeSchema(elem(t))}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return registry.structSchema(t)
+		}
+		return registry.component(t, func() *Schema {
+			return registry.structSchema(t)
+		})
+	default:
+		registry.fail(fmt.Errorf("openapi: unsupported type %s", t))
+		return &Schema{}
+	}
+}
+
+// component registers the schema of a named type once, and refers to it
+func (registry *schemaRegistry) component(t reflect.Type, build func() *Schema) *Schema {
+	name := componentName(t)
+	ref := &Schema{Ref: componentsPrefix + name}
+
+	if registered, ok := registry.types[name]; ok {
+		if registered != t {
+			registry.fail(fmt.Errorf("openapi: %s and %s are both named %s", registered, t, name))
+		}
+		return ref
+	}
+
+	// Registered before being built, for recursive types
+	registry.types[name] = t
+	registry.components[name] = build()
+
+	return ref
+}
+
+// componentName names the instances of generic types after their type
+// arguments, e.g. PageUser for pagination.Page[*models.User]
+func componentName(t reflect.Type) string {
+	base, arguments, ok := strings.Cut(t.Name(), "[")
+	if !ok {
+		return base
+	}
+
+	name := base
+	for argument := range strings.SplitSeq(strings.TrimSuffix(arguments, "]"), ",") {
+		argument = argument[strings.LastIndex(argument, ".")+1:]
+		name += strings.TrimLeft(argument, "*[]")
+	}
+	return name
+}
+
+// structSchema requires the properties with a required binding in request
+// bodies, and every property without omitempty in the others
+func (registry *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	registry.addFields(schema, t, hasBindings(t))
+	return schema
+}
+
+func (registry *schemaRegistry) addFields(schema *Schema, t reflect.Type, isRequestBody bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Fields of embedded structs are inlined
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				registry.addFields(schema, embedded, isRequestBody)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		binding := field.Tag.Get("binding")
+		schema.Properties[name] = registry.constrain(registry.typeSchema(field.Type), field.Type, binding)
+		schema.propertyOrder = append(schema.propertyOrder, name)
+
+		required := !strings.Contains(options, "omitempty")
+		if isRequestBody {
+			required = slices.Contains(strings.Split(binding, ","), "required")
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// constrain applies the binding rules with an OpenAPI equivalent, the others
+// are only enforced by the server
+func (registry *schemaRegistry) constrain(schema *Schema, t reflect.Type, binding string) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, rule := range strings.Split(binding, ",") {
+		// Alternatives, e.g. len=0|alpha, have no equivalent
+		if rule == "" || strings.Contains(rule, "|") {
+			continue
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			var values []string
+			for _, value := range oneofValuesRegex.FindAllString(param, -1) {
+				values = append(values, strings.Trim(value, "'"))
+			}
+			// Narrower than the enum component, if any
+			schema = &Schema{Type: "string", Enum: values, Nullable: schema.Nullable}
+		case "min", "max", "len":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				registry.fail(fmt.Errorf("openapi: invalid binding %q: %w", rule, err))
+				continue
+			}
+			setBound(schema, t, name, n)
+		case "email":
+			schema.Format = "email"
+		case "uuid":
+			schema.Format = "uuid"
+		case "url":
+			schema.Format = "uri"
+		case "jwt":
+			schema.Format = "jwt"
+		case "e164":
+			schema.Pattern = `^\+[1-9][0-9]{1,14}$`
+		case "alpha":
+			schema.Pattern = `^[a-zA-Z]+$`
+		case "romanized_name":
+			schema.Pattern = `^[a-zA-Z][a-zA-Z .'’-]*$`
+		case "number":
+			schema.Pattern = `^[0-9]+$`
+		}
+	}
+
+	return schema
+}
+
+func setBound(schema *Schema, t reflect.Type, rule string, n int) {
+	switch t.Kind() {
+	case reflect.String:
+		if rule != "max" {
+			schema.MinLength = &n
+		}
+		if rule != "min" {
+			schema.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if rule != "max" {
+			schema.MinItems = &n
+		}
+		if rule != "min" {
+			schema.MaxItems = &n
+		}
+	default:
+		bound := float64(n)
+		if rule != "max" {
+			schema.Minimum = &bound
+		}
+		if rule != "min" {
+			schema.Maximum = &bound
+		}
+	}
+}
+
+func (registry *schemaRegistry) fail(err error) {
+	if registry.err == nil {
+		registry.err = err
+	}
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// nullable wraps references, which cannot have sibling keywords in OpenAPI 3.0
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+
+	schema.Nullable = true
+	return schema
+}
+
+func hasBindings(t reflect.Type) bool {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup("binding"); ok {
+			return true
+		}
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && embedded.Kind() == reflect.Struct && hasBindings(embedded) {
+			return true
+		}
+	}
+	return false
+}
+
+// elem is the element type of a slice or map, the pointers dereferenced: the
+// API never serializes nil elements
+func elem(t reflect.Type) reflect.Type {
+	elem := t.Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	return elem
+}
@@ -0,0 +1,240 @@
+package openapifx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const typeScriptHeader = `// Code generated by "go run ./cmd/openapi" in server. DO NOT EDIT.
+
+import { apiService, ApiService } from "./api.service"
+`
+
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScript generates the client of the web client: a type per component,
+// and an ApiClient method per operation, calling the hand-written ApiService
+func (document *Document) TypeScript() string {
+	var ts strings.Builder
+	ts.WriteString(typeScriptHeader)
+
+	names := make([]string, 0, len(document.Components.Schemas))
+	for name := range document.Components.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		schema := document.Components.Schemas[name]
+		ts.WriteString("\n")
+		if schema.Type == "object" && schema.Properties != nil {
+			fmt.Fprintf(&ts, "export interface %s %s\n", name, tsObject(schema, 0))
+		} else {
+			fmt.Fprintf(&ts, "export type %s = %s\n", name, tsType(schema))
+		}
+	}
+
+	ts.WriteString(`
+export class ApiClient {
+    constructor(
+        private readonly apiService: ApiService,
+        private readonly baseUrl: string
+    ) {}
+`)
+	for _, pm := range document.order {
+		ts.WriteString("\n")
+		ts.WriteString(tsMethod(pm.path, pm.method, document.Paths[pm.path][pm.method]))
+	}
+	ts.WriteString(`}
+
+export const apiClient = new ApiClient(
+    apiService,
+    process.env.NEXT_PUBLIC_API_URL ?? ""
+)
+`)
+
+	return ts.String()
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func tsMethod(path, method string, pathOp *PathOp) string {
+	var params, query []string
+	requiredQuery := false
+	url := path
+	for _, param := range pathOp.Parameters {
+		switch param.In {
+		case "path":
+			params = append(params, param.Name+": string")
+			url = strings.Replace(url, "{"+param.Name+"}", "${encodeURIComponent("+param.Name+")}", 1)
+		case "query":
+			optional := "?"
+			if param.Required {
+				optional = ""
+				requiredQuery = true
+			}
+			query = append(query, tsProperty(param.Name)+optional+": "+tsType(param.Schema))
+		}
+	}
+
+	bodyType := "undefined"
+	if pathOp.RequestBody != nil {
+		if media, ok := pathOp.RequestBody.Content[contentTypeJSON]; ok {
+			bodyType = tsType(media.Schema)
+		} else {
+			bodyType = "FormData"
+		}
+		params = append(params, "body: "+bodyType)
+	}
+
+	if len(query) > 0 {
+		optional := "?"
+		if requiredQuery {
+			optional = ""
+		}
+		params = append(params, "query"+optional+": { "+strings.Join(query, "; ")+" }")
+	}
+
+	resultType := tsResult(pathOp)
+
+	var call string
+	urlArg := "`${this.baseUrl}" + url + "`"
+	switch method {
+	case "get":
+		call = fmt.Sprintf("this.apiService.get<%s>(\n            %s", resultType, urlArg)
+		if len(query) > 0 {
+			call += ",\n            query"
+		}
+	default:
+		call = fmt.Sprintf("this.apiService.%s<%s, %s>(\n            %s", method, bodyType, resultType, urlArg)
+		switch {
+		case pathOp.RequestBody != nil:
+			call += ",\n            body"
+		case len(query) > 0:
+			call += ",\n            undefined"
+		}
+		if len(query) > 0 {
+			call += ",\n            query"
+		}
+	}
+
+	var ts strings.Builder
+	if pathOp.Summary != "" {
+		fmt.Fprintf(&ts, "    // %s\n", pathOp.Summary)
+	}
+	fmt.Fprintf(&ts, "    async %s(%s): Promise<%s> {\n", pathOp.OperationID, strings.Join(params, ", "), resultType)
+	fmt.Fprintf(&ts, "        return await %s\n        )\n    }\n", call)
+
+	return ts.String()
+}
+
+// tsResult is the union of the successful bodies, null when empty
+func tsResult(pathOp *PathOp) string {
+	var statuses []string
+	for status := range pathOp.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	slices.Sort(statuses)
+
+	var results []string
+	for _, status := range statuses {
+		result := "null"
+		for contentType, media := range pathOp.Responses[status].Content {
+			result = "string"
+			if contentType == contentTypeJSON {
+				result = tsType(media.Schema)
+			}
+		}
+		if !slices.Contains(results, result) {
+			results = append(results, result)
+		}
+	}
+
+	return strings.Join(results, " | ")
+}
+
+func tsType(schema *Schema) string {
+	var ts string
+	switch {
+	case schema.Ref != "":
+		ts = schema.RefName()
+	case len(schema.AllOf) == 1:
+		ts = tsType(schema.AllOf[0])
+	case len(schema.OneOf) > 0:
+		var types []string
+		for _, one := range schema.OneOf {
+			types = append(types, tsType(one))
+		}
+		ts = strings.Join(types, " | ")
+	case len(schema.Enum) > 0:
+		var values []string
+		for _, value := range schema.Enum {
+			values = append(values, strconv.Quote(value))
+		}
+		ts = strings.Join(values, " | ")
+	case schema.Type == "string":
+		ts = "string"
+	case schema.Type == "integer" || schema.Type == "number":
+		ts = "number"
+	case schema.Type == "boolean":
+		ts = "boolean"
+	case schema.Type == "array":
+		ts = tsType(schema.Items)
+		if strings.Contains(ts, " ") {
+			ts = "(" + ts + ")"
+		}
+		ts += "[]"
+	case schema.Type == "object" && schema.Properties != nil:
+		ts = tsInlineObject(schema)
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		ts = "{ [key: string]: " + tsType(schema.AdditionalProperties) + " }"
+	default:
+		ts = "unknown"
+	}
+
+	if schema.Nullable {
+		ts += " | null"
+	}
+	return ts
+}
+
+func tsInlineObject(schema *Schema) string {
+	var properties []string
+	for _, name := range schema.propertyOrder {
+		properties = append(properties, tsField(schema, name))
+	}
+	return "{ " + strings.Join(properties, "; ") + " }"
+}
+
+func tsObject(schema *Schema, depth int) string {
+	indent := strings.Repeat("    ", depth+1)
+
+	var ts strings.Builder
+	ts.WriteString("{\n")
+	for _, name := range schema.propertyOrder {
+		ts.WriteString(indent + tsField(schema, name) + "\n")
+	}
+	ts.WriteString(strings.Repeat("    ", depth) + "}")
+
+	return ts.String()
+}
+
+func tsField(schema *Schema, name string) string {
+	optional := "?"
+	if slices.Contains(schema.Required, name) {
+		optional = ""
+	}
+	return tsProperty(name) + optional + ": " + tsType(schema.Properties[name])
+}
+
+func tsProperty(name string) string {
+	if identifierRegex.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
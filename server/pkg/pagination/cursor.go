@@ -0,0 +1,127 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cursor is opaque to clients, it only saves them from resending the values
+// of the last row. It carries the sort it was made for, so that it is not
+// applied to another order.
+type cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// CursorAfter returns the cursor of the page starting after item, a row of
+// the model of db
+func (query *Query) CursorAfter(db *gorm.DB, item any) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(item); err != nil {
+		return "", err
+	}
+	row := reflect.Indirect(reflect.ValueOf(item))
+
+	c := cursor{Sort: signature(query.Sort)}
+	for _, sort := range query.Sort {
+		column := sort.Column[strings.LastIndex(sort.Column, ".")+1:]
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			return "", fmt.Errorf("pagination: %s has no column %q", stmt.Schema.Name, column)
+		}
+
+		value, _ := field.ValueOf(db.Statement.Context, row)
+		c.Values = append(c.Values, formatValue(value))
+	}
+
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// parseCursor returns the typed values of the cursor parameter, nil when it
+// is not set
+func parseCursor(values url.Values, sorts []Sort, details map[string]string) []any {
+	raw := values.Get("cursor")
+	if raw == "" {
+		return nil
+	}
+
+	after, ok := decodeCursor(raw, sorts)
+	if !ok {
+		details["cursor"] = "cursor is invalid or does not match the sort"
+		return nil
+	}
+	return after
+}
+
+func decodeCursor(raw string, sorts []Sort) ([]any, bool) {
+	if len(raw) > maxCursorLen {
+		return nil, false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, false
+	}
+
+	var c cursor
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return nil, false
+	}
+	if c.Sort != signature(sorts) || len(c.Values) != len(sorts) {
+		return nil, false
+	}
+
+	after := make([]any, len(sorts))
+	for i, sort := range sorts {
+		value, message := parseKind(sort.Kind, c.Values[i])
+		if message != "" {
+			return nil, false
+		}
+		after[i] = value
+	}
+	return after, true
+}
+
+// signature is the sort parameter equivalent to sorts, e.g. -created_at,-id
+func signature(sorts []Sort) string {
+	names := make([]string, len(sorts))
+	for i, sort := range sorts {
+		names[i] = sort.Field
+		if sort.Desc {
+			names[i] = "-" + sort.Field
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// formatValue formats a column value as parsed back by parseKind
+func formatValue(value any) string {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		value = v.Elem().Interface()
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String() // e.g. uuid.UUID
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/google/uuid"
+)
+
+// Params documents the query parameters of resource, for the Query of its
+// operation
+func (resource *Resource) Params() []openapifx.Param {
+	params := []openapifx.Param{
+		{Name: "limit", Schema: 0, Description: fmt.Sprintf("1 to %d, %d by default", resource.maxLimit(), min(DefaultLimit, resource.maxLimit()))},
+		{Name: "cursor", Schema: "", Description: "The next_cursor of the previous page, with the same sort and filters"},
+		{Name: "sort", Schema: "", Description: fmt.Sprintf(
+			"Comma separated, - for descending, of: %s", strings.Join(resource.names(isSortable), ", "),
+		)},
+	}
+
+	for _, name := range resource.names(isFilterable) {
+		field := resource.Fields[name]
+
+		names := []string{}
+		for _, operator := range operators(field.Kind) {
+			names = append(names, string(operator))
+		}
+		params = append(params, openapifx.Param{
+			Name:   "filter[" + name + "]",
+			Schema: kindSchema(field.Kind),
+			Description: fmt.Sprintf(
+				"Equal to, or filter[%s][operator] with one of: %s", name, strings.Join(names, ", "),
+			),
+		})
+	}
+
+	return params
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func kindSchema(kind Kind) any {
+	switch kind {
+	case KindInt:
+		return 0
+	case KindFloat:
+		return 0.0
+	case KindBool:
+		return false
+	case KindTime:
+		return time.Time{}
+	case KindUUID:
+		return uuid.UUID{}
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,117 @@
+// Package pagination parses the list query parameters of an endpoint against
+// the whitelist of its resource, and pages through the rows by keyset.
+//
+//	?limit=20&sort=-created_at,last_name&filter[role]=student&filter[created_at][gte]=2025-01-01T00:00:00Z&cursor=…
+//
+// Only the fields of the Resource can be sorted or filtered, by the columns it
+// names; the values from the request are always bound as parameters. The limit
+// is bounded, and every page is ordered by a unique key so that the next one
+// starts after the last row of the previous one (the cursor) rather than at an
+// offset. Sortable columns must be NOT NULL.
+//
+// A controller parses the query, a service applies it with Find, and the
+// controller responds with the Page:
+//
+//	query, err := pagination.Parse(usersResource, ctx.Request.URL.Query())
+//	page, err := pagination.Find[*models.User](db.Model(&models.User{}), query)
+package pagination
+
+import "slices"
+
+// Bounds of a query, whatever the resource
+const (
+	DefaultLimit  = 20
+	MaxLimit      = 100
+	maxSortFields = 3
+	maxFilters    = 10
+	maxInValues   = 50
+	maxValueLen   = 256
+	maxCursorLen  = 4096
+)
+
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindBool
+	KindTime // RFC 3339
+	KindUUID
+)
+
+type Operator string
+
+const (
+	OperatorEq     Operator = "eq"
+	OperatorNe     Operator = "ne"
+	OperatorGt     Operator = "gt"
+	OperatorGte    Operator = "gte"
+	OperatorLt     Operator = "lt"
+	OperatorLte    Operator = "lte"
+	OperatorIn     Operator = "in"     // Comma separated values
+	OperatorPrefix Operator = "prefix" // Strings only, case sensitive
+)
+
+// Field is a property of a resource that clients may sort or filter by
+type Field struct {
+	Column     string // Trusted, never taken from the request, e.g. "users.created_at"
+	Kind       Kind
+	Sortable   bool
+	Filterable bool
+	Values     []string // When set, the only values accepted, e.g. the roles
+}
+
+// Resource is the whitelist of a list endpoint
+type Resource struct {
+	Fields      map[string]Field // By query name, e.g. "created_at"
+	Key         string           // Unique field breaking ties, e.g. "id"
+	DefaultSort string           // e.g. "-created_at", the Key alone when empty
+	MaxLimit    int              // Bounded by MaxLimit, which is the default
+}
+
+// Query is a parsed list query, see Parse
+type Query struct {
+	Limit   int
+	Sort    []Sort // Ending with the Key
+	Filters []Filter
+	After   []any // Values of Sort in the last row of the previous page, nil on the first
+}
+
+type Sort struct {
+	Field  string
+	Column string
+	Kind   Kind
+	Desc   bool
+}
+
+type Filter struct {
+	Field    string
+	Column   string
+	Operator Operator
+	Values   []any // Typed by the kind of the field, a single one but for OperatorIn
+}
+
+// Page is the envelope of a list response. NextCursor is null on the last page.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// operators returns the operators filtering a field of kind
+func operators(kind Kind) []Operator {
+	switch kind {
+	case KindString:
+		return []Operator{OperatorEq, OperatorNe, OperatorIn, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorPrefix}
+	case KindInt, KindFloat, KindTime:
+		return []Operator{OperatorEq, OperatorNe, OperatorIn, OperatorGt, OperatorGte, OperatorLt, OperatorLte}
+	default:
+		return []Operator{OperatorEq, OperatorNe, OperatorIn}
+	}
+}
+
+func supports(kind Kind, operator Operator) bool {
+	return slices.Contains(operators(kind), operator)
+}
@@ -0,0 +1,274 @@
+package pagination
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/google/uuid"
+)
+
+// Parse parses the limit, cursor, sort and filter[…] query parameters, other
+// parameters being left to the caller. It returns common.ErrValidation with a
+// message per invalid parameter. It panics when resource is misconfigured, as
+// resources are static.
+func Parse(resource *Resource, values url.Values) (*Query, error) {
+	resource.mustBeValid()
+
+	query := &Query{}
+	details := map[string]string{}
+
+	query.Limit = resource.parseLimit(values, details)
+	query.Sort = resource.parseSort(values, details)
+	query.Filters = resource.parseFilters(values, details)
+	if len(details) == 0 {
+		query.After = parseCursor(values, query.Sort, details)
+	}
+
+	if len(details) > 0 {
+		return nil, common.ErrValidation.WithDetails(details)
+	}
+	return query, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func (resource *Resource) mustBeValid() {
+	key, ok := resource.Fields[resource.Key]
+	if !ok || !key.Sortable {
+		panic(fmt.Sprintf("pagination: key %q is not a sortable field", resource.Key))
+	}
+
+	for name := range strings.SplitSeq(resource.DefaultSort, ",") {
+		name = strings.TrimPrefix(name, "-")
+		if field, ok := resource.Fields[name]; name != "" && (!ok || !field.Sortable) {
+			panic(fmt.Sprintf("pagination: default sort %q is not a sortable field", name))
+		}
+	}
+}
+
+func (resource *Resource) maxLimit() int {
+	if resource.MaxLimit <= 0 {
+		return MaxLimit
+	}
+	return min(resource.MaxLimit, MaxLimit)
+}
+
+func (resource *Resource) parseLimit(values url.Values, details map[string]string) int {
+	maxLimit := resource.maxLimit()
+
+	raw := values.Get("limit")
+	if raw == "" {
+		return min(DefaultLimit, maxLimit)
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 || limit > maxLimit {
+		details["limit"] = fmt.Sprintf("limit must be a number between 1 and %d", maxLimit)
+		return 0
+	}
+	return limit
+}
+
+// parseSort parses e.g. -created_at,last_name, and appends the key so that the
+// order is total
+func (resource *Resource) parseSort(values url.Values, details map[string]string) []Sort {
+	raw := values.Get("sort")
+	if raw == "" {
+		raw = resource.DefaultSort
+	}
+
+	names := []string{}
+	if raw != "" {
+		names = strings.Split(raw, ",")
+	}
+	if len(names) > maxSortFields {
+		details["sort"] = fmt.Sprintf("sort must not contain more than %d fields", maxSortFields)
+		return nil
+	}
+
+	sorts := []Sort{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name, desc := strings.CutPrefix(name, "-")
+
+		field, ok := resource.Fields[name]
+		if !ok || !field.Sortable {
+			details["sort"] = fmt.Sprintf("sort must be a list of: %s", strings.Join(resource.names(isSortable), ", "))
+			return nil
+		}
+		if seen[name] {
+			details["sort"] = "sort must not repeat a field"
+			return nil
+		}
+		seen[name] = true
+
+		sorts = append(sorts, Sort{Field: name, Column: field.Column, Kind: field.Kind, Desc: desc})
+	}
+
+	if !seen[resource.Key] {
+		key := resource.Fields[resource.Key]
+		desc := len(sorts) > 0 && sorts[len(sorts)-1].Desc
+		sorts = append(sorts, Sort{Field: resource.Key, Column: key.Column, Kind: key.Kind, Desc: desc})
+	}
+
+	return sorts
+}
+
+// parseFilters parses filter[name]=value and filter[name][operator]=value
+func (resource *Resource) parseFilters(values url.Values, details map[string]string) []Filter {
+	params := []string{}
+	for param := range values {
+		if strings.HasPrefix(param, "filter[") {
+			params = append(params, param)
+		}
+	}
+	if len(params) > maxFilters {
+		details["filter"] = fmt.Sprintf("filter must not contain more than %d conditions", maxFilters)
+		return nil
+	}
+	slices.Sort(params) // Deterministic statements
+
+	filters := []Filter{}
+	for _, param := range params {
+		name, operator, ok := parseFilterParam(param)
+		field, known := resource.Fields[name]
+		if !ok || !known || !field.Filterable {
+			details[param] = fmt.Sprintf("filter must be one of: %s", strings.Join(resource.names(isFilterable), ", "))
+			continue
+		}
+		if !supports(field.Kind, operator) {
+			details[param] = fmt.Sprintf("%s cannot be filtered with %q", name, operator)
+			continue
+		}
+		if len(values[param]) != 1 {
+			details[param] = param + " must be given once"
+			continue
+		}
+
+		raws := []string{values.Get(param)}
+		if operator == OperatorIn {
+			raws = strings.Split(raws[0], ",")
+			if len(raws) > maxInValues {
+				details[param] = fmt.Sprintf("%s must not contain more than %d values", param, maxInValues)
+				continue
+			}
+		}
+
+		filter := Filter{Field: name, Column: field.Column, Operator: operator}
+		for _, raw := range raws {
+			value, message := field.parseValue(raw)
+			if message != "" {
+				details[param] = param + " " + message
+				break
+			}
+			filter.Values = append(filter.Values, value)
+		}
+		if _, invalid := details[param]; !invalid {
+			filters = append(filters, filter)
+		}
+	}
+
+	return filters
+}
+
+// parseFilterParam splits filter[name] and filter[name][operator]
+func parseFilterParam(param string) (string, Operator, bool) {
+	rest, ok := strings.CutPrefix(param, "filter[")
+	if !ok {
+		return "", "", false
+	}
+
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	if rest == "" {
+		return name, OperatorEq, true
+	}
+
+	operator, ok := strings.CutPrefix(rest, "[")
+	if !ok {
+		return "", "", false
+	}
+	operator, ok = strings.CutSuffix(operator, "]")
+	if !ok || strings.ContainsAny(operator, "[]") {
+		return "", "", false
+	}
+	return name, Operator(operator), true
+}
+
+// parseValue returns the value typed by the kind of field, or the message of
+// why it is invalid
+func (field Field) parseValue(raw string) (any, string) {
+	if utf8.RuneCountInString(raw) > maxValueLen {
+		return nil, fmt.Sprintf("must not exceed %d characters", maxValueLen)
+	}
+	if len(field.Values) > 0 && !slices.Contains(field.Values, raw) {
+		return nil, "must be one of: " + strings.Join(field.Values, ", ")
+	}
+
+	return parseKind(field.Kind, raw)
+}
+
+func parseKind(kind Kind, raw string) (any, string) {
+	switch kind {
+	case KindInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, "must be an integer"
+		}
+		return value, ""
+	case KindFloat:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, "must be a number"
+		}
+		return value, ""
+	case KindBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, "must be true or false"
+		}
+		return value, ""
+	case KindTime:
+		value, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, "must be an RFC 3339 time"
+		}
+		return value, ""
+	case KindUUID:
+		value, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, "must be a UUID"
+		}
+		return value, ""
+	default:
+		// Postgres rejects NUL in text, which would fail as a database error
+		if !utf8.ValidString(raw) || strings.ContainsRune(raw, 0) {
+			return nil, "must be valid text"
+		}
+		return raw, ""
+	}
+}
+
+func isSortable(field Field) bool   { return field.Sortable }
+func isFilterable(field Field) bool { return field.Filterable }
+
+// names returns the sorted names of the fields matching keep
+func (resource *Resource) names(keep func(Field) bool) []string {
+	names := []string{}
+	for name, field := range resource.Fields {
+		if keep(field) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Scope filters, orders and limits db to the page of query, with one more row
+// telling whether there is a next page. Use it as db.Scopes(query.Scope).
+func (query *Query) Scope(db *gorm.DB) *gorm.DB {
+	conditions := []clause.Expression{}
+	for _, filter := range query.Filters {
+		conditions = append(conditions, filter.expression())
+	}
+	if query.After != nil {
+		conditions = append(conditions, query.afterExpression())
+	}
+	if len(conditions) > 0 {
+		db = db.Clauses(clause.Where{Exprs: conditions})
+	}
+
+	orderBy := clause.OrderBy{}
+	for _, sort := range query.Sort {
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+			Column: clause.Column{Name: sort.Column},
+			Desc:   sort.Desc,
+		})
+	}
+
+	return db.Order(orderBy).Limit(query.Limit + 1)
+}
+
+// Find reads the page of query from db, e.g. db.Model(&models.User{}) scoped
+// to what the user may list
+func Find[T any](db *gorm.DB, query *Query) (*Page[T], error) {
+	items := []T{}
+	if err := db.Scopes(query.Scope).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{Items: items}
+	if len(items) <= query.Limit {
+		return page, nil
+	}
+
+	page.Items = items[:query.Limit]
+	next, err := query.CursorAfter(db, page.Items[query.Limit-1])
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = &next
+
+	return page, nil
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+func (filter Filter) expression() clause.Expression {
+	column := clause.Column{Name: filter.Column}
+
+	switch filter.Operator {
+	case OperatorNe:
+		return clause.Neq{Column: column, Value: filter.Values[0]}
+	case OperatorGt:
+		return clause.Gt{Column: column, Value: filter.Values[0]}
+	case OperatorGte:
+		return clause.Gte{Column: column, Value: filter.Values[0]}
+	case OperatorLt:
+		return clause.Lt{Column: column, Value: filter.Values[0]}
+	case OperatorLte:
+		return clause.Lte{Column: column, Value: filter.Values[0]}
+	case OperatorIn:
+		return clause.IN{Column: column, Values: filter.Values}
+	case OperatorPrefix:
+		return clause.Like{Column: column, Value: escapeLike(filter.Values[0].(string)) + "%"}
+	default:
+		return clause.Eq{Column: column, Value: filter.Values[0]}
+	}
+}
+
+// afterExpression selects the rows after the cursor in the order of the sort,
+// e.g. (a > ?) OR (a = ? AND b > ?)
+func (query *Query) afterExpression() clause.Expression {
+	alternatives := []clause.Expression{}
+	for i, sort := range query.Sort {
+		conditions := []clause.Expression{}
+		for j := range i {
+			conditions = append(conditions, clause.Eq{Column: clause.Column{Name: query.Sort[j].Column}, Value: query.After[j]})
+		}
+
+		column := clause.Column{Name: sort.Column}
+		if sort.Desc {
+			conditions = append(conditions, clause.Lt{Column: column, Value: query.After[i]})
+		} else {
+			conditions = append(conditions, clause.Gt{Column: column, Value: query.After[i]})
+		}
+
+		alternatives = append(alternatives, clause.And(conditions...))
+	}
+
+	return clause.Or(alternatives...)
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, backslash being the
+// default escape character of Postgres
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,44 @@
+// Package pglock holds the session-level Postgres advisory locks of the job
+// scheduler and the migrator. Such a lock lives as long as its connection, so
+// a connection that may still hold one must never go back to the pool.
+package pglock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"time"
+)
+
+// How long the unlock may take, on a fresh context since the caller's may be cancelled already
+const releaseTimeout = 5 * time.Second
+
+// Key returns the advisory lock key of a lock name
+func Key(name string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// Release unlocks the lock of name held by conn, then returns conn to the pool.
+// When the unlock fails conn may still hold the lock, so it is discarded instead.
+func Release(conn *sql.Conn, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", Key(name))
+	if err != nil {
+		Discard(conn)
+		return err
+	}
+
+	return conn.Close()
+}
+
+// Discard closes the session of conn, and every lock it holds with it, rather
+// than returning it to the pool
+func Discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
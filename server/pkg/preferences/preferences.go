@@ -0,0 +1,12 @@
+package preferencesfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"preferencesfx",
+	fx.Provide(
+		NewPreferencesRoutes,
+		NewPreferencesController,
+		NewPreferencesService,
+	),
+)
@@ -0,0 +1,143 @@
+package preferencesfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type PreferencesControllerParams struct {
+	fx.In
+	Logger             *zap.Logger
+	PreferencesService PreferencesServiceInterface
+}
+
+type PreferencesController struct {
+	Logger             *zap.Logger
+	PreferencesService PreferencesServiceInterface
+}
+
+func NewPreferencesController(params PreferencesControllerParams) *PreferencesController {
+	return &PreferencesController{
+		Logger:             params.Logger,
+		PreferencesService: params.PreferencesService,
+	}
+}
+
+// ======================== REQUEST BODY ========================
+
+// UpdatePreferencesBody updates the fields that are set. Quiet hours are set
+// together, both empty clearing them.
+type UpdatePreferencesBody struct {
+	Timezone        *string                `json:"timezone"          binding:"omitempty,max=64,timezone"` // IANA, e.g. Asia/Bangkok
+	Locale          *string                `json:"locale"            binding:"omitempty,oneof=en th"`
+	Channels        *ChannelsBody          `json:"channels"`
+	QuietHoursStart *string                `json:"quiet_hours_start" binding:"required_with=QuietHoursEnd,omitempty,len=0|datetime=15:04"`
+	QuietHoursEnd   *string                `json:"quiet_hours_end"   binding:"required_with=QuietHoursStart,omitempty,len=0|datetime=15:04"`
+	DigestFrequency *types.DigestFrequency `json:"digest_frequency"  binding:"omitempty,oneof='none' 'daily' 'weekly'"` // 'none' opts out
+}
+
+type ChannelsBody struct {
+	Reminder   *ChannelSetBody `json:"reminder"`
+	Escalation *ChannelSetBody `json:"escalation"`
+}
+
+type ChannelSetBody struct {
+	Email *bool `json:"email"`
+	InApp *bool `json:"in_app"`
+}
+
+// ======================== METHODS ========================
+
+func (controller *PreferencesController) GetMyPreferences(ctx *gin.Context) {
+	// Get userID Context that set by AuthMiddleware
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		controller.Logger.Debug("User ID parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	preferences, err := controller.PreferencesService.GetPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"preferences": preferences})
+}
+
+func (controller *PreferencesController) UpdateMyPreferences(ctx *gin.Context) {
+	// Get userID Context that set by AuthMiddleware
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		controller.Logger.Debug("User ID parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	validatedBody, _ := ctx.Get("validatedBody")
+	body, _ := validatedBody.(*UpdatePreferencesBody)
+
+	preferences, err := controller.PreferencesService.UpdatePreferences(ctx.Request.Context(), userID, body)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"preferences": preferences})
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// applyTo sets the fields of body on preferences
+func (body *UpdatePreferencesBody) applyTo(preferences *models.UserPreferences) error {
+	if body.Timezone != nil {
+		preferences.Timezone = *body.Timezone
+	}
+	if body.Locale != nil {
+		preferences.Locale = *body.Locale
+	}
+	if body.DigestFrequency != nil {
+		preferences.DigestFrequency = *body.DigestFrequency
+	}
+
+	if body.Channels != nil {
+		body.Channels.Reminder.applyTo(&preferences.Channels.Reminder)
+		body.Channels.Escalation.applyTo(&preferences.Channels.Escalation)
+	}
+
+	if body.QuietHoursStart != nil && body.QuietHoursEnd != nil {
+		start, end := *body.QuietHoursStart, *body.QuietHoursEnd
+		switch {
+		case start == "" && end == "":
+			preferences.QuietHoursStart, preferences.QuietHoursEnd = nil, nil
+		case start == "" || end == "":
+			return common.ErrValidation.WithDetails(map[string]string{
+				"quiet_hours": "quiet_hours_start and quiet_hours_end must both be set or both be empty",
+			})
+		default:
+			preferences.QuietHoursStart, preferences.QuietHoursEnd = &start, &end
+		}
+	}
+
+	return nil
+}
+
+func (body *ChannelSetBody) applyTo(set *models.ChannelSet) {
+	if body == nil {
+		return
+	}
+	if body.Email != nil {
+		set.Email = *body.Email
+	}
+	if body.InApp != nil {
+		set.InApp = *body.InApp
+	}
+}
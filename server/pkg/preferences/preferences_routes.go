@@ -0,0 +1,79 @@
+package preferencesfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type PreferencesRoutesParams struct {
+	fx.In
+	Logger                *zap.Logger
+	Router                *gin.Engine
+	AuthMiddleware        *middlewarefx.AuthMiddleware
+	RequestBodyValidator  *middlewarefx.RequestBodyValidator
+	PreferencesController *PreferencesController
+}
+
+type PreferencesRoutes struct {
+	Logger                *zap.Logger
+	Router                *gin.Engine
+	AuthMiddleware        *middlewarefx.AuthMiddleware
+	RequestBodyValidator  *middlewarefx.RequestBodyValidator
+	PreferencesController *PreferencesController
+}
+
+func NewPreferencesRoutes(params PreferencesRoutesParams) *PreferencesRoutes {
+	return &PreferencesRoutes{
+		Logger:                params.Logger,
+		Router:                params.Router,
+		AuthMiddleware:        params.AuthMiddleware,
+		RequestBodyValidator:  params.RequestBodyValidator,
+		PreferencesController: params.PreferencesController,
+	}
+}
+
+func (routes *PreferencesRoutes) Setup() {
+	routes.Logger.Info("Setting up [Preferences] routes.")
+
+	routes.Router.GET(string(endpoints.GetMyPreferencesV1),
+		routes.AuthMiddleware.Handler(),
+		routes.PreferencesController.GetMyPreferences)
+
+	routes.Router.PATCH(string(endpoints.UpdateMyPreferencesV1),
+		routes.AuthMiddleware.Handler(),
+		routes.RequestBodyValidator.Handler(UpdatePreferencesBody{}),
+		routes.PreferencesController.UpdateMyPreferences)
+}
+
+func Operations() []openapifx.Operation {
+	preferencesResponse := openapifx.Object{"preferences": models.UserPreferences{}}
+
+	return []openapifx.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetMyPreferencesV1),
+			ID:        "getMyPreferences",
+			Tag:       "users",
+			Summary:   "Get the preferences of the signed in user",
+			Auth:      openapifx.AuthUser,
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: preferencesResponse}},
+		},
+		{
+			Method:    http.MethodPatch,
+			Path:      string(endpoints.UpdateMyPreferencesV1),
+			ID:        "updateMyPreferences",
+			Tag:       "users",
+			Summary:   "Update the preferences of the signed in user",
+			Auth:      openapifx.AuthUser,
+			Body:      UpdatePreferencesBody{},
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: preferencesResponse}},
+		},
+	}
+}
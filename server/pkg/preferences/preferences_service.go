@@ -0,0 +1,152 @@
+package preferencesfx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PreferencesServiceParams struct {
+	fx.In
+	Logger *zap.Logger
+	DB     *gorm.DB
+	Clock  clock.Clock
+}
+
+type PreferencesService struct {
+	Logger *zap.Logger
+	DB     *gorm.DB
+	Clock  clock.Clock
+}
+
+type PreferencesServiceInterface interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error)
+	GetPreferencesByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.UserPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, body *UpdatePreferencesBody) (*models.UserPreferences, error)
+}
+
+// Verify interface implementation at compile time
+var _ PreferencesServiceInterface = (*PreferencesService)(nil)
+
+func NewPreferencesService(params PreferencesServiceParams) PreferencesServiceInterface {
+	return &PreferencesService{
+		Logger: params.Logger,
+		DB:     params.DB,
+		Clock:  params.Clock,
+	}
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// GetPreferences returns the default preferences of a user who never set them
+func (service *PreferencesService) GetPreferences(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*models.UserPreferences, error) {
+	return service.getPreferences(service.DB.WithContext(ctx), logging.FromContext(ctx, service.Logger), userID)
+}
+
+// GetPreferencesByUserIDs returns the preferences of every user, the default
+// ones for those who never set them
+func (service *PreferencesService) GetPreferencesByUserIDs(
+	ctx context.Context,
+	userIDs []uuid.UUID,
+) (map[uuid.UUID]*models.UserPreferences, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	preferencesByUser := make(map[uuid.UUID]*models.UserPreferences, len(userIDs))
+	if len(userIDs) == 0 {
+		return preferencesByUser, nil
+	}
+
+	var rows []*models.UserPreferences
+	result := service.DB.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&rows)
+	if result.Error != nil {
+		logger.Error("User preferences database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	for _, preferences := range rows {
+		preferencesByUser[preferences.UserID] = preferences
+	}
+	for _, userID := range userIDs {
+		if _, ok := preferencesByUser[userID]; !ok {
+			preferencesByUser[userID] = models.DefaultUserPreferences(userID)
+		}
+	}
+
+	return preferencesByUser, nil
+}
+
+// UpdatePreferences applies the fields set in body over the current
+// preferences. Empty quiet hours clear them.
+func (service *PreferencesService) UpdatePreferences(
+	ctx context.Context,
+	userID uuid.UUID,
+	body *UpdatePreferencesBody,
+) (*models.UserPreferences, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	var preferences *models.UserPreferences
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		preferences, err = service.getPreferences(tx.Clauses(clause.Locking{Strength: "UPDATE"}), logger, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := body.applyTo(preferences); err != nil {
+			return err
+		}
+		preferences.UpdatedAt = service.Clock.Now()
+
+		result := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(preferences)
+		if result.Error != nil {
+			logger.Error("User preferences database upsert failed",
+				zap.String("user_id", userID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *PreferencesService) getPreferences(
+	db *gorm.DB,
+	logger *zap.Logger,
+	userID uuid.UUID,
+) (*models.UserPreferences, error) {
+	preferences := &models.UserPreferences{}
+
+	result := db.First(preferences, "user_id = ?", userID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return models.DefaultUserPreferences(userID), nil
+	} else if result.Error != nil {
+		logger.Error("User preferences database retrieval failed",
+			zap.String("user_id", userID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,14 @@
+package remindersfx
+
+import (
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"remindersfx",
+	fx.Provide(
+		NewReminderService,
+		jobsfx.AsJob(NewReminderJob),
+	),
+)
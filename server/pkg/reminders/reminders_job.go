@@ -0,0 +1,43 @@
+package remindersfx
+
+import (
+	"context"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"go.uber.org/fx"
+)
+
+type ReminderJobParams struct {
+	fx.In
+	AppConfig       *configfx.AppConfig
+	ReminderService *ReminderService
+	Clock           clock.Clock
+}
+
+// ReminderJob delivers the due reminders every ReminderCheckInterval
+type ReminderJob struct {
+	AppConfig       *configfx.AppConfig
+	ReminderService *ReminderService
+	Clock           clock.Clock
+}
+
+func NewReminderJob(params ReminderJobParams) *ReminderJob {
+	return &ReminderJob{
+		AppConfig:       params.AppConfig,
+		ReminderService: params.ReminderService,
+		Clock:           params.Clock,
+	}
+}
+
+func (job *ReminderJob) Name() string {
+	return "reminders"
+}
+
+func (job *ReminderJob) Schedule() string {
+	return "@every " + job.AppConfig.ReminderCheckInterval.String()
+}
+
+func (job *ReminderJob) Run(ctx context.Context) error {
+	return job.ReminderService.RunOnce(ctx, job.Clock.Now())
+}
@@ -0,0 +1,490 @@
+package remindersfx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	notificationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/notifications"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderServiceParams struct {
+	fx.In
+	AppConfig           *configfx.AppConfig
+	Logger              *zap.Logger
+	DB                  *gorm.DB
+	MailService         *mailfx.MailService
+	NotificationService notificationsfx.NotificationServiceInterface
+	PreferencesService  preferencesfx.PreferencesServiceInterface
+}
+
+type ReminderService struct {
+	AppConfig           *configfx.AppConfig
+	Logger              *zap.Logger
+	DB                  *gorm.DB
+	MailService         *mailfx.MailService
+	NotificationService notificationsfx.NotificationServiceInterface
+	PreferencesService  preferencesfx.PreferencesServiceInterface
+}
+
+func NewReminderService(params ReminderServiceParams) *ReminderService {
+	return &ReminderService{
+		AppConfig:           params.AppConfig,
+		Logger:              params.Logger,
+		DB:                  params.DB,
+		MailService:         params.MailService,
+		NotificationService: params.NotificationService,
+		PreferencesService:  params.PreferencesService,
+	}
+}
+
+// Reminder kinds, part of the de-duplication key
+const (
+	kindOverdue           = "overdue"
+	kindEscalateGuardians = "escalate_guardians"
+	kindEscalateTeachers  = "escalate_teachers"
+)
+
+// How long after the last escalation an overdue assignment is still looked at
+const overdueLookback = 7 * 24 * time.Hour
+
+// Every reminder is delivered over each of these channels
+var reminderChannels = []types.NotificationChannel{
+	types.NotificationChannelInApp,
+	types.NotificationChannelEmail,
+}
+
+// PendingSubmission is a student who has not submitted an assigned homework yet
+type PendingSubmission struct {
+	HomeworkID       uuid.UUID
+	HomeworkName     string
+	ClassID          uuid.UUID
+	ClassName        string
+	DueAt            time.Time
+	StudentID        uuid.UUID
+	StudentFirstName string
+	StudentLastName  string
+}
+
+func (row PendingSubmission) studentName() string {
+	return strings.TrimSpace(row.StudentFirstName + " " + row.StudentLastName)
+}
+
+type reminder struct {
+	homeworkID       uuid.UUID
+	classID          uuid.UUID
+	studentID        uuid.UUID // uuid.Nil for class-wide reminders
+	recipientID      uuid.UUID
+	kind             string
+	notificationKind types.NotificationKind
+	title            string
+	dueAt            time.Time
+	lines            func(dueAt string) []string // With the due date formatted for the recipient
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// RunOnce sends every reminder that is due at 'now' and was not sent before:
+// reminders at the configured offsets before the due date, then, for missing
+// submissions, an overdue notice to the student, an escalation to the linked
+// guardians and finally one to the class teachers. Each recipient only gets
+// the channels they enabled, with the dates in their time zone and locale, and
+// their emails wait for the end of their quiet hours.
+func (service *ReminderService) RunOnce(ctx context.Context, now time.Time) error {
+	submissions, err := service.getPendingSubmissions(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	return service.Remind(ctx, submissions, now)
+}
+
+// Remind sends the reminders of the pending submissions that are due at 'now'
+// and were not sent before, see RunOnce
+func (service *ReminderService) Remind(ctx context.Context, submissions []PendingSubmission, now time.Time) error {
+	reminders, err := service.planReminders(ctx, submissions, now)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := service.getRecipients(ctx, reminders)
+	if err != nil {
+		return err
+	}
+
+	recipientIDs := make([]uuid.UUID, 0, len(recipients))
+	for recipientID := range recipients {
+		recipientIDs = append(recipientIDs, recipientID)
+	}
+	preferences, err := service.PreferencesService.GetPreferencesByUserIDs(ctx, recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reminders {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		recipient, ok := recipients[r.recipientID]
+		if !ok {
+			continue
+		}
+
+		for _, channel := range reminderChannels {
+			service.deliver(ctx, r, recipient, preferences[recipient.ID], channel, now)
+		}
+	}
+
+	return nil
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *ReminderService) getPendingSubmissions(
+	ctx context.Context,
+	now time.Time,
+) ([]PendingSubmission, error) {
+	var maxOffset time.Duration
+	if len(service.AppConfig.ReminderOffsets) > 0 {
+		maxOffset = slices.Max(service.AppConfig.ReminderOffsets)
+	}
+	lookback := max(service.AppConfig.EscalateGuardiansAfter,
+		service.AppConfig.EscalateTeachersAfter) + overdueLookback
+
+	var rows []PendingSubmission
+	result := service.DB.WithContext(ctx).
+		Table("assignments AS a").
+		Distinct(`h.id AS homework_id, h.name AS homework_name,
+			c.id AS class_id, c.name AS class_name, a.due_at,
+			u.id AS student_id, u.first_name AS student_first_name, u.last_name AS student_last_name`).
+		Joins("JOIN homework AS h ON h.id = a.homework_id").
+		Joins("JOIN classes AS c ON c.id = a.class_id").
+		Joins("JOIN class_students AS cs ON cs.class_id = a.class_id").
+		Joins("JOIN users AS u ON u.id = cs.student_id").
+		Joins(`LEFT JOIN homework_students AS hs
+			ON hs.homework_id = a.homework_id AND hs.student_id = cs.student_id`).
+		Where("hs.submitted_at IS NULL AND u.deactivated_at IS NULL").
+		Where("a.due_at > ? AND a.due_at <= ?", now.Add(-lookback), now.Add(maxOffset)).
+		Scopes(tenant.Column("c.school_id")).
+		Scan(&rows)
+	if result.Error != nil {
+		service.Logger.Error(
+			"Pending submissions database retrieval failed",
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return rows, nil
+}
+
+func (service *ReminderService) planReminders(
+	ctx context.Context,
+	rows []PendingSubmission,
+	now time.Time,
+) ([]reminder, error) {
+	reminders := []reminder{}
+
+	// Overdue submissions that must be escalated
+	var guardianEscalations []PendingSubmission
+	missingByClass := make(map[[2]uuid.UUID][]PendingSubmission) // [homework_id, class_id]
+
+	for _, row := range rows {
+		if row.DueAt.After(now) {
+			offset, ok := service.reminderOffset(row.DueAt.Sub(now))
+			if !ok {
+				continue
+			}
+			reminders = append(reminders, reminder{
+				homeworkID:       row.HomeworkID,
+				classID:          row.ClassID,
+				studentID:        row.StudentID,
+				recipientID:      row.StudentID,
+				kind:             "before_" + offset.String(),
+				notificationKind: types.NotificationKindReminder,
+				title:            fmt.Sprintf("%s is due soon", row.HomeworkName),
+				dueAt:            row.DueAt,
+				lines: func(dueAt string) []string {
+					return []string{fmt.Sprintf("%s for %s is due on %s.",
+						row.HomeworkName, row.ClassName, dueAt)}
+				},
+			})
+			continue
+		}
+
+		reminders = append(reminders, reminder{
+			homeworkID:       row.HomeworkID,
+			classID:          row.ClassID,
+			studentID:        row.StudentID,
+			recipientID:      row.StudentID,
+			kind:             kindOverdue,
+			notificationKind: types.NotificationKindReminder,
+			title:            fmt.Sprintf("%s is overdue", row.HomeworkName),
+			dueAt:            row.DueAt,
+			lines: func(dueAt string) []string {
+				return []string{fmt.Sprintf("%s for %s was due on %s and has not been submitted yet.",
+					row.HomeworkName, row.ClassName, dueAt)}
+			},
+		})
+
+		overdueFor := now.Sub(row.DueAt)
+		if overdueFor >= service.AppConfig.EscalateGuardiansAfter {
+			guardianEscalations = append(guardianEscalations, row)
+		}
+		if overdueFor >= service.AppConfig.EscalateTeachersAfter {
+			key := [2]uuid.UUID{row.HomeworkID, row.ClassID}
+			missingByClass[key] = append(missingByClass[key], row)
+		}
+	}
+
+	guardianReminders, err := service.planGuardianEscalations(ctx, guardianEscalations)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, guardianReminders...)
+
+	teacherReminders, err := service.planTeacherEscalations(ctx, missingByClass)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, teacherReminders...)
+
+	return reminders, nil
+}
+
+func (service *ReminderService) planGuardianEscalations(
+	ctx context.Context,
+	rows []PendingSubmission,
+) ([]reminder, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		studentIDs = append(studentIDs, row.StudentID)
+	}
+
+	var links []*models.StudentGuardian
+	result := service.DB.WithContext(ctx).Where("student_id IN ?", studentIDs).Find(&links)
+	if result.Error != nil {
+		service.Logger.Error("Student guardians database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	guardiansByStudent := make(map[uuid.UUID][]uuid.UUID)
+	for _, link := range links {
+		guardiansByStudent[link.StudentID] = append(guardiansByStudent[link.StudentID], link.GuardianID)
+	}
+
+	reminders := []reminder{}
+	for _, row := range rows {
+		for _, guardianID := range guardiansByStudent[row.StudentID] {
+			reminders = append(reminders, reminder{
+				homeworkID:       row.HomeworkID,
+				classID:          row.ClassID,
+				studentID:        row.StudentID,
+				recipientID:      guardianID,
+				kind:             kindEscalateGuardians,
+				notificationKind: types.NotificationKindEscalation,
+				title:            fmt.Sprintf("%s has overdue homework", row.studentName()),
+				dueAt:            row.DueAt,
+				lines: func(dueAt string) []string {
+					return []string{fmt.Sprintf("%s has not submitted %s for %s, which was due on %s.",
+						row.studentName(), row.HomeworkName, row.ClassName, dueAt)}
+				},
+			})
+		}
+	}
+
+	return reminders, nil
+}
+
+// planTeacherEscalations sends one reminder per homework and class to each
+// teacher of the class, listing every student with a missing submission
+func (service *ReminderService) planTeacherEscalations(
+	ctx context.Context,
+	missingByClass map[[2]uuid.UUID][]PendingSubmission,
+) ([]reminder, error) {
+	if len(missingByClass) == 0 {
+		return nil, nil
+	}
+
+	classIDs := make([]uuid.UUID, 0, len(missingByClass))
+	for key := range missingByClass {
+		classIDs = append(classIDs, key[1])
+	}
+
+	var classTeachers []*models.ClassTeacher
+	result := service.DB.WithContext(ctx).Where("class_id IN ?", classIDs).Find(&classTeachers)
+	if result.Error != nil {
+		service.Logger.Error("Class teachers database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	teachersByClass := make(map[uuid.UUID][]uuid.UUID)
+	for _, classTeacher := range classTeachers {
+		teachersByClass[classTeacher.ClassID] = append(teachersByClass[classTeacher.ClassID],
+			classTeacher.TeacherID)
+	}
+
+	reminders := []reminder{}
+	for key, rows := range missingByClass {
+		first := rows[0]
+
+		lines := func(dueAt string) []string {
+			lines := []string{fmt.Sprintf("%d student(s) of %s have not submitted %s, which was due on %s:",
+				len(rows), first.ClassName, first.HomeworkName, dueAt)}
+			for _, row := range rows {
+				lines = append(lines, "- "+row.studentName())
+			}
+			return lines
+		}
+
+		for _, teacherID := range teachersByClass[key[1]] {
+			reminders = append(reminders, reminder{
+				homeworkID:       key[0],
+				classID:          key[1],
+				studentID:        uuid.Nil,
+				recipientID:      teacherID,
+				kind:             kindEscalateTeachers,
+				notificationKind: types.NotificationKindEscalation,
+				title:            fmt.Sprintf("Missing submissions for %s", first.HomeworkName),
+				dueAt:            first.DueAt,
+				lines:            lines,
+			})
+		}
+	}
+
+	return reminders, nil
+}
+
+func (service *ReminderService) getRecipients(
+	ctx context.Context,
+	reminders []reminder,
+) (map[uuid.UUID]*models.User, error) {
+	recipientIDs := make([]uuid.UUID, 0, len(reminders))
+	for _, r := range reminders {
+		recipientIDs = append(recipientIDs, r.recipientID)
+	}
+	if len(recipientIDs) == 0 {
+		return map[uuid.UUID]*models.User{}, nil
+	}
+
+	var users []*models.User
+	result := service.DB.WithContext(ctx).Where("id IN ? AND deactivated_at IS NULL", recipientIDs).Find(&users)
+	if result.Error != nil {
+		service.Logger.Error("Reminder recipients database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	recipients := make(map[uuid.UUID]*models.User, len(users))
+	for _, user := range users {
+		recipients[user.ID] = user
+	}
+
+	return recipients, nil
+}
+
+// deliver claims the reminder in reminder_deliveries before sending it,
+// so a reminder that was already claimed (e.g. before a restart) is skipped.
+// The claim is released if sending fails, so that the next run retries.
+// An email in the quiet hours of the recipient is not claimed either, the
+// first run after them sends it.
+func (service *ReminderService) deliver(
+	ctx context.Context,
+	r reminder,
+	recipient *models.User,
+	preferences *models.UserPreferences,
+	channel types.NotificationChannel,
+	now time.Time,
+) {
+	if !preferences.Wants(r.notificationKind, channel) {
+		return
+	}
+	if channel == types.NotificationChannelEmail && preferences.InQuietHours(now) {
+		return
+	}
+
+	delivery := &models.ReminderDelivery{
+		HomeworkID:  r.homeworkID,
+		ClassID:     r.classID,
+		StudentID:   r.studentID,
+		RecipientID: r.recipientID,
+		Kind:        r.kind,
+		Channel:     channel,
+		SentAt:      now,
+	}
+	logFields := []zap.Field{
+		zap.String("homework_id", r.homeworkID.String()),
+		zap.String("recipient_id", r.recipientID.String()),
+		zap.String("kind", r.kind),
+		zap.String("channel", string(channel)),
+	}
+
+	result := service.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(delivery)
+	if result.Error != nil {
+		service.Logger.Error("Reminder delivery database creation failed",
+			append(logFields, zap.Error(result.Error))...)
+		return
+	}
+	if result.RowsAffected == 0 {
+		// Already delivered
+		return
+	}
+
+	lines := r.lines(i18n.FormatDateTime(preferences.Locale, r.dueAt.In(preferences.Location())))
+
+	var err error
+	switch channel {
+	case types.NotificationChannelInApp:
+		err = service.NotificationService.CreateNotification(ctx, recipient.ID, r.title,
+			strings.Join(lines, "\n"))
+	case types.NotificationChannelEmail:
+		err = service.MailService.SendReminder(ctx, recipient, r.title, lines)
+	}
+	if err == nil {
+		return
+	}
+
+	service.Logger.Error("Reminder delivery failed", append(logFields, zap.Error(err))...)
+	result = service.DB.WithContext(ctx).Delete(delivery)
+	if result.Error != nil {
+		service.Logger.Error("Reminder delivery database deletion failed",
+			append(logFields, zap.Error(result.Error))...)
+	}
+}
+
+// reminderOffset returns the smallest configured offset that covers the
+// remaining time, so that a late start sends only the closest reminder
+func (service *ReminderService) reminderOffset(remaining time.Duration) (time.Duration, bool) {
+	found := false
+	var best time.Duration
+
+	for _, offset := range service.AppConfig.ReminderOffsets {
+		if offset >= remaining && (!found || offset < best) {
+			best = offset
+			found = true
+		}
+	}
+
+	return best, found
+}
@@ -0,0 +1,12 @@
+package rosterfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"rosterfx",
+	fx.Provide(
+		NewRosterRoutes,
+		NewRosterController,
+		NewRosterService,
+	),
+)
@@ -0,0 +1,122 @@
+package rosterfx
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type RosterControllerParams struct {
+	fx.In
+	Logger        *zap.Logger
+	AuthzService  authzfx.AuthzServiceInterface
+	RosterService RosterServiceInterface
+}
+
+type RosterController struct {
+	Logger        *zap.Logger
+	AuthzService  authzfx.AuthzServiceInterface
+	RosterService RosterServiceInterface
+}
+
+func NewRosterController(params RosterControllerParams) *RosterController {
+	return &RosterController{
+		Logger:        params.Logger,
+		AuthzService:  params.AuthzService,
+		RosterService: params.RosterService,
+	}
+}
+
+const (
+	maxRosterFileSize = 2 << 20 // 2 MiB
+	maxRosterRows     = 1000
+)
+
+// ======================== METHODS ========================
+
+// ImportRoster accepts a multipart "file" field with a .csv or .xlsx roster.
+// With ?dry_run=true, every row is validated and nothing is written.
+func (controller *RosterController) ImportRoster(ctx *gin.Context) {
+	// Get subject from Context that set by AuthMiddleware
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+
+	classID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"dry_run": "dry_run must be a boolean"}))
+		return
+	}
+
+	// Check the permission before reading the file
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, authzfx.PermissionClassManage, classID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"file": "file is required"}))
+		return
+	}
+	if fileHeader.Size > maxRosterFileSize {
+		common.RespondError(ctx, common.ErrRosterFileTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		controller.Logger.Error("Roster file opening failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return
+	}
+	defer file.Close()
+
+	rows, err := ParseFile(fileHeader.Filename, file)
+	if err != nil {
+		controller.Logger.Debug("Roster file parsing failed", zap.Error(err))
+		if errors.Is(err, ErrUnsupportedRosterType) {
+			common.RespondError(ctx, common.ErrRosterUnsupportedType)
+			return
+		}
+		common.RespondError(ctx, common.ErrRosterMalformed.WithDetails(map[string]string{"file": err.Error()}))
+		return
+	}
+	if len(rows) > maxRosterRows {
+		common.RespondError(ctx, common.ErrRosterTooManyRows)
+		return
+	}
+
+	result, err := controller.RosterService.ImportRoster(ctx.Request.Context(), classID, rows, dryRun)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	switch {
+	case result.Applied:
+		ctx.JSON(http.StatusCreated, gin.H{"roster": result})
+	case result.DryRun:
+		ctx.JSON(http.StatusOK, gin.H{"roster": result})
+	default:
+		// Some rows are invalid, nothing was written
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"roster": result})
+	}
+}
@@ -0,0 +1,170 @@
+package rosterfx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/xuri/excelize/v2"
+)
+
+// RosterRow is one student of a roster file. The rules mirror RegisterBody
+// for a student, except that gender is optional.
+type RosterRow struct {
+	Line      int              `json:"line"` // 1-based, counting the header
+	FirstName string           `json:"first_name" binding:"required,max=128,name"`
+	LastName  string           `json:"last_name"  binding:"omitempty,max=128,name"`
+	Email     string           `json:"email"      binding:"required,email,max=255"`
+	SchoolNum string           `json:"school_num" binding:"required,number,max=16"`
+	Phone     string           `json:"phone"      binding:"required,e164"`
+	Gender    types.UserGender `json:"gender"     binding:"omitempty,oneof='male' 'female' 'other' 'prefer_not_to_say'"`
+}
+
+func (row *RosterRow) Normalize() {
+	row.FirstName = common.NormalizeName(row.FirstName)
+	row.LastName = common.NormalizeName(row.LastName)
+}
+
+// Required columns of a roster file, matched case-insensitively against the
+// header. "gender" is optional.
+var requiredColumns = []string{"first_name", "last_name", "email", "school_num", "phone"}
+
+var (
+	ErrEmptyRoster           = errors.New("roster has no rows")
+	ErrUnsupportedRosterType = errors.New("roster must be a .csv or .xlsx file")
+)
+
+// ParseFile picks the parser by the extension of the file name
+func ParseFile(fileName string, reader io.Reader) ([]*RosterRow, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return ParseCSV(reader)
+	case ".xlsx":
+		return ParseXLSX(reader)
+	default:
+		return nil, ErrUnsupportedRosterType
+	}
+}
+
+// ParseCSV reads a roster with a header row. Column order does not matter and
+// unknown columns are ignored.
+func ParseCSV(reader io.Reader) ([]*RosterRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // Validated per row instead
+	csvReader.TrimLeadingSpace = true
+
+	// The reader skips blank lines, keep the real line numbers for the report
+	records := [][]string{}
+	lines := []int{}
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+
+	return parseRecords(records, lines)
+}
+
+// ParseXLSX reads the first sheet of a workbook, the first row being the header
+func ParseXLSX(reader io.Reader) ([]*RosterRow, error) {
+	workbook, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrEmptyRoster
+	}
+
+	// Cells are read as displayed, e.g. phone numbers formatted as text
+	records, err := workbook.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+
+	// Blank rows are returned as empty records, so the index is the row number
+	lines := make([]int, len(records))
+	for i := range records {
+		lines[i] = i + 1
+	}
+
+	return parseRecords(records, lines)
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// parseRecords maps the records to rows using the header in records[0].
+// lines holds the 1-based line (or spreadsheet row) number of each record.
+func parseRecords(records [][]string, lines []int) ([]*RosterRow, error) {
+	if len(records) == 0 {
+		return nil, ErrEmptyRoster
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		// Excel may prepend a BOM to the first cell
+		name = strings.TrimPrefix(name, "\uFEFF")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	rows := []*RosterRow{}
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		cell := func(name string) string {
+			idx, ok := columns[name]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		rows = append(rows, &RosterRow{
+			Line:      lines[i+1],
+			FirstName: cell("first_name"),
+			LastName:  cell("last_name"),
+			Email:     strings.ToLower(cell("email")),
+			SchoolNum: cell("school_num"),
+			Phone:     cell("phone"),
+			Gender:    types.UserGender(strings.ToLower(cell("gender"))),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyRoster
+	}
+
+	return rows, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package rosterfx
+
+import (
+	"net/http"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type RosterRoutesParams struct {
+	fx.In
+	Logger           *zap.Logger
+	Router           *gin.Engine
+	AuthMiddleware   *middlewarefx.AuthMiddleware
+	RosterController *RosterController
+}
+
+type RosterRoutes struct {
+	Logger           *zap.Logger
+	Router           *gin.Engine
+	AuthMiddleware   *middlewarefx.AuthMiddleware
+	RosterController *RosterController
+}
+
+func NewRosterRoutes(params RosterRoutesParams) *RosterRoutes {
+	return &RosterRoutes{
+		Logger:           params.Logger,
+		Router:           params.Router,
+		AuthMiddleware:   params.AuthMiddleware,
+		RosterController: params.RosterController,
+	}
+}
+
+func (routes *RosterRoutes) Setup() {
+	routes.Logger.Info("Setting up [Roster] routes.")
+
+	routes.Router.POST(string(endpoints.ImportRosterV1)+"/:id/roster",
+		routes.AuthMiddleware.Handler(),
+		routes.RosterController.ImportRoster)
+}
+
+func Operations() []openapifx.Operation {
+	rosterResponse := openapifx.Object{"roster": ImportResult{}}
+
+	return []openapifx.Operation{
+		{
+			Method:     http.MethodPost,
+			Path:       string(endpoints.ImportRosterV1) + "/:id/roster",
+			ID:         "importRoster",
+			Tag:        "classes",
+			Summary:    "Import the students of a class from a CSV or XLSX file",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Query:      []openapifx.Param{{Name: "dry_run", Schema: false, Description: "Validate without importing"}},
+			Form:       openapifx.Object{"file": openapifx.File{}},
+			Responses: []openapifx.Response{
+				{Status: http.StatusOK, Body: rosterResponse, Description: "Dry run"},
+				{Status: http.StatusCreated, Body: rosterResponse, Description: "Imported"},
+				{Status: http.StatusUnprocessableEntity, Body: rosterResponse, Description: "Some rows are invalid, nothing was imported"},
+			},
+			Errors: []int{
+				http.StatusForbidden,
+				http.StatusNotFound,
+				http.StatusRequestEntityTooLarge,
+				http.StatusUnsupportedMediaType,
+			},
+		},
+	}
+}
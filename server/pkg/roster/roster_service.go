@@ -0,0 +1,380 @@
+package rosterfx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RosterServiceParams struct {
+	fx.In
+	AppConfig   *configfx.AppConfig
+	Logger      *zap.Logger
+	DB          *gorm.DB
+	MailService *mailfx.MailService
+}
+
+type RosterService struct {
+	AppConfig   *configfx.AppConfig
+	Logger      *zap.Logger
+	DB          *gorm.DB
+	MailService *mailfx.MailService
+	validator   *validator.Validate
+}
+
+type RosterServiceInterface interface {
+	ImportRoster(ctx context.Context, classID uuid.UUID, rows []*RosterRow, dryRun bool) (*ImportResult, error)
+}
+
+// Verify interface implementation at compile time
+var _ RosterServiceInterface = (*RosterService)(nil)
+
+// Outcome of a roster row
+const (
+	RowStatusCreated = "created" // A new student account
+	RowStatusMatched = "matched" // An existing student, matched by email
+	RowStatusInvalid = "invalid"
+)
+
+type RowResult struct {
+	Line    int               `json:"line"`
+	Email   string            `json:"email"`
+	Status  string            `json:"status"`
+	UserID  *uuid.UUID        `json:"user_id"` // Nil unless applied and valid
+	Invited bool              `json:"invited"` // Set-password mail sent
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type ImportResult struct {
+	ClassID uuid.UUID    `json:"class_id"`
+	DryRun  bool         `json:"dry_run"`
+	Applied bool         `json:"applied"` // False on dry runs and when any row is invalid
+	Created int          `json:"created"`
+	Matched int          `json:"matched"`
+	Invalid int          `json:"invalid"`
+	Rows    []*RowResult `json:"rows"`
+}
+
+func NewRosterService(params RosterServiceParams) RosterServiceInterface {
+	// Same tags as the request bodies, independent of the router's validator
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	if err := common.RegisterNameValidations(validate); err != nil {
+		params.Logger.Fatal("Name validations registration failed", zap.Error(err))
+	}
+
+	return &RosterService{
+		AppConfig:   params.AppConfig,
+		Logger:      params.Logger,
+		DB:          params.DB,
+		MailService: params.MailService,
+		validator:   validate,
+	}
+}
+
+// ======================== BUSINESS LOGIC METHODS ========================
+
+// ImportRoster creates the missing student accounts and enrols every row into
+// the class. Nothing is written on a dry run or when any row is invalid.
+// Students without a password yet are invited to set one by mail.
+func (service *RosterService) ImportRoster(
+	ctx context.Context,
+	classID uuid.UUID,
+	rows []*RosterRow,
+	dryRun bool,
+) (*ImportResult, error) {
+	class, err := service.getClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingUsers, err := service.getUsersByEmails(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{ClassID: classID, DryRun: dryRun, Rows: make([]*RowResult, 0, len(rows))}
+	seenEmails := make(map[string]int) // Email to line
+
+	for _, row := range rows {
+		rowResult := &RowResult{Line: row.Line, Email: row.Email}
+		row.Normalize()
+		rowErrors := service.validateRow(ctx, row)
+
+		if line, ok := seenEmails[row.Email]; ok && row.Email != "" {
+			rowErrors["email"] = "email is duplicated on line " + strconv.Itoa(line)
+		} else {
+			seenEmails[row.Email] = row.Line
+		}
+
+		existing, exists := existingUsers[row.Email]
+		if exists && !isSameSchool(existing.SchoolID, class.SchoolID) {
+			// Without telling which school
+			rowErrors["email"] = "email is already registered"
+		} else if exists && existing.Role != types.UserRoleStudent {
+			rowErrors["email"] = "email belongs to a " + string(existing.Role)
+		}
+
+		switch {
+		case len(rowErrors) > 0:
+			rowResult.Status = RowStatusInvalid
+			rowResult.Errors = rowErrors
+			result.Invalid++
+		case exists:
+			rowResult.Status = RowStatusMatched
+			result.Matched++
+		default:
+			rowResult.Status = RowStatusCreated
+			result.Created++
+		}
+
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	if dryRun || result.Invalid > 0 {
+		return result, nil
+	}
+
+	users, err := service.applyRoster(ctx, class, rows, existingUsers, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	service.sendInvitations(ctx, class, users, result)
+
+	return result, nil
+}
+
+// ======================== HELPER METHODS ========================
+
+func (service *RosterService) getClass(ctx context.Context, classID uuid.UUID) (*models.Class, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	class := &models.Class{}
+
+	result := service.DB.WithContext(ctx).First(class, classID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		logger.Debug(
+			"Roster import skipped",
+			zap.String("reason", "class_not_found"),
+			zap.String("class_id", classID.String()),
+		)
+		return nil, common.ErrClassNotFound
+	} else if result.Error != nil {
+		logger.Error(
+			"Class database retrieval failed",
+			zap.String("class_id", classID.String()),
+			zap.Error(result.Error),
+		)
+		return nil, common.ErrDatabase
+	}
+
+	return class, nil
+}
+
+func (service *RosterService) getUsersByEmails(
+	ctx context.Context,
+	rows []*RosterRow,
+) (map[string]*models.User, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	emails := make([]string, 0, len(rows))
+	for _, row := range rows {
+		emails = append(emails, row.Email)
+	}
+
+	// Emails are unique across schools, the caller must not return users of another school
+	var users []*models.User
+	result := service.DB.WithContext(tenant.Bypass(ctx)).Where("LOWER(email) IN ?", emails).Find(&users)
+	if result.Error != nil {
+		logger.Error("Roster users database retrieval failed", zap.Error(result.Error))
+		return nil, common.ErrDatabase
+	}
+
+	byEmail := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		byEmail[strings.ToLower(user.Email)] = user
+	}
+
+	return byEmail, nil
+}
+
+// validateRow returns the messages in the locale of ctx, by field
+func (service *RosterService) validateRow(ctx context.Context, row *RosterRow) map[string]string {
+	err := service.validator.Struct(row)
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return middlewarefx.ParseValidationErrors(validationErrors, i18n.FromContext(ctx))
+	}
+
+	return map[string]string{}
+}
+
+// applyRoster creates the new students and enrols every row in one transaction.
+// It returns the user of each row.
+func (service *RosterService) applyRoster(
+	ctx context.Context,
+	class *models.Class,
+	rows []*RosterRow,
+	existingUsers map[string]*models.User,
+	importResult *ImportResult,
+) ([]*models.User, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	classID := class.ID
+	users := make([]*models.User, len(rows))
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		enrolments := make([]*models.ClassStudent, 0, len(rows))
+
+		for i, row := range rows {
+			user, ok := existingUsers[row.Email]
+			if !ok {
+				user = row.toUserModel(class.SchoolID)
+				if result := tx.Create(user); result.Error != nil {
+					logger.Error(
+						"User database creation failed",
+						zap.Int("line", row.Line),
+						zap.Error(result.Error),
+					)
+					return common.ErrDatabase
+				}
+			}
+
+			users[i] = user
+			importResult.Rows[i].UserID = &user.ID
+			enrolments = append(enrolments, &models.ClassStudent{ClassID: classID, StudentID: user.ID})
+		}
+
+		// Students may already be enrolled
+		if result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&enrolments); result.Error != nil {
+			logger.Error(
+				"Class students database creation failed",
+				zap.String("class_id", classID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		result := tx.Model(&models.Class{}).
+			Where("id = ?", classID).
+			Update("student_count", gorm.Expr(
+				"(SELECT COUNT(*) FROM class_students WHERE class_id = ?)", classID))
+		if result.Error != nil {
+			logger.Error(
+				"Class student count database update failed",
+				zap.String("class_id", classID.String()),
+				zap.Error(result.Error),
+			)
+			return common.ErrDatabase
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Roster imported",
+		zap.String("class_id", classID.String()),
+		zap.Int("created", importResult.Created),
+		zap.Int("matched", importResult.Matched),
+	)
+
+	return users, nil
+}
+
+// sendInvitations mails a set-password link to every student without a
+// password. A failed mail does not undo the import, it is reported per row
+// and can be re-sent from the outbox.
+func (service *RosterService) sendInvitations(
+	ctx context.Context,
+	class *models.Class,
+	users []*models.User,
+	importResult *ImportResult,
+) {
+	logger := logging.FromContext(ctx, service.Logger)
+	expiresIn := time.Duration(service.AppConfig.InvitationExpiresIn) * 24 * time.Hour
+
+	for i, user := range users {
+		if user.Password != common.UnusablePassword {
+			continue
+		}
+
+		// Accepted by the reset password API until the password is set, see
+		// UserService.SetUserPwdByEmail
+		setPwdToken, err := common.GenerateJTWToken(jwt.MapClaims{
+			"typ":   types.ActionTokenTypeSetPwd,
+			"email": user.Email,
+			"pwd":   common.PasswordFingerprint(user.Password),
+		}, service.AppConfig.JWTSecret, expiresIn)
+		if err != nil {
+			logger.Error("JWT action token generation failed", zap.Error(err))
+			continue
+		}
+
+		if err := service.MailService.SendRosterInvitation(ctx, user, class.Name, setPwdToken); err != nil {
+			logger.Error(
+				"Roster invitation sending failed",
+				zap.String("user_id", user.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		importResult.Rows[i].Invited = true
+	}
+}
+
+func (row *RosterRow) toUserModel(schoolID uuid.UUID) *models.User {
+	gender := row.Gender
+	if gender == "" {
+		gender = types.UserGenderPNTS
+	}
+	schoolNum := row.SchoolNum
+
+	return &models.User{
+		Role:      types.UserRoleStudent,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		Phone:     row.Phone,
+		Gender:    gender,
+		Email:     row.Email,
+		Password:  common.UnusablePassword,
+		SchoolNum: &schoolNum,
+		SchoolID:  &schoolID,
+	}
+}
+
+func isSameSchool(userSchoolID *uuid.UUID, schoolID uuid.UUID) bool {
+	return userSchoolID != nil && *userSchoolID == schoolID
+}
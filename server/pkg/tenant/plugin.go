@@ -0,0 +1,172 @@
+package tenant
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Plugin registers the tenant callbacks, see the package documentation
+type Plugin struct{}
+
+// Verify interface implementation at compile time
+var _ gorm.Plugin = Plugin{}
+
+func (Plugin) Name() string {
+	return "tenant"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := []error{
+		db.Callback().Query().Before("gorm:query").Register("tenant:query", filterBySchool),
+		db.Callback().Row().Before("gorm:row").Register("tenant:row", filterBySchool),
+		db.Callback().Update().Before("gorm:update").Register("tenant:update", filterWriteBySchool),
+		db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", filterWriteBySchool),
+		db.Callback().Create().Before("gorm:create").Register("tenant:create", assignSchool),
+	}
+
+	for _, err := range callbacks {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ======================== CALLBACKS ========================
+
+func filterBySchool(db *gorm.DB) {
+	schoolID, field, ok := scopeOf(db)
+	if !ok {
+		return
+	}
+
+	addSchoolCondition(db, field, schoolID)
+}
+
+// filterWriteBySchool leaves statements without conditions alone, so that
+// gorm still rejects them as global updates and deletes
+func filterWriteBySchool(db *gorm.DB) {
+	schoolID, field, ok := scopeOf(db)
+	if !ok {
+		return
+	}
+
+	if _, hasWhere := db.Statement.Clauses["WHERE"]; !hasWhere && !db.AllowGlobalUpdate {
+		return
+	}
+
+	addSchoolCondition(db, field, schoolID)
+}
+
+// assignSchool stamps the school on new rows without one, and rejects rows of another school
+func assignSchool(db *gorm.DB) {
+	schoolID, field, ok := scopeOf(db)
+	if !ok || schoolID == uuid.Nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	reflectValue := db.Statement.ReflectValue
+
+	assign := func(row reflect.Value) {
+		value, isZero := field.ValueOf(ctx, row)
+		if isZero {
+			if err := field.Set(ctx, row, schoolID); err != nil {
+				_ = db.AddError(err)
+			}
+			return
+		}
+
+		if rowSchoolID, ok := asUUID(value); !ok || rowSchoolID != schoolID {
+			_ = db.AddError(ErrCrossTenantWrite)
+		}
+	}
+
+	switch reflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < reflectValue.Len(); i++ {
+			assign(reflect.Indirect(reflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		assign(reflectValue)
+	}
+}
+
+// ======================== SCOPES ========================
+
+// Column filters a raw query on the school column of a joined table, such as
+// "c.school_id", by the scope of its context, with the rules of the models
+func Column(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		s, ok := scopeFromContext(db.Statement.Context)
+		switch {
+		case !ok:
+			_ = db.AddError(ErrNoTenant)
+			return db
+		case s.bypass:
+			return db
+		case s.schoolID == uuid.Nil:
+			return db.Where(column + " IS NULL")
+		default:
+			return db.Where(column+" = ?", s.schoolID)
+		}
+	}
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// scopeOf returns the school of a scoped statement on a model with a school_id
+// column, and fails the statement when its context has neither a school nor a bypass
+func scopeOf(db *gorm.DB) (uuid.UUID, *schema.Field, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return uuid.Nil, nil, false
+	}
+
+	field := db.Statement.Schema.LookUpField(schoolColumn)
+	if field == nil {
+		return uuid.Nil, nil, false
+	}
+
+	s, ok := scopeFromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrNoTenant)
+		return uuid.Nil, nil, false
+	}
+	if s.bypass {
+		return uuid.Nil, nil, false
+	}
+
+	return s.schoolID, field, true
+}
+
+func addSchoolCondition(db *gorm.DB, field *schema.Field, schoolID uuid.UUID) {
+	addCondition(db, clause.Column{Table: clause.CurrentTable, Name: field.DBName}, schoolID)
+}
+
+func addCondition(db *gorm.DB, column clause.Column, schoolID uuid.UUID) {
+	var condition clause.Expression = clause.Eq{Column: column, Value: schoolID}
+	if schoolID == uuid.Nil {
+		condition = clause.Eq{Column: column, Value: nil} // IS NULL
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{condition}})
+}
+
+func asUUID(value any) (uuid.UUID, bool) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, true
+	case *uuid.UUID:
+		if v == nil {
+			return uuid.Nil, false
+		}
+		return *v, true
+	default:
+		return uuid.Nil, false
+	}
+}
@@ -0,0 +1,81 @@
+package tracingfx
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GormPlugin starts a span for every statement, a child of the span in the
+// context of the statement
+type GormPlugin struct {
+	TracerProvider trace.TracerProvider
+}
+
+// Verify interface implementation at compile time
+var _ gorm.Plugin = GormPlugin{}
+
+// Key of the span in the statement, from the before to the after callback
+const gormSpanKey = "tracing:span"
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (plugin GormPlugin) Initialize(db *gorm.DB) error {
+	tracer := plugin.TracerProvider.Tracer(TracerName)
+
+	return errors.Join(
+		db.Callback().Create().Before("gorm:create").Register("tracing:before_create", startSpan(tracer, "create")),
+		db.Callback().Create().After("gorm:create").Register("tracing:after_create", endSpan),
+		db.Callback().Query().Before("gorm:query").Register("tracing:before_query", startSpan(tracer, "query")),
+		db.Callback().Query().After("gorm:query").Register("tracing:after_query", endSpan),
+		db.Callback().Update().Before("gorm:update").Register("tracing:before_update", startSpan(tracer, "update")),
+		db.Callback().Update().After("gorm:update").Register("tracing:after_update", endSpan),
+		db.Callback().Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan(tracer, "delete")),
+		db.Callback().Delete().After("gorm:delete").Register("tracing:after_delete", endSpan),
+		db.Callback().Row().Before("gorm:row").Register("tracing:before_row", startSpan(tracer, "row")),
+		db.Callback().Row().After("gorm:row").Register("tracing:after_row", endSpan),
+		db.Callback().Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan(tracer, "raw")),
+		db.Callback().Raw().After("gorm:raw").Register("tracing:after_raw", endSpan),
+	)
+}
+
+// ======================== CALLBACKS ========================
+
+func startSpan(tracer trace.Tracer, operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		_, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", "postgresql")),
+		)
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// endSpan records the statement without its values, which may be personal data
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,86 @@
+package tracingfx
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts the server span of the request, continuing the trace of
+// the caller if any, and puts it in the request context
+func Middleware(tracerProvider trace.TracerProvider) gin.HandlerFunc {
+	tracer := tracerProvider.Tracer(TracerName)
+
+	return func(ctx *gin.Context) {
+		reqCtx := otel.GetTextMapPropagator().Extract(ctx.Request.Context(),
+			propagation.HeaderCarrier(ctx.Request.Header))
+
+		// Route template, so that spans of the same route are grouped
+		route := ctx.FullPath()
+		spanName := ctx.Request.Method + " " + route
+		if route == "" {
+			spanName = ctx.Request.Method
+		}
+
+		reqCtx, span := tracer.Start(reqCtx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", ctx.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
+
+// Transport starts a client span for every request, named by SpanName
+type Transport struct {
+	Base           http.RoundTripper
+	TracerProvider trace.TracerProvider
+	SpanName       func(*http.Request) string
+}
+
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := transport.TracerProvider.Tracer(TracerName).Start(req.Context(), transport.SpanName(req),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	res, err := transport.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, strconv.Itoa(res.StatusCode))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package tracingfx
+
+import (
+	"context"
+	"fmt"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const serviceName = "touch-grass-scheduler"
+
+type TracerProviderParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	AppConfig *configfx.AppConfig
+	Logger    *zap.Logger
+}
+
+// NewTracerProvider exports the spans over OTLP/HTTP when TRACING_EXPORTER is
+// "otlp", configured by the standard OTEL_EXPORTER_OTLP_* variables. Otherwise
+// spans are never recorded.
+func NewTracerProvider(params TracerProviderParams) (trace.TracerProvider, error) {
+	// Continue the traces of the callers, e.g. the client
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	switch params.AppConfig.TracingExporter {
+	case "none":
+		params.Logger.Info("Tracing disabled")
+		return noop.NewTracerProvider(), nil
+	case "otlp":
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", params.AppConfig.TracingExporter)
+	}
+
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed creating OTLP exporter: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(params.AppConfig.TracingSampleRatio),
+		)),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	params.Lifecycle.Append(fx.Hook{
+		// Flush the spans still batched
+		OnStop: func(ctx context.Context) error {
+			return tracerProvider.Shutdown(ctx)
+		},
+	})
+
+	params.Logger.Info("Tracing initialization succeeded",
+		zap.Float64("sample_ratio", params.AppConfig.TracingSampleRatio),
+	)
+
+	return tracerProvider, nil
+}
@@ -0,0 +1,11 @@
+package tracingfx
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"tracingfx",
+	fx.Provide(NewTracerProvider),
+)
+
+// Name of the tracers of the server, the instrumentation scope of its spans
+const TracerName = "github.com/TeaChanathip/touch-grass-scheduler/server"
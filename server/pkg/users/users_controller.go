@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,30 +15,47 @@ import (
 
 type UsersControllerParams struct {
 	fx.In
-	Logger      *zap.Logger
-	UserService UserServiceInterface
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	UserService  UserServiceInterface
 }
 
 type UsersController struct {
-	Logger      *zap.Logger
-	UserService UserServiceInterface
+	Logger       *zap.Logger
+	AuthzService authzfx.AuthzServiceInterface
+	UserService  UserServiceInterface
 }
 
 func NewUsersController(params UsersControllerParams) *UsersController {
 	return &UsersController{
-		Logger:      params.Logger,
-		UserService: params.UserService,
+		Logger:       params.Logger,
+		AuthzService: params.AuthzService,
+		UserService:  params.UserService,
 	}
 }
 
 // ======================== REQUEST BODY ========================
 
 type UpdateUserBody struct {
-	FirstName  *string           `json:"first_name"  binding:"omitempty,max=128,alpha"`
-	MiddleName *string           `json:"middle_name" binding:"omitempty,max=128,len=0|alpha"`
-	LastName   *string           `json:"last_name"   binding:"omitempty,max=128,len=0|alpha"`
-	Phone      *string           `json:"phone"       binding:"omitempty,e164"`
-	Gender     *types.UserGender `json:"gender"      binding:"omitempty,oneof=''male' 'female' 'other' 'prefer_not_to_say'"`
+	FirstName     *string           `json:"first_name"       binding:"omitempty,max=128,name"`
+	MiddleName    *string           `json:"middle_name"      binding:"omitempty,max=128,len=0|name"`
+	LastName      *string           `json:"last_name"        binding:"omitempty,max=128,len=0|name"`
+	RomanizedName *string           `json:"romanized_name"   binding:"omitempty,max=384,len=0|romanized_name"`
+	Phone         *string           `json:"phone"            binding:"omitempty,e164"`
+	Gender        *types.UserGender `json:"gender"           binding:"omitempty,oneof='male' 'female' 'other' 'prefer_not_to_say'"`
+	Bio           *string           `json:"bio"              binding:"omitempty,max=500"`
+}
+
+func (body *UpdateUserBody) Normalize() {
+	body.FirstName = common.NormalizeNamePtr(body.FirstName)
+	body.MiddleName = common.NormalizeNamePtr(body.MiddleName)
+	body.LastName = common.NormalizeNamePtr(body.LastName)
+	body.RomanizedName = common.NormalizeNamePtr(body.RomanizedName)
+}
+
+// Admins are only created with tgsctl
+type UpdateUserRoleBody struct {
+	Role types.UserRole `json:"role" binding:"required,oneof='student' 'teacher' 'guardian'"`
 }
 
 // ======================== RESPONSE BODY ========================
@@ -50,13 +68,14 @@ type GetUploadAvatarSignedURLResponse struct {
 // ======================== METHODS ========================
 
 func (controller *UsersController) GetMe(ctx *gin.Context) {
-	userID, ok := controller.parseUserID(ctx.GetString("user_id"))
-	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
 		return
 	}
 
-	user, err := controller.UserService.GetPublicUserByID(*userID)
+	user, err := controller.UserService.GetPublicUserByID(ctx.Request.Context(), subject, subject.UserID)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -69,11 +88,16 @@ func (controller *UsersController) GetUserByID(ctx *gin.Context) {
 	// Get userIDParam from params
 	userID, ok := controller.parseUserID(ctx.Param("id"))
 	if !ok {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "request id is not uuid"})
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
 		return
 	}
 
-	user, err := controller.UserService.GetPublicUserByID(*userID)
+	subject, ok := controller.authorize(ctx, authzfx.PermissionUserRead, *userID)
+	if !ok {
+		return
+	}
+
+	user, err := controller.UserService.GetPublicUserByID(ctx.Request.Context(), subject, *userID)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -83,18 +107,47 @@ func (controller *UsersController) GetUserByID(ctx *gin.Context) {
 }
 
 func (controller *UsersController) UpdateUserByID(ctx *gin.Context) {
-	// Get userID Context that set by AuthMiddleware
-	userID, ok := controller.parseUserID(ctx.GetString("user_id"))
+	// The subject is the user, set by AuthMiddleware
+	subject, ok := controller.authorizeSelf(ctx, authzfx.PermissionUserUpdate)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
 		return
 	}
+	userID := &subject.UserID
 
 	validatedBody, _ := ctx.Get("validatedBody")
 	updateUserBody, _ := validatedBody.(*UpdateUserBody)
 	controller.Logger.Debug(fmt.Sprintf("%+v\n", updateUserBody))
 
-	user, err := controller.UserService.UpdateUserByID(*userID, updateUserBody)
+	user, err := controller.UserService.UpdateUserByID(ctx.Request.Context(), *userID, updateUserBody)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+func (controller *UsersController) UpdateUserRole(ctx *gin.Context) {
+	userID, ok := controller.parseUserID(ctx.Param("id"))
+	if !ok {
+		common.RespondError(ctx, common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"}))
+		return
+	}
+
+	subject, ok := controller.authorize(ctx, authzfx.PermissionUserRoleUpdate, *userID)
+	if !ok {
+		return
+	}
+
+	validatedBody, _ := ctx.Get("validatedBody")
+	updateUserRoleBody, _ := validatedBody.(*UpdateUserRoleBody)
+
+	user, err := controller.UserService.UpdateUserRole(
+		ctx.Request.Context(),
+		subject.UserID,
+		*userID,
+		updateUserRoleBody.Role,
+	)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -104,14 +157,14 @@ func (controller *UsersController) UpdateUserByID(ctx *gin.Context) {
 }
 
 func (controller *UsersController) GetUploadAvatarSignedURL(ctx *gin.Context) {
-	// Get userID Context that set by AuthMiddleware
-	userID, ok := controller.parseUserID(ctx.GetString("user_id"))
+	// The subject is the user, set by AuthMiddleware
+	subject, ok := controller.authorizeSelf(ctx, authzfx.PermissionUserAvatarUpload)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
 		return
 	}
+	userID := &subject.UserID
 
-	response, err := controller.UserService.GetUploadAvatarSignedURL(*userID)
+	response, err := controller.UserService.GetUploadAvatarSignedURL(ctx.Request.Context(), *userID)
 	if err != nil {
 		common.HandleBusinessLogicErr(ctx, err)
 		return
@@ -121,12 +174,12 @@ func (controller *UsersController) GetUploadAvatarSignedURL(ctx *gin.Context) {
 }
 
 func (controller *UsersController) HandleAvatarUpload(ctx *gin.Context) {
-	// Get userID Context that set by AuthMiddleware
-	userID, ok := controller.parseUserID(ctx.GetString("user_id"))
+	// The subject is the user, set by AuthMiddleware
+	subject, ok := controller.authorizeSelf(ctx, authzfx.PermissionUserAvatarUpload)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
 		return
 	}
+	userID := &subject.UserID
 
 	url, err := controller.UserService.HandleAvatarUpload(ctx.Request.Context(), *userID)
 	if err != nil {
@@ -137,6 +190,44 @@ func (controller *UsersController) HandleAvatarUpload(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"avatar_url": url.String()})
 }
 
+// authorize checks the permission of the subject set by AuthMiddleware, and
+// responds with the error when denied
+func (controller *UsersController) authorize(
+	ctx *gin.Context,
+	permission authzfx.Permission,
+	resourceID uuid.UUID,
+) (*authzfx.Subject, bool) {
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return nil, false
+	}
+
+	err = controller.AuthzService.Authorize(ctx.Request.Context(), subject, permission, resourceID)
+	if err != nil {
+		common.HandleBusinessLogicErr(ctx, err)
+		return nil, false
+	}
+
+	return subject, true
+}
+
+// authorizeSelf is authorize with the subject's own user as the resource
+func (controller *UsersController) authorizeSelf(
+	ctx *gin.Context,
+	permission authzfx.Permission,
+) (*authzfx.Subject, bool) {
+	subject, err := authzfx.SubjectFromContext(ctx)
+	if err != nil {
+		controller.Logger.Debug("Subject parsing failed", zap.Error(err))
+		common.RespondError(ctx, common.ErrInternal)
+		return nil, false
+	}
+
+	return controller.authorize(ctx, permission, subject.UserID)
+}
+
 func (controller *UsersController) parseUserID(
 	userIDStr string,
 ) (*uuid.UUID, bool) {
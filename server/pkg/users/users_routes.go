@@ -1,10 +1,14 @@
 package usersfx
 
 import (
+	"net/http"
+
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/endpoints"
 	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
-	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -44,25 +48,95 @@ func (routes *UsersRoutes) Setup() {
 		routes.UsersController.GetMe)
 
 	routes.Router.GET(string(endpoints.GetUserByIDV1)+"/:id",
-		routes.AuthMiddleware.HandlerWithRole(types.UserRoleAdmin),
+		routes.AuthMiddleware.Handler(),
 		routes.UsersController.GetUserByID)
 
 	routes.Router.PUT(string(endpoints.UpdateUserByIDV1),
-		routes.AuthMiddleware.HandlerWithRole(types.UserRoleStudent,
-			types.UserRoleTeacher,
-			types.UserRoleGuardian),
+		routes.AuthMiddleware.Handler(),
 		routes.RequestBodyValidator.Handler(UpdateUserBody{}),
 		routes.UsersController.UpdateUserByID)
 
+	routes.Router.PUT(string(endpoints.UpdateUserRoleV1)+"/:id/role",
+		routes.AuthMiddleware.Handler(),
+		routes.RequestBodyValidator.Handler(UpdateUserRoleBody{}),
+		routes.UsersController.UpdateUserRole)
+
 	routes.Router.GET(string(endpoints.GetUploadAvatarSignedURLV1),
-		routes.AuthMiddleware.HandlerWithRole(types.UserRoleStudent,
-			types.UserRoleTeacher,
-			types.UserRoleGuardian),
+		routes.AuthMiddleware.Handler(),
 		routes.UsersController.GetUploadAvatarSignedURL)
 
 	routes.Router.POST(string(endpoints.HandleAvatarUploadV1),
-		routes.AuthMiddleware.HandlerWithRole(types.UserRoleStudent,
-			types.UserRoleTeacher,
-			types.UserRoleGuardian),
+		routes.AuthMiddleware.Handler(),
 		routes.UsersController.HandleAvatarUpload)
 }
+
+func Operations() []openapifx.Operation {
+	userResponse := openapifx.Object{"user": models.PublicUser{}}
+
+	return []openapifx.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetMeV1),
+			ID:        "getMe",
+			Tag:       "users",
+			Summary:   "Get the signed in user",
+			Auth:      openapifx.AuthUser,
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: userResponse}},
+			Errors:    []int{http.StatusNotFound},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       string(endpoints.GetUserByIDV1) + "/:id",
+			ID:         "getUserById",
+			Tag:        "users",
+			Summary:    "Get a user",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Responses:  []openapifx.Response{{Status: http.StatusOK, Body: userResponse}},
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:    http.MethodPut,
+			Path:      string(endpoints.UpdateUserByIDV1),
+			ID:        "updateUser",
+			Tag:       "users",
+			Summary:   "Update the signed in user",
+			Auth:      openapifx.AuthUser,
+			Body:      UpdateUserBody{},
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: userResponse}},
+			Errors:    []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       string(endpoints.UpdateUserRoleV1) + "/:id/role",
+			ID:         "updateUserRole",
+			Tag:        "users",
+			Summary:    "Change the role of a user",
+			Auth:       openapifx.AuthUser,
+			PathParams: []openapifx.Param{{Name: "id", Schema: uuid.UUID{}}},
+			Body:       UpdateUserRoleBody{},
+			Responses:  []openapifx.Response{{Status: http.StatusOK, Body: userResponse}},
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      string(endpoints.GetUploadAvatarSignedURLV1),
+			ID:        "getUploadAvatarSignedURL",
+			Tag:       "users",
+			Summary:   "Get a signed URL and form to upload an avatar to the storage",
+			Auth:      openapifx.AuthUser,
+			Responses: []openapifx.Response{{Status: http.StatusOK, Body: GetUploadAvatarSignedURLResponse{}}},
+			Errors:    []int{http.StatusForbidden},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      string(endpoints.HandleAvatarUploadV1),
+			ID:        "handleAvatarUpload",
+			Tag:       "users",
+			Summary:   "Set the uploaded avatar as the avatar of the signed in user",
+			Auth:      openapifx.AuthUser,
+			Responses: []openapifx.Response{{Status: http.StatusCreated, Body: openapifx.Object{"avatar_url": ""}}},
+			Errors:    []int{http.StatusForbidden, http.StatusNotFound},
+		},
+	}
+}
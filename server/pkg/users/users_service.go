@@ -10,7 +10,11 @@ import (
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
@@ -18,6 +22,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserServiceParams struct {
@@ -26,6 +31,8 @@ type UserServiceParams struct {
 	Logger        *zap.Logger
 	DB            *gorm.DB
 	StorageClient *minio.Client
+	AuditService  auditfx.AuditServiceInterface
+	Clock         clock.Clock
 }
 
 type UserService struct {
@@ -33,16 +40,21 @@ type UserService struct {
 	Logger        *zap.Logger
 	DB            *gorm.DB
 	StorageClient *minio.Client
+	AuditService  auditfx.AuditServiceInterface
+	Clock         clock.Clock
 }
 
 type UserServiceInterface interface {
-	GetPublicUserByID(userID uuid.UUID) (*models.PublicUser, error)
-	UpdateUserByID(userID uuid.UUID, body *UpdateUserBody) (*models.PublicUser, error)
-	GetUploadAvatarSignedURL(userID uuid.UUID) (*GetUploadAvatarSignedURLResponse, error)
-	UpdateUserPwdByEmail(email, newPassword string) error
-	CreateUser(user *models.User) error
-	GetUserByEmail(email string) (*models.User, error)
-	GetUserByID(userID uuid.UUID) (*models.User, error)
+	GetPublicUserByID(ctx context.Context, reader *authzfx.Subject, userID uuid.UUID) (*models.PublicUser, error)
+	UpdateUserByID(ctx context.Context, userID uuid.UUID, body *UpdateUserBody) (*models.PublicUser, error)
+	UpdateUserRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, role types.UserRole) (*models.PublicUser, error)
+	GetUploadAvatarSignedURL(ctx context.Context, userID uuid.UUID) (*GetUploadAvatarSignedURLResponse, error)
+	UpdateUserPwdByEmail(ctx context.Context, email, newPassword string) error
+	SetUserPwdByEmail(ctx context.Context, email, pwdFingerprint, newPassword string) error
+	DeactivateUserByEmail(ctx context.Context, email string) error
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, reader *authzfx.Subject, userID uuid.UUID) (*models.User, error)
 	HandleAvatarUpload(ctx context.Context, userID uuid.UUID) (*url.URL, error)
 }
 
@@ -55,18 +67,25 @@ func NewUserService(params UserServiceParams) UserServiceInterface {
 		Logger:        params.Logger,
 		DB:            params.DB,
 		StorageClient: params.StorageClient,
+		AuditService:  params.AuditService,
+		Clock:         params.Clock,
 	}
 }
 
 // ======================== BUSINESS LOGIC METHODS ========================
 
-func (service *UserService) GetPublicUserByID(userID uuid.UUID) (*models.PublicUser, error) {
-	user, err := service.GetUserByID(userID)
+func (service *UserService) GetPublicUserByID(
+	ctx context.Context,
+	reader *authzfx.Subject,
+	userID uuid.UUID,
+) (*models.PublicUser, error) {
+	user, err := service.GetUserByID(ctx, reader, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	publicUser, err := user.ToPublic(
+		ctx,
 		service.Logger,
 		service.StorageClient,
 		service.AppConfig.StorageBucketName,
@@ -79,22 +98,44 @@ func (service *UserService) GetPublicUserByID(userID uuid.UUID) (*models.PublicU
 }
 
 func (service *UserService) UpdateUserByID(
+	ctx context.Context,
 	userID uuid.UUID,
 	body *UpdateUserBody,
 ) (*models.PublicUser, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	var updatedUser *models.User
 
 	// NOTE: Gorm doen't support update and return in one operation
 	// Utilize transaction for atomicity
-	err := service.DB.Transaction(
+	err := service.DB.WithContext(ctx).Transaction(
 		func(tx *gorm.DB) error {
-			// 1. Perform update
+			// 1. Get the user before the update, for the audit
+			var user models.User
+			if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return common.ErrUserNotFound
+				}
+				logger.Error("User database retrieval failed",
+					zap.String("user_id", userID.String()),
+					zap.Error(err),
+				)
+				return common.ErrDatabase
+			}
+
+			changes, err := auditfx.Changes(&user, body)
+			if err != nil {
+				logger.Error("User changes computation failed", zap.Error(err))
+				return common.ErrDatabase
+			}
+
+			// 2. Perform update
 			result := tx.Model(&models.User{}).
 				Where("id = ?", userID).
 				Updates(&body)
 
 			if result.Error != nil {
-				service.Logger.Error("User database update failed",
+				logger.Error("User database update failed",
 					zap.String("user_id", userID.String()),
 					zap.Error(result.Error),
 				)
@@ -103,7 +144,7 @@ func (service *UserService) UpdateUserByID(
 
 			// No row affected (no user found)
 			if result.RowsAffected == 0 {
-				service.Logger.Debug(
+				logger.Debug(
 					"User database update skipped",
 					zap.String("reason", "user_not_found"),
 					zap.String("user_id", userID.String()),
@@ -111,16 +152,26 @@ func (service *UserService) UpdateUserByID(
 				return common.ErrUserNotFound
 			}
 
-			// 2. Get updated user
-			err := tx.First(&updatedUser, "id = ?", userID).Error
+			// 3. Get updated user
+			err = tx.First(&updatedUser, "id = ?", userID).Error
 			if err != nil {
-				service.Logger.Error("User database retrieval failed",
+				logger.Error("User database retrieval failed",
 					zap.String("user_id", userID.String()),
 					zap.Error(err),
 				)
 				return common.ErrDatabase
 			}
-			return nil
+
+			// 4. Record the changed fields, users only update themselves
+			if len(changes) == 0 {
+				return nil
+			}
+			return service.AuditService.RecordTx(tx, &models.AuditEvent{
+				Action:   types.AuditActionProfileUpdated,
+				ActorID:  &userID,
+				TargetID: &userID,
+				Metadata: map[string]any{"changes": changes},
+			})
 		},
 	)
 	if err != nil {
@@ -128,6 +179,73 @@ func (service *UserService) UpdateUserByID(
 	}
 
 	publicUser, err := updatedUser.ToPublic(
+		ctx,
+		service.Logger,
+		service.StorageClient,
+		service.AppConfig.StorageBucketName,
+		time.Hour*time.Duration(service.AppConfig.JWTExpiresIn))
+	if err != nil {
+		return nil, err
+	}
+
+	return publicUser, nil
+}
+
+// UpdateUserRole changes the role of the user and records the change by the
+// actor in the same transaction. The user keeps the role of their access
+// token until they sign in again.
+func (service *UserService) UpdateUserRole(
+	ctx context.Context,
+	actorID uuid.UUID,
+	userID uuid.UUID,
+	role types.UserRole,
+) (*models.PublicUser, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	var user models.User
+
+	err := service.DB.WithContext(ctx).Transaction(
+		func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "id = ?", userID)
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return common.ErrUserNotFound
+			} else if result.Error != nil {
+				logger.Error("User database retrieval failed",
+					zap.String("user_id", userID.String()),
+					zap.Error(result.Error),
+				)
+				return common.ErrDatabase
+			}
+
+			if user.Role == role {
+				return nil
+			}
+			from := user.Role
+
+			result = tx.Model(&user).Update("role", role)
+			if result.Error != nil {
+				logger.Error("User role database update failed",
+					zap.String("user_id", userID.String()),
+					zap.Error(result.Error),
+				)
+				return common.ErrDatabase
+			}
+			user.Role = role
+
+			return service.AuditService.RecordTx(tx, &models.AuditEvent{
+				Action:   types.AuditActionRoleChanged,
+				ActorID:  &actorID,
+				TargetID: &userID,
+				Metadata: map[string]any{"from": from, "to": role},
+			})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	publicUser, err := user.ToPublic(
+		ctx,
 		service.Logger,
 		service.StorageClient,
 		service.AppConfig.StorageBucketName,
@@ -140,15 +258,18 @@ func (service *UserService) UpdateUserByID(
 }
 
 func (service *UserService) GetUploadAvatarSignedURL(
+	ctx context.Context,
 	userID uuid.UUID,
 ) (*GetUploadAvatarSignedURLResponse, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	var result *gorm.DB
 
 	// Delete old pending uploads (if exists)
-	result = service.DB.Where("user_id = ? AND type = 'avatar'", userID).
+	result = service.DB.WithContext(ctx).Where("user_id = ? AND type = 'avatar'", userID).
 		Delete(models.PendingUpload{})
 	if result.Error != nil {
-		service.Logger.Error(
+		logger.Error(
 			"Pending upload database deletion failed",
 			zap.String("user_id", userID.String()),
 			zap.String("type", "pending_user_avatar"),
@@ -160,16 +281,16 @@ func (service *UserService) GetUploadAvatarSignedURL(
 	// Generate ID that will be used in object name
 	objectID, err := uuid.NewRandom()
 	if err != nil {
-		service.Logger.Error("UUID generation failed", zap.Error(err))
+		logger.Error("UUID generation failed", zap.Error(err))
 		return nil, common.ErrUUIDGeneration
 	}
 
 	// Generate URL
 	objectKey := fmt.Sprintf("avatars/%s.webp", objectID.String())
 	pendingObjectKey := fmt.Sprintf("pending/%s", objectKey)
-	url, formData, err := service.generateAvatarUploadURL(pendingObjectKey)
+	url, formData, err := service.generateAvatarUploadURL(ctx, pendingObjectKey)
 	if err != nil {
-		service.Logger.Error(
+		logger.Error(
 			"Signed POST URL storage generation failed",
 			zap.String("user_id", userID.String()),
 			zap.String("type", "pending_user_avatar"),
@@ -183,12 +304,12 @@ func (service *UserService) GetUploadAvatarSignedURL(
 		ObjectKey: objectKey,
 		UserID:    userID,
 		Type:      types.UploadTypeAvatar,
-		ExpireAt:  time.Now().Add(time.Hour * 24),
+		ExpireAt:  service.Clock.Now().Add(time.Hour * 24),
 	}
 
-	result = service.DB.Create(pendingUpload)
+	result = service.DB.WithContext(ctx).Create(pendingUpload)
 	if result.Error != nil {
-		service.Logger.Error("Pending upload database creation failed",
+		logger.Error("Pending upload database creation failed",
 			zap.String("user_id", userID.String()),
 			zap.String("type", "pending_user_avatar"),
 			zap.Error(result.Error),
@@ -208,11 +329,13 @@ func (service *UserService) HandleAvatarUpload(
 	ctx context.Context,
 	userID uuid.UUID,
 ) (*url.URL, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	// 1. Query pending upload of user's avatar
 	var pendingUpload *models.PendingUpload
-	result := service.DB.Where("user_id = ? AND type = 'avatar'", userID).First(&pendingUpload)
+	result := service.DB.WithContext(ctx).Where("user_id = ? AND type = 'avatar'", userID).First(&pendingUpload)
 	if result.Error != nil {
-		service.Logger.Error(
+		logger.Error(
 			"Pending upload database retrieval failed",
 			zap.String("user_id", userID.String()),
 			zap.String("type", "pending_user_avatar"),
@@ -230,7 +353,7 @@ func (service *UserService) HandleAvatarUpload(
 	if err != nil {
 		var minioErr minio.ErrorResponse
 		if errors.As(err, &minioErr) && minioErr.Code == "NoSuchKey" {
-			service.Logger.Debug(
+			logger.Debug(
 				"Object storage upload skipped",
 				zap.String("reason", "object_not_found"),
 				zap.String("type", "pending_user_avatar"),
@@ -238,7 +361,7 @@ func (service *UserService) HandleAvatarUpload(
 			)
 			return nil, common.ErrStorageObjectNotFound
 		}
-		service.Logger.Error(
+		logger.Error(
 			"Object info storage retrieval failed",
 			zap.String("object_key", pendingKey),
 			zap.String("type", "pending_user_avatar"),
@@ -249,14 +372,14 @@ func (service *UserService) HandleAvatarUpload(
 
 	var updatedUser *models.User
 	var oldAvatarKey *string
-	err = service.DB.Transaction(func(tx *gorm.DB) error {
+	err = service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var result *gorm.DB
 
 		// 3. Get user by ID
 		result = tx.Where("id = ?", userID).First(&updatedUser)
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			// User not found
-			service.Logger.Debug(
+			logger.Debug(
 				"User avatar database upload skipped",
 				zap.String("reason", "user_not_found"),
 				zap.String("user_id", userID.String()),
@@ -264,7 +387,7 @@ func (service *UserService) HandleAvatarUpload(
 			return common.ErrUserNotFound
 		} else if result.Error != nil {
 			// Other errors
-			service.Logger.Error("User database retrieval failed",
+			logger.Error("User database retrieval failed",
 				zap.String("user_id", userID.String()),
 				zap.Error(result.Error),
 			)
@@ -282,7 +405,7 @@ func (service *UserService) HandleAvatarUpload(
 				Update("avatar_key", &pendingUpload.ObjectKey)
 			if result.Error != nil {
 				// Other errors
-				service.Logger.Error(
+				logger.Error(
 					"User avatar database update failed",
 					zap.String("user_id", userID.String()),
 					zap.Error(result.Error))
@@ -298,7 +421,7 @@ func (service *UserService) HandleAvatarUpload(
 				Where("user_id = ? AND object_key = ? AND type = 'avatar'", userID, pendingUpload.ObjectKey).
 				Delete(models.PendingUpload{})
 			if result.Error != nil {
-				service.Logger.Error(
+				logger.Error(
 					"Pending upload database deletion failed",
 					zap.String("user_id", userID.String()),
 					zap.String("type", "pending_user_avatar"),
@@ -308,7 +431,7 @@ func (service *UserService) HandleAvatarUpload(
 			}
 			if result.RowsAffected == 0 {
 				// "No pending upload deleted",
-				service.Logger.Error(
+				logger.Error(
 					"Pending upload database deletion skipped",
 					zap.String("reason", "pending_upload_not_found"),
 					zap.String("type", "pending_user_avatar"),
@@ -337,7 +460,7 @@ func (service *UserService) HandleAvatarUpload(
 	}
 	_, err = service.StorageClient.CopyObject(ctx, dst, src)
 	if err != nil {
-		service.Logger.Error(
+		logger.Error(
 			"Object storage copy failed",
 			zap.String("from_object_key", pendingKey),
 			zap.String("to_object_key", pendingUpload.ObjectKey),
@@ -358,7 +481,7 @@ func (service *UserService) HandleAvatarUpload(
 				*oldAvatarKey,
 				minio.RemoveObjectOptions{})
 			if err != nil {
-				service.Logger.Error(
+				logger.Error(
 					"Object storage deletion failed",
 					zap.String("object_key", *oldAvatarKey),
 					zap.String("type", "user_avatar"),
@@ -377,7 +500,7 @@ func (service *UserService) HandleAvatarUpload(
 			pendingKey,
 			minio.RemoveObjectOptions{})
 		if err != nil {
-			service.Logger.Error(
+			logger.Error(
 				"Object storage deletion failed",
 				zap.String("object_key", pendingKey),
 				zap.String("type", "pending_user_avatar"),
@@ -398,7 +521,7 @@ func (service *UserService) HandleAvatarUpload(
 		time.Hour*time.Duration(service.AppConfig.JWTExpiresIn),
 		nil)
 	if err != nil {
-		service.Logger.Error(
+		logger.Error(
 			"Signed GET URL storage generation failed",
 			zap.String("type", "user_avatar"),
 			zap.Error(err),
@@ -411,25 +534,29 @@ func (service *UserService) HandleAvatarUpload(
 
 // ======================== HELPER METHODS ========================
 
-func (service *UserService) CreateUser(user *models.User) error {
+func (service *UserService) CreateUser(ctx context.Context, user *models.User) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	// Hash the password
 	hashed, err := common.HashPassword(user.Password)
 	if err != nil {
-		service.Logger.Error("Password hashing failed", zap.Error(err))
+		logger.Error("Password hashing failed", zap.Error(err))
 		return common.ErrPasswordHashing
 	}
 
 	// Replace password with hashed
 	user.Password = hashed
+	// Emails are matched case-insensitively, stored lowercased
+	user.Email = strings.ToLower(user.Email)
 
 	// Create new User in DB
-	result := service.DB.Create(&user)
+	result := service.DB.WithContext(ctx).Create(&user)
 
 	if result.Error != nil {
 		// Check for PostgreSQL unique constraint violation
 		// I know this looks absurd, but it is the simplest solution
 		if strings.Contains(result.Error.Error(), "SQLSTATE 23505") {
-			service.Logger.Debug(
+			logger.Debug(
 				"User database creation skipped",
 				zap.String("reason", "email_duplicated"),
 				zap.String("email", user.Email),
@@ -438,7 +565,7 @@ func (service *UserService) CreateUser(user *models.User) error {
 		}
 
 		// Other database errors
-		service.Logger.Error(
+		logger.Error(
 			"User database creation failed",
 			zap.Error(result.Error),
 		)
@@ -448,14 +575,46 @@ func (service *UserService) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (service *UserService) GetUserByEmail(email string) (*models.User, error) {
+func (service *UserService) DeactivateUserByEmail(ctx context.Context, email string) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
+	result := service.DB.WithContext(ctx).Model(&models.User{}).
+		Where("LOWER(email) = ? AND deactivated_at IS NULL", strings.ToLower(email)).
+		Update("deactivated_at", service.Clock.Now())
+	if result.Error != nil {
+		logger.Error(
+			"User deactivation database update failed",
+			zap.String("email", email),
+			zap.Error(result.Error),
+		)
+		return common.ErrDatabase
+	}
+
+	if result.RowsAffected == 0 {
+		// Either unknown or already deactivated
+		if _, err := service.GetUserByEmail(ctx, email); err != nil {
+			return err
+		}
+		logger.Debug(
+			"User deactivation database update skipped",
+			zap.String("reason", "already_deactivated"),
+			zap.String("email", email),
+		)
+	}
+
+	return nil
+}
+
+func (service *UserService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	var user *models.User
 
-	// Query user by email
-	result := service.DB.Where("email = ?", email).First(&user)
+	// Query user by email, whatever its case
+	result := service.DB.WithContext(ctx).Where("LOWER(email) = ?", strings.ToLower(email)).First(&user)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		service.Logger.Debug(
+		logger.Debug(
 			"User database retrieval skipped",
 			zap.String("reason", "user_not_found"),
 			zap.String("email", email),
@@ -463,7 +622,7 @@ func (service *UserService) GetUserByEmail(email string) (*models.User, error) {
 		return nil, common.ErrUserNotFound
 	} else if result.Error != nil {
 		// Other errors
-		service.Logger.Error(
+		logger.Error(
 			// "Database error while fetching user with email",
 			"User database retrieval failed",
 			zap.String("email", email),
@@ -475,13 +634,22 @@ func (service *UserService) GetUserByEmail(email string) (*models.User, error) {
 	return user, nil
 }
 
-func (service *UserService) GetUserByID(userID uuid.UUID) (*models.User, error) {
+// GetUserByID returns the user read by the reader. Admins read any user of
+// their scope, unlike the relationship based reads, so their reads of other
+// users are audited.
+func (service *UserService) GetUserByID(
+	ctx context.Context,
+	reader *authzfx.Subject,
+	userID uuid.UUID,
+) (*models.User, error) {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	var user *models.User
 
-	result := service.DB.First(&user, userID)
+	result := service.DB.WithContext(ctx).First(&user, userID)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		service.Logger.Debug(
+		logger.Debug(
 			"User database retrieval skipped",
 			zap.String("reason", "user_id_not_found"),
 			zap.String("user_id", userID.String()),
@@ -489,7 +657,7 @@ func (service *UserService) GetUserByID(userID uuid.UUID) (*models.User, error)
 		return nil, common.ErrUserNotFound
 	} else if result.Error != nil {
 		// Other errors
-		service.Logger.Error(
+		logger.Error(
 			"User database retrieval failed",
 			zap.String("id", userID.String()),
 			zap.Error(result.Error),
@@ -497,29 +665,93 @@ func (service *UserService) GetUserByID(userID uuid.UUID) (*models.User, error)
 		return nil, common.ErrDatabase
 	}
 
+	isAdmin := reader.Role == types.UserRoleAdmin || reader.Role == types.UserRoleSchoolAdmin
+	if isAdmin && reader.UserID != userID {
+		service.AuditService.Record(ctx, &models.AuditEvent{
+			Action:   types.AuditActionUserRead,
+			ActorID:  &reader.UserID,
+			TargetID: &userID,
+		})
+	}
+
 	return user, nil
 }
 
-func (service *UserService) UpdateUserPwdByEmail(email, newPassword string) error {
+func (service *UserService) UpdateUserPwdByEmail(ctx context.Context, email, newPassword string) error {
+	return service.updatePwdByEmail(ctx, email, newPassword, nil)
+}
+
+// SetUserPwdByEmail sets the password of a user whose password still has the
+// fingerprint of a set-password token, so that the token is only used once
+func (service *UserService) SetUserPwdByEmail(ctx context.Context, email, pwdFingerprint, newPassword string) error {
+	return service.updatePwdByEmail(ctx, email, newPassword, &pwdFingerprint)
+}
+
+// updatePwdByEmail only updates a password that still has pwdFingerprint,
+// when not nil
+func (service *UserService) updatePwdByEmail(
+	ctx context.Context,
+	email string,
+	newPassword string,
+	pwdFingerprint *string,
+) error {
+	logger := logging.FromContext(ctx, service.Logger)
+
 	// Hash the password
 	hashed, err := common.HashPassword(newPassword)
 	if err != nil {
-		service.Logger.Error("Password hashing failed", zap.String("email", email), zap.Error(err))
+		logger.Error("Password hashing failed", zap.String("email", email), zap.Error(err))
 		return common.ErrPasswordHashing
 	}
 
-	err = service.DB.Transaction(func(tx *gorm.DB) error {
-		result := tx.Model(&models.User{}).Where("email = ?", email).Update("password", hashed)
+	err = service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if pwdFingerprint != nil {
+			var current models.User
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "password").
+				Where("LOWER(email) = ?", strings.ToLower(email)).
+				First(&current)
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return common.ErrUserNotFound
+			} else if result.Error != nil {
+				logger.Error(
+					"User database retrieval failed",
+					zap.String("email", email),
+					zap.Error(result.Error),
+				)
+				return common.ErrDatabase
+			}
+
+			if common.PasswordFingerprint(current.Password) != *pwdFingerprint {
+				logger.Debug(
+					"User password database update skipped",
+					zap.String("reason", "password_changed"),
+					zap.String("email", email),
+				)
+				return common.ErrActionTokenUsed
+			}
+		}
+
+		var user models.User
+		result := tx.Model(&user).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("LOWER(email) = ?", strings.ToLower(email)).
+			Update("password", hashed)
 		// Must be only one user that affected
 		if result.Error != nil || result.RowsAffected != 1 {
-			service.Logger.Error(
+			logger.Error(
 				"User password database update failed",
 				zap.String("email", email),
 				zap.Error(result.Error),
 			)
 			return common.ErrDatabase
 		}
-		return nil
+
+		// By the user through a reset link, or by tgsctl
+		return service.AuditService.RecordTx(tx, &models.AuditEvent{
+			Action:   types.AuditActionPasswordReset,
+			TargetID: &user.ID,
+		})
 	})
 	if err != nil {
 		return err
@@ -529,6 +761,7 @@ func (service *UserService) UpdateUserPwdByEmail(email, newPassword string) erro
 }
 
 func (service *UserService) generateAvatarUploadURL(
+	ctx context.Context,
 	objectKey string,
 ) (*url.URL, map[string]string, error) {
 	// Create upload policy
@@ -543,7 +776,7 @@ func (service *UserService) generateAvatarUploadURL(
 	}
 
 	// Set an expiration
-	if err := policy.SetExpires(time.Now().Add(3 * time.Minute)); err != nil {
+	if err := policy.SetExpires(service.Clock.Now().Add(3 * time.Minute)); err != nil {
 		return nil, nil, err
 	}
 
@@ -555,7 +788,6 @@ func (service *UserService) generateAvatarUploadURL(
 	}
 
 	// Generate signed URL
-	ctx := context.Background()
 	url, formData, err := service.StorageClient.PresignedPostPolicy(ctx, policy)
 	if err != nil {
 		return nil, nil, err
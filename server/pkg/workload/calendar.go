@@ -0,0 +1,39 @@
+package workload
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budgets are the daily man-hours budgets of students
+type Budgets struct {
+	Default   float64
+	ByStudent map[uuid.UUID]float64 // Of the students with their own
+}
+
+// Of returns the budget of the student
+func (budgets Budgets) Of(studentID uuid.UUID) float64 {
+	if budget, ok := budgets.ByStudent[studentID]; ok {
+		return budget
+	}
+	return budgets.Default
+}
+
+// Calendar tells the school days, on which homework is assigned and due.
+// Students may still work on the other days.
+type Calendar struct {
+	Weekend  []time.Weekday
+	Holidays []time.Time // Any time of the day, the calendar date counts
+}
+
+// IsSchoolDay reports whether the calendar date of day is a school day
+func (calendar Calendar) IsSchoolDay(day time.Time) bool {
+	if slices.Contains(calendar.Weekend, day.Weekday()) {
+		return false
+	}
+	return !slices.ContainsFunc(calendar.Holidays, func(holiday time.Time) bool {
+		return DaysBetween(holiday, day) == 0
+	})
+}
@@ -0,0 +1,63 @@
+package workload
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Heatmap is the daily load of a group of students, e.g. a class
+type Heatmap struct {
+	Days     []time.Time  // Midnight in the location of the window start
+	ManHours [][]float64  // By student, in the given order, then by day
+	Summary  []DaySummary // By day
+}
+
+// DaySummary is the load of the group on a day
+type DaySummary struct {
+	Median     float64
+	Max        float64
+	Overloaded int // Number of students over the budget
+}
+
+// BuildHeatmap builds the DailyLoad of every student over the window
+// [from, from+days), against their budget, with the median and maximum load
+// of each day
+func BuildHeatmap(
+	studentIDs []uuid.UUID,
+	itemsByStudent map[uuid.UUID][]Item,
+	from time.Time,
+	days int,
+	budgets Budgets,
+) *Heatmap {
+	heatmap := &Heatmap{
+		Days:     make([]time.Time, days),
+		ManHours: make([][]float64, len(studentIDs)),
+		Summary:  make([]DaySummary, days),
+	}
+
+	windowStart := StartOfDay(from)
+	for i := range days {
+		heatmap.Days[i] = windowStart.AddDate(0, 0, i)
+	}
+
+	byDay := make([][]float64, days)
+	for s, studentID := range studentIDs {
+		heatmap.ManHours[s] = make([]float64, days)
+
+		for i, day := range DailyLoad(itemsByStudent[studentID], from, days, budgets.Of(studentID)) {
+			heatmap.ManHours[s][i] = day.ManHours
+			byDay[i] = append(byDay[i], day.ManHours)
+			if day.Overloaded {
+				heatmap.Summary[i].Overloaded++
+			}
+		}
+	}
+
+	for i := range days {
+		heatmap.Summary[i].Median = Percentile(byDay[i], 50)
+		heatmap.Summary[i].Max = Percentile(byDay[i], 100)
+	}
+
+	return heatmap
+}
@@ -0,0 +1,160 @@
+package workload
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Problem is a homework to place for a group of students, e.g. a class
+type Problem struct {
+	StudentIDs     []uuid.UUID
+	ItemsByStudent map[uuid.UUID][]Item // Without the homework
+	ManHours       float64              // Of the homework
+	From           time.Time            // Start of the window, the earliest assignment
+	Days           int
+	Budgets        Budgets
+	Calendar       Calendar
+}
+
+// Slot is a proposed window of the homework, from the day it is assigned to
+// the day it is due, with the load it leaves the students with
+type Slot struct {
+	AssignedOn       time.Time // Midnight in the location of the window start
+	DueOn            time.Time // Midnight in the location of the window start
+	PeakManHours     float64   // Highest daily man-hours of a student over the window
+	PushedOverBudget int       // Students, see PushedOverBudget
+}
+
+// Tolerance of the comparisons of man-hours, so that the rounding errors of
+// the shares never break a tie
+const tolerance = 1e-9
+
+// Daily load of a student without the homework
+type baseline struct {
+	manHours []float64 // By day of the window
+	peak     float64
+	budget   float64
+}
+
+type scoredSlot struct {
+	Slot
+	overBudget float64 // Man-hours over the budgets added by the homework
+	peaks      float64 // Sum of the peaks of the students
+	span       int     // Days
+	dueIdx     int
+}
+
+// Optimize proposes up to limit slots of the homework in the window [From,
+// From+Days), assigned and due on school days, each one due on a different
+// day. The homework is spread evenly from the day it is assigned to the day
+// it is due, as in DailyLoad, so a large homework is spread over several days
+// to lower the peak. The slots are ranked by, in order:
+//
+//  1. the man-hours they add over the budgets of the students,
+//  2. the peak daily man-hours of a student over the window,
+//  3. the sum of the peaks of the students,
+//  4. the fewest days, then the earliest due date.
+//
+// The best slot due on a day is ranked the same way. The slots only depend on
+// the problem, never on the order of iteration of maps.
+func Optimize(problem Problem, limit int) []Slot {
+	windowStart := StartOfDay(problem.From)
+
+	schoolDays := []int{}
+	for i := range problem.Days {
+		if problem.Calendar.IsSchoolDay(windowStart.AddDate(0, 0, i)) {
+			schoolDays = append(schoolDays, i)
+		}
+	}
+
+	baselines := make([]baseline, len(problem.StudentIDs))
+	for s, studentID := range problem.StudentIDs {
+		baselines[s] = baseline{manHours: make([]float64, problem.Days), budget: problem.Budgets.Of(studentID)}
+		for i, day := range DailyLoad(problem.ItemsByStudent[studentID], problem.From, problem.Days, baselines[s].budget) {
+			baselines[s].manHours[i] = day.ManHours
+			baselines[s].peak = max(baselines[s].peak, day.ManHours)
+		}
+	}
+
+	// The best slot due on each school day
+	candidates := []scoredSlot{}
+	for d, dueIdx := range schoolDays {
+		var best *scoredSlot
+		for _, assignedIdx := range schoolDays[:d+1] {
+			slot := scoreSlot(baselines, problem.ManHours, assignedIdx, dueIdx)
+			if best == nil || compareSlots(slot, *best) < 0 {
+				best = &slot
+			}
+		}
+		best.AssignedOn = windowStart.AddDate(0, 0, best.dueIdx-best.span+1)
+		best.DueOn = windowStart.AddDate(0, 0, best.dueIdx)
+		candidates = append(candidates, *best)
+	}
+
+	slices.SortStableFunc(candidates, compareSlots)
+
+	slots := make([]Slot, 0, min(limit, len(candidates)))
+	for _, candidate := range candidates[:min(limit, len(candidates))] {
+		slots = append(slots, candidate.Slot)
+	}
+	return slots
+}
+
+// ======================== HELPER FUNCTIONS ========================
+
+// scoreSlot scores the homework assigned and due on the days of the window
+func scoreSlot(baselines []baseline, manHours float64, assignedIdx, dueIdx int) scoredSlot {
+	slot := scoredSlot{span: dueIdx - assignedIdx + 1, dueIdx: dueIdx}
+	share := manHours / float64(slot.span)
+
+	for _, student := range baselines {
+		peak := student.peak
+		pushed := false
+		for i := assignedIdx; i <= dueIdx; i++ {
+			before := student.manHours[i]
+			after := before + share
+
+			slot.overBudget += max(after-student.budget, 0) - max(before-student.budget, 0)
+			pushed = pushed || (after > student.budget && before <= student.budget)
+			peak = max(peak, after)
+		}
+
+		slot.PeakManHours = max(slot.PeakManHours, peak)
+		slot.peaks += peak
+		if pushed {
+			slot.PushedOverBudget++
+		}
+	}
+
+	return slot
+}
+
+func compareSlots(a, b scoredSlot) int {
+	if c := compareManHours(a.overBudget, b.overBudget); c != 0 {
+		return c
+	}
+	if c := compareManHours(a.PeakManHours, b.PeakManHours); c != 0 {
+		return c
+	}
+	if c := compareManHours(a.peaks, b.peaks); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.span, b.span); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.dueIdx, b.dueIdx)
+}
+
+func compareManHours(a, b float64) int {
+	switch {
+	case a < b-tolerance:
+		return -1
+	case a > b+tolerance:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,82 @@
+package workload
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushedOverBudget returns the students, in the given order, that the change
+// from their items before to their items after pushes over the budget:
+// overloaded on a day of the window [from, from+days) after and not before,
+// against their own budget. A student already over the budget on a day is not
+// pushed again.
+func PushedOverBudget(
+	studentIDs []uuid.UUID,
+	before map[uuid.UUID][]Item,
+	after map[uuid.UUID][]Item,
+	from time.Time,
+	days int,
+	budgets Budgets,
+) []uuid.UUID {
+	pushed := []uuid.UUID{}
+	for _, studentID := range studentIDs {
+		loadBefore := DailyLoad(before[studentID], from, days, budgets.Of(studentID))
+		loadAfter := DailyLoad(after[studentID], from, days, budgets.Of(studentID))
+		for i := range loadAfter {
+			if loadAfter[i].Overloaded && !loadBefore[i].Overloaded {
+				pushed = append(pushed, studentID)
+				break
+			}
+		}
+	}
+	return pushed
+}
+
+// AlternativeDueDays returns up to limit school days of the window [from,
+// from+days) on which item could be due instead, pushing none of the students
+// over their budget on top of their items. The closest days to the due date of
+// item come first, the earlier one on a tie. Days before the assignment of
+// item, and its own due date, are not alternatives.
+func AlternativeDueDays(
+	studentIDs []uuid.UUID,
+	itemsByStudent map[uuid.UUID][]Item,
+	item Item,
+	from time.Time,
+	days int,
+	budgets Budgets,
+	calendar Calendar,
+	limit int,
+) []time.Time {
+	windowStart := StartOfDay(from)
+	dueIdx := DaysBetween(windowStart, item.DueAt.In(from.Location()))
+	firstIdx := 0
+	if item.AssignedAt != nil {
+		firstIdx = max(DaysBetween(windowStart, item.AssignedAt.In(from.Location())), 0)
+	}
+
+	alternatives := []time.Time{}
+	for distance := 1; dueIdx-distance >= firstIdx || dueIdx+distance < days; distance++ {
+		for _, idx := range []int{dueIdx - distance, dueIdx + distance} {
+			if idx < firstIdx || idx >= days || !calendar.IsSchoolDay(windowStart.AddDate(0, 0, idx)) {
+				continue
+			}
+
+			candidate := item
+			candidate.DueAt = windowStart.AddDate(0, 0, idx)
+			after := make(map[uuid.UUID][]Item, len(studentIDs))
+			for _, studentID := range studentIDs {
+				after[studentID] = append(slices.Clone(itemsByStudent[studentID]), candidate)
+			}
+
+			if len(PushedOverBudget(studentIDs, itemsByStudent, after, from, days, budgets)) == 0 {
+				alternatives = append(alternatives, candidate.DueAt)
+			}
+			if len(alternatives) == limit {
+				return alternatives
+			}
+		}
+	}
+	return alternatives
+}
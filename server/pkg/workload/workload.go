@@ -0,0 +1,113 @@
+package workload
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is a single assignment as seen by one student
+type Item struct {
+	HomeworkID   uuid.UUID  `json:"homework_id"`
+	HomeworkName string     `json:"homework_name"`
+	ClassID      uuid.UUID  `json:"class_id"`
+	ClassName    string     `json:"class_name"`
+	ManHours     float64    `json:"man_hours"`
+	AssignedAt   *time.Time `json:"assigned_at"`
+	DueAt        time.Time  `json:"due_at"`
+}
+
+// Day is the load of one student on one calendar day
+type Day struct {
+	Date       time.Time `json:"date"` // Midnight in the location of the window start
+	ManHours   float64   `json:"man_hours"`
+	Overloaded bool      `json:"overloaded"`
+	Due        []Item    `json:"due"` // Items due on that day
+}
+
+// DailyLoad builds the per-day load of a student for the window [from, from+days).
+// The man-hours of each item are spread evenly over the days between its
+// assignment (or the window start, if later) and its due date, inclusive.
+// A day is overloaded when its man-hours exceed the budget.
+func DailyLoad(items []Item, from time.Time, days int, budget float64) []Day {
+	loc := from.Location()
+	windowStart := StartOfDay(from)
+
+	result := make([]Day, days)
+	for i := range result {
+		result[i].Date = windowStart.AddDate(0, 0, i)
+		result[i].Due = []Item{}
+	}
+
+	for _, item := range items {
+		dueDay := StartOfDay(item.DueAt.In(loc))
+
+		spreadStart := windowStart
+		if item.AssignedAt != nil {
+			if assignedDay := StartOfDay(item.AssignedAt.In(loc)); assignedDay.After(spreadStart) {
+				spreadStart = assignedDay
+			}
+		}
+		if spreadStart.After(dueDay) {
+			spreadStart = dueDay
+		}
+
+		spreadDays := DaysBetween(spreadStart, dueDay) + 1
+		perDay := item.ManHours / float64(spreadDays)
+
+		for d := 0; d < spreadDays; d++ {
+			idx := DaysBetween(windowStart, spreadStart) + d
+			if idx < 0 || idx >= days {
+				continue
+			}
+			result[idx].ManHours += perDay
+		}
+
+		if idx := DaysBetween(windowStart, dueDay); idx >= 0 && idx < days {
+			result[idx].Due = append(result[idx].Due, item)
+		}
+	}
+
+	for i := range result {
+		result[i].Overloaded = result[i].ManHours > budget
+	}
+
+	return result
+}
+
+// StartOfDay returns midnight of t in t's location
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// DaysBetween counts calendar days from a to b (negative if b is before a)
+func DaysBetween(a, b time.Time) int {
+	// Compare the calendar dates in UTC so that DST shifts don't matter
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	aDate := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	bDate := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+
+	return int(bDate.Sub(aDate).Hours() / 24)
+}
+
+// Percentile returns the p-th percentile (0 to 100) of values, interpolated
+// between the closest ranks, e.g. 50 for the median and 100 for the maximum.
+// It is 0 without values, which are left unsorted.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := min(max(p, 0), 100) / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
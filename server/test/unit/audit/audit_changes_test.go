@@ -0,0 +1,87 @@
+package audit_unit_test
+
+import (
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestChanges(t *testing.T) {
+	// ------------------ Arrange ------------------
+	user := &models.User{
+		FirstName: "John",
+		LastName:  "Smith",
+		Phone:     "+66912345678",
+		Gender:    types.UserGenderMale,
+		Password:  "hashed",
+	}
+
+	cases := []struct {
+		name     string
+		body     *usersfx.UpdateUserBody
+		expected map[string]auditfx.Change
+	}{
+		{
+			name:     "empty body",
+			body:     &usersfx.UpdateUserBody{},
+			expected: map[string]auditfx.Change{},
+		},
+		{
+			name:     "unchanged field is left out",
+			body:     &usersfx.UpdateUserBody{FirstName: ptr("John")},
+			expected: map[string]auditfx.Change{},
+		},
+		{
+			name: "changed fields",
+			body: &usersfx.UpdateUserBody{
+				FirstName: ptr("Jane"),
+				LastName:  ptr("Smith"),
+				Gender:    ptr(types.UserGenderFemale),
+			},
+			expected: map[string]auditfx.Change{
+				"first_name": {From: "John", To: "Jane"},
+				"gender":     {From: "male", To: "female"},
+			},
+		},
+		{
+			name: "cleared field",
+			body: &usersfx.UpdateUserBody{LastName: ptr("")},
+			expected: map[string]auditfx.Change{
+				"last_name": {From: "Smith", To: ""},
+			},
+		},
+		{
+			name: "enum field",
+			body: &usersfx.UpdateUserBody{Gender: ptr(types.UserGenderOther)},
+			expected: map[string]auditfx.Change{
+				"gender": {From: "male", To: "other"},
+			},
+		},
+		{
+			name: "unset optional field",
+			body: &usersfx.UpdateUserBody{Bio: ptr("Hi")},
+			expected: map[string]auditfx.Change{
+				"bio": {From: nil, To: "Hi"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			changes, err := auditfx.Changes(user, tc.body)
+
+			// ------------------ Assert -------------------
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, changes)
+		})
+	}
+}
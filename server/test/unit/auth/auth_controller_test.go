@@ -16,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
 
@@ -44,7 +45,7 @@ func TestAuthController_Register_Success(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Password:  "12345678",
-		SchoolNum: "12345",
+		SchoolNum: ptr("12345"),
 	}
 
 	expectedUser := &models.PublicUser{
@@ -55,18 +56,19 @@ func TestAuthController_Register_Success(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Email:     "johnsmith@gmail.com",
-		SchoolNum: "12345",
+		SchoolNum: ptr("12345"),
 	}
 
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 	ctx.Params = gin.Params{
 		{Key: "registrationToken", Value: "testregistrationtoken"},
 	}
 	ctx.Set("validatedBody", registerBody)
 
 	// Setup mock expectation
-	mockAuthService.On("Register", "testregistrationtoken", registerBody).Return(expectedUser, "testaccesstokenvalue", nil)
+	mockAuthService.On("Register", mock.Anything, "testregistrationtoken", registerBody).Return(expectedUser, "testaccesstokenvalue", nil)
 
 	// ------------------ Act ----------------------
 	authController.Register(ctx)
@@ -84,9 +86,11 @@ func TestAuthController_Register_Success(t *testing.T) {
 	userMap, _ := responseBody["user"].(map[string]any)
 	assert.Equal(t, "johnsmith@gmail.com", userMap["email"])
 
-	// Verify accessToken
-	assert.Contains(t, responseBody, "accessToken")
-	assert.Equal(t, responseBody["accessToken"], "testaccesstokenvalue")
+	// Verify accessToken, only set as an HttpOnly cookie
+	assert.NotContains(t, responseBody, "accessToken")
+	cookie := w.Header().Get("Set-Cookie")
+	assert.Contains(t, cookie, "accessToken=testaccesstokenvalue")
+	assert.Contains(t, cookie, "HttpOnly")
 
 	// Verify mock was called
 	mockAuthService.AssertExpectations(t)
@@ -117,6 +121,7 @@ func TestAuthController_Register_MissingRequiredSchoolNum(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 		ctx.Params = gin.Params{
 			{Key: "registrationToken", Value: "testregistrationtoken"},
 		}
@@ -129,12 +134,13 @@ func TestAuthController_Register_MissingRequiredSchoolNum(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
 		// Parse response body
-		var responseBody map[string]any
+		var responseBody common.ErrorResponse
 		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 		assert.NoError(t, err)
-		assert.Contains(t, responseBody, "error")
-		assert.Contains(t, responseBody["error"],
-			fmt.Sprintf("%s must provide school_num", tc.Role))
+		assert.Equal(t, common.ErrValidation.Code, responseBody.Error.Code)
+		assert.Equal(t,
+			fmt.Sprintf("%s must provide school_num", tc.Role),
+			responseBody.Error.Details["school_num"])
 	}
 }
 
@@ -151,11 +157,12 @@ func TestAuthController_Register_UnexpectedSchoolNum(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Password:  "12345678",
-		SchoolNum: "92839",
+		SchoolNum: ptr("92839"),
 	}
 
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 	ctx.Params = gin.Params{
 		{Key: "registrationToken", Value: "testregistrationtoken"},
 	}
@@ -168,17 +175,18 @@ func TestAuthController_Register_UnexpectedSchoolNum(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Parse response body
-	var responseBody map[string]any
+	var responseBody common.ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 	assert.NoError(t, err)
-	assert.Contains(t, responseBody, "error")
-	assert.Contains(t, responseBody["error"],
-		fmt.Sprintf("%s should not provide school_num", types.UserRoleGuardian))
+	assert.Equal(t, common.ErrValidation.Code, responseBody.Error.Code)
+	assert.Equal(t,
+		fmt.Sprintf("%s should not provide school_num", types.UserRoleGuardian),
+		responseBody.Error.Details["school_num"])
 }
 
 func TestAuthController_Register_InternalServerError(t *testing.T) {
 	testCases := []struct {
-		errType error
+		errType common.CustomError
 	}{
 		{common.ErrDatabase},
 		{common.ErrTokenGeneration},
@@ -199,18 +207,19 @@ func TestAuthController_Register_InternalServerError(t *testing.T) {
 			Phone:     "+66912345678",
 			Gender:    types.UserGenderMale,
 			Password:  "12345678",
-			SchoolNum: "1",
+			SchoolNum: ptr("1"),
 		}
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 		ctx.Params = gin.Params{
 			{Key: "registrationToken", Value: "testregistrationtoken"},
 		}
 		ctx.Set("validatedBody", registerBody)
 
 		// Setup mock expectation
-		mockAuthService.On("Register", "testregistrationtoken", registerBody).Return(nil, "", tc.errType)
+		mockAuthService.On("Register", mock.Anything, "testregistrationtoken", registerBody).Return(nil, "", tc.errType)
 
 		// ------------------ Act ----------------------
 		authController.Register(ctx)
@@ -219,11 +228,11 @@ func TestAuthController_Register_InternalServerError(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 		// Parse response body
-		var responseBody map[string]any
+		var responseBody common.ErrorResponse
 		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 		assert.NoError(t, err)
-		assert.Contains(t, responseBody, "error")
-		assert.Contains(t, responseBody["error"], tc.errType.Error())
+		assert.Equal(t, tc.errType.Code, responseBody.Error.Code)
+		assert.Equal(t, tc.errType.Message, responseBody.Error.Message)
 
 		// Verify mock was called
 		mockAuthService.AssertExpectations(t)
@@ -258,15 +267,16 @@ func TestAuthController_Login_Success(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Email:     "johnsmith@gmail.com",
-		SchoolNum: "12345",
+		SchoolNum: ptr("12345"),
 	}
 
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 	ctx.Set("validatedBody", loginBody)
 
 	// Setup mock expectation
-	mockAuthService.On("Login", loginBody).Return(expectedUser, "testaccesstokenvalue", nil)
+	mockAuthService.On("Login", mock.Anything, loginBody).Return(expectedUser, "testaccesstokenvalue", nil)
 
 	// ------------------ Act ----------------------
 	authController.Login(ctx)
@@ -284,9 +294,11 @@ func TestAuthController_Login_Success(t *testing.T) {
 	userMap, _ := responseBody["user"].(map[string]any)
 	assert.Equal(t, "johnsmith@gmail.com", userMap["email"])
 
-	// Verify accessToken
-	assert.Contains(t, responseBody, "accessToken")
-	assert.Equal(t, responseBody["accessToken"], "testaccesstokenvalue")
+	// Verify accessToken, only set as an HttpOnly cookie
+	assert.NotContains(t, responseBody, "accessToken")
+	cookie := w.Header().Get("Set-Cookie")
+	assert.Contains(t, cookie, "accessToken=testaccesstokenvalue")
+	assert.Contains(t, cookie, "HttpOnly")
 
 	// Verify mock was called
 	mockAuthService.AssertExpectations(t)
@@ -294,7 +306,7 @@ func TestAuthController_Login_Success(t *testing.T) {
 
 func TestAuthController_Login_InternalServerError(t *testing.T) {
 	testCases := []struct {
-		errType error
+		errType common.CustomError
 	}{
 		{common.ErrDatabase},
 		{common.ErrTokenGeneration},
@@ -315,10 +327,11 @@ func TestAuthController_Login_InternalServerError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
 		ctx.Set("validatedBody", loginBody)
 
 		// Setup mock expectation
-		mockAuthService.On("Login", loginBody).Return(nil, "", tc.errType)
+		mockAuthService.On("Login", mock.Anything, loginBody).Return(nil, "", tc.errType)
 
 		// ------------------ Act ----------------------
 		authController.Login(ctx)
@@ -327,11 +340,11 @@ func TestAuthController_Login_InternalServerError(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 		// Parse response body
-		var responseBody map[string]any
+		var responseBody common.ErrorResponse
 		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 		assert.NoError(t, err)
-		assert.Contains(t, responseBody, "error")
-		assert.Contains(t, responseBody["error"], tc.errType.Error())
+		assert.Equal(t, tc.errType.Code, responseBody.Error.Code)
+		assert.Equal(t, tc.errType.Message, responseBody.Error.Message)
 
 		// Verify mock was called
 		mockAuthService.AssertExpectations(t)
@@ -1,17 +1,22 @@
 package auth_unit_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
 	authfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/auth"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 )
 
 // ======================== REGISTER ========================
@@ -20,6 +25,8 @@ func TestAuthService_Register_Success(t *testing.T) {
 	// ------------------ Arrange ------------------
 	mockUserService := new(mocks.MockUserService)
 	authService := &authfx.AuthService{
+		Logger:      zap.NewNop(),
+		Metrics:     metricsfx.NewMetrics(),
 		UserService: mockUserService,
 		AppConfig: &configfx.AppConfig{
 			JWTSecret:    "test-secret",
@@ -34,21 +41,22 @@ func TestAuthService_Register_Success(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Password:  "12345678",
-		SchoolNum: "1",
+		SchoolNum: ptr("1"),
 	}
 
 	// Compute mock registrationToken
 	claims := jwt.MapClaims{
+		"typ":   types.ActionTokenTypeRegistration,
 		"email": "johnsmith@gmail.com",
 	}
-	registrationTokenString, err := common.GenerateJTWToken(claims, "test-secret", 24)
+	registrationTokenString, err := common.GenerateJTWToken(claims, "test-secret", 24*time.Hour)
 	assert.NoError(t, err)
 
 	// Setup mock expectation
-	mockUserService.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+	mockUserService.On("CreateUser", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
 
 	// ------------------ Act ----------------------
-	user, accessToken, err := authService.Register(registrationTokenString, registerBody)
+	user, accessToken, err := authService.Register(context.Background(), registrationTokenString, registerBody)
 
 	// ------------------ Assert -------------------
 	assert.NoError(t, err)
@@ -61,7 +69,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 	assert.Equal(t, "+66912345678", user.Phone)
 	assert.Equal(t, types.UserGenderMale, user.Gender)
 	assert.Equal(t, "johnsmith@gmail.com", user.Email)
-	assert.Equal(t, "1", user.SchoolNum)
+	assert.Equal(t, "1", *user.SchoolNum)
 
 	// Verify mock was called
 	mockUserService.AssertExpectations(t)
@@ -71,6 +79,8 @@ func TestAuthService_Register_DuplicateEmail(t *testing.T) {
 	// ------------------ Arrange ------------------
 	mockUserService := new(mocks.MockUserService)
 	authService := &authfx.AuthService{
+		Logger:      zap.NewNop(),
+		Metrics:     metricsfx.NewMetrics(),
 		UserService: mockUserService,
 		AppConfig: &configfx.AppConfig{
 			JWTSecret: "test-secret",
@@ -84,21 +94,22 @@ func TestAuthService_Register_DuplicateEmail(t *testing.T) {
 		Phone:     "+66912345678",
 		Gender:    types.UserGenderMale,
 		Password:  "12345678",
-		SchoolNum: "1",
+		SchoolNum: ptr("1"),
 	}
 
 	// Compute mock registrationToken
 	claims := jwt.MapClaims{
+		"typ":   types.ActionTokenTypeRegistration,
 		"email": "duplicate@gmail.com",
 	}
-	registrationTokenString, err := common.GenerateJTWToken(claims, "test-secret", 24)
+	registrationTokenString, err := common.GenerateJTWToken(claims, "test-secret", 24*time.Hour)
 	assert.NoError(t, err)
 
 	// Setup mock expectation
-	mockUserService.On("CreateUser", mock.AnythingOfType("*models.User")).Return(common.ErrDuplicatedEmail)
+	mockUserService.On("CreateUser", mock.Anything, mock.AnythingOfType("*models.User")).Return(common.ErrDuplicatedEmail)
 
 	// ------------------ Act ----------------------
-	user, accessToken, err := authService.Register(registrationTokenString, registerBody)
+	user, accessToken, err := authService.Register(context.Background(), registrationTokenString, registerBody)
 
 	// ------------------ Assert -------------------
 	assert.Error(t, err)
@@ -110,6 +121,50 @@ func TestAuthService_Register_DuplicateEmail(t *testing.T) {
 	mockUserService.AssertExpectations(t)
 }
 
+func TestAuthService_Register_RejectsOtherTokenKinds(t *testing.T) {
+	testCases := map[string]jwt.MapClaims{
+		"Reset password": {"typ": types.ActionTokenTypeResetPwd, "email": "johnsmith@gmail.com"},
+		"Invitation": {
+			"typ": types.ActionTokenTypeInvitation, "invitation_id": uuid.New(), "email": "johnsmith@gmail.com",
+		},
+		"Set password": {
+			"typ": types.ActionTokenTypeSetPwd, "email": "johnsmith@gmail.com",
+			"pwd": common.PasswordFingerprint(common.UnusablePassword),
+		},
+		"No kind": {"email": "johnsmith@gmail.com"},
+	}
+
+	for name, claims := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			mockUserService := new(mocks.MockUserService)
+			authService := &authfx.AuthService{
+				Logger:      zap.NewNop(),
+				Metrics:     metricsfx.NewMetrics(),
+				UserService: mockUserService,
+				AppConfig: &configfx.AppConfig{
+					JWTSecret: "test-secret",
+				},
+			}
+
+			tokenString, err := common.GenerateJTWToken(claims, "test-secret", 24*time.Hour)
+			assert.NoError(t, err)
+
+			// ------------------ Act ----------------------
+			user, accessToken, err := authService.Register(context.Background(), tokenString, &authfx.RegisterBody{
+				Role:     types.UserRoleStudent,
+				Password: "12345678",
+			})
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, common.ErrInvalidActionToken, err)
+			assert.Nil(t, user)
+			assert.Empty(t, accessToken)
+			mockUserService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestAuthService_Register_DatabaseError(t *testing.T) {}
 
 // ======================== LOGIN ========================
@@ -117,8 +172,12 @@ func TestAuthService_Register_DatabaseError(t *testing.T) {}
 func TestAuthService_Login_Success(t *testing.T) {
 	// ------------------ Arrange ------------------
 	mockUserService := new(mocks.MockUserService)
+	mockAuditService := new(mocks.MockAuditService)
 	authService := &authfx.AuthService{
-		UserService: mockUserService,
+		Logger:       zap.NewNop(),
+		Metrics:      metricsfx.NewMetrics(),
+		UserService:  mockUserService,
+		AuditService: mockAuditService,
 		AppConfig: &configfx.AppConfig{
 			JWTSecret:    "test-secret",
 			JWTExpiresIn: 24,
@@ -131,15 +190,19 @@ func TestAuthService_Login_Success(t *testing.T) {
 	}
 
 	expectedUser := &models.User{
+		ID:       uuid.New(),
 		Email:    "johnsmith@gmail.com",
 		Password: "$2a$12$20IzYYMVPI2I79ceTEXx6upUNULaygvivZzZyBWIHb0lzJPR8P3iy", // bcrypt hash
 	}
 
 	// Setup mock expectation
-	mockUserService.On("GetUserByEmail", "johnsmith@gmail.com").Return(expectedUser, nil)
+	mockUserService.On("GetUserByEmail", mock.Anything, "johnsmith@gmail.com").Return(expectedUser, nil)
+	mockAuditService.On("Record", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == types.AuditActionLoginSucceeded && *event.ActorID == expectedUser.ID
+	})).Return()
 
 	// ------------------ Act ----------------------
-	user, accessToken, err := authService.Login(loginBody)
+	user, accessToken, err := authService.Login(context.Background(), loginBody)
 
 	// ------------------ Assert -------------------
 	assert.NoError(t, err)
@@ -149,12 +212,58 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 	// Verify the mock was called exactly once
 	mockUserService.AssertExpectations(t)
+	mockAuditService.AssertExpectations(t)
+}
+
+func TestAuthService_Login_EmailInAnyCase(t *testing.T) {
+	// ------------------ Arrange ------------------
+	mockUserService := new(mocks.MockUserService)
+	mockAuditService := new(mocks.MockAuditService)
+	authService := &authfx.AuthService{
+		Logger:       zap.NewNop(),
+		Metrics:      metricsfx.NewMetrics(),
+		UserService:  mockUserService,
+		AuditService: mockAuditService,
+		AppConfig: &configfx.AppConfig{
+			JWTSecret:    "test-secret",
+			JWTExpiresIn: 24,
+		},
+	}
+
+	loginBody := &authfx.LoginBody{
+		Email:    "JohnSmith@Gmail.com",
+		Password: "12345678",
+	}
+
+	expectedUser := &models.User{
+		ID:       uuid.New(),
+		Email:    "johnsmith@gmail.com",
+		Password: "$2a$12$20IzYYMVPI2I79ceTEXx6upUNULaygvivZzZyBWIHb0lzJPR8P3iy", // bcrypt hash
+	}
+
+	// Setup mock expectation, emails are stored lowercased
+	mockUserService.On("GetUserByEmail", mock.Anything, "johnsmith@gmail.com").Return(expectedUser, nil)
+	mockAuditService.On("Record", mock.Anything, mock.Anything).Return()
+
+	// ------------------ Act ----------------------
+	user, _, err := authService.Login(context.Background(), loginBody)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.Equal(t, "johnsmith@gmail.com", user.Email)
+	mockUserService.AssertExpectations(t)
 }
 
 func TestAuthService_Login_EmailNotExist(t *testing.T) {
 	// ------------------ Arrange ------------------
 	mockUserService := new(mocks.MockUserService)
-	authService := &authfx.AuthService{UserService: mockUserService}
+	mockAuditService := new(mocks.MockAuditService)
+	authService := &authfx.AuthService{
+		Logger:       zap.NewNop(),
+		UserService:  mockUserService,
+		AuditService: mockAuditService,
+		Metrics:      metricsfx.NewMetrics(),
+	}
 
 	loginBody := &authfx.LoginBody{
 		Email:    "johnsmith@gmail.com",
@@ -162,10 +271,14 @@ func TestAuthService_Login_EmailNotExist(t *testing.T) {
 	}
 
 	// Setup mock expectation
-	mockUserService.On("GetUserByEmail", "johnsmith@gmail.com").Return(nil, common.ErrUserNotFound)
+	mockUserService.On("GetUserByEmail", mock.Anything, "johnsmith@gmail.com").Return(nil, common.ErrUserNotFound)
+	mockAuditService.On("Record", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == types.AuditActionLoginFailed && event.TargetID == nil &&
+			event.Metadata["reason"] == "unknown_email"
+	})).Return()
 
 	// ------------------ Act ----------------------
-	user, accessToken, err := authService.Login(loginBody)
+	user, accessToken, err := authService.Login(context.Background(), loginBody)
 
 	// ------------------ Assert -------------------
 	assert.Error(t, err)
@@ -175,12 +288,19 @@ func TestAuthService_Login_EmailNotExist(t *testing.T) {
 
 	// Verify the mock was called exactly once
 	mockUserService.AssertExpectations(t)
+	mockAuditService.AssertExpectations(t)
 }
 
 func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	// ------------------ Arrange ------------------
 	mockUserService := new(mocks.MockUserService)
-	authService := &authfx.AuthService{UserService: mockUserService}
+	mockAuditService := new(mocks.MockAuditService)
+	authService := &authfx.AuthService{
+		Logger:       zap.NewNop(),
+		UserService:  mockUserService,
+		AuditService: mockAuditService,
+		Metrics:      metricsfx.NewMetrics(),
+	}
 
 	loginBody := &authfx.LoginBody{
 		Email:    "johnsmith@gmail.com",
@@ -188,15 +308,20 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	}
 
 	expectedUser := &models.User{
+		ID:       uuid.New(),
 		Email:    "johnsmith@gmail.com",
 		Password: "no_matched_pwd",
 	}
 
 	// Setup mock expectation
-	mockUserService.On("GetUserByEmail", "johnsmith@gmail.com").Return(expectedUser, nil)
+	mockUserService.On("GetUserByEmail", mock.Anything, "johnsmith@gmail.com").Return(expectedUser, nil)
+	mockAuditService.On("Record", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == types.AuditActionLoginFailed && *event.TargetID == expectedUser.ID &&
+			event.Metadata["reason"] == "wrong_password"
+	})).Return()
 
 	// ------------------ Act ----------------------
-	user, accessToken, err := authService.Login(loginBody)
+	user, accessToken, err := authService.Login(context.Background(), loginBody)
 
 	// ------------------ Assert -------------------
 	assert.Error(t, err)
@@ -206,6 +331,110 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 
 	// Verify the mock was called exactly once
 	mockUserService.AssertExpectations(t)
+	mockAuditService.AssertExpectations(t)
 }
 
 func TestAuthService_Login_DatabaseError(t *testing.T) {}
+
+// ======================== RESET PASSWORD ========================
+
+func TestAuthService_ResetPwd_SetPwdTokenIsBoundToPassword(t *testing.T) {
+	// ------------------ Arrange ------------------
+	mockUserService := new(mocks.MockUserService)
+	authService := &authfx.AuthService{
+		Logger:      zap.NewNop(),
+		UserService: mockUserService,
+		AppConfig: &configfx.AppConfig{
+			JWTSecret: "test-secret",
+		},
+	}
+
+	pwdFingerprint := common.PasswordFingerprint(common.UnusablePassword)
+	setPwdToken, err := common.GenerateJTWToken(jwt.MapClaims{
+		"typ":   types.ActionTokenTypeSetPwd,
+		"email": "johnsmith@gmail.com",
+		"pwd":   pwdFingerprint,
+	}, "test-secret", 24*time.Hour)
+	assert.NoError(t, err)
+
+	mockUserService.On("SetUserPwdByEmail", mock.Anything, "johnsmith@gmail.com", pwdFingerprint, "12345678").
+		Return(nil)
+
+	// ------------------ Act ----------------------
+	err = authService.ResetPwd(context.Background(), &authfx.ResetPwdBody{
+		ResetPwdToken: setPwdToken,
+		NewPassword:   "12345678",
+	})
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	mockUserService.AssertExpectations(t)
+	mockUserService.AssertNotCalled(t, "UpdateUserPwdByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ResetPwd_SetPwdTokenWithoutFingerprint(t *testing.T) {
+	// ------------------ Arrange ------------------
+	mockUserService := new(mocks.MockUserService)
+	authService := &authfx.AuthService{
+		Logger:      zap.NewNop(),
+		UserService: mockUserService,
+		AppConfig: &configfx.AppConfig{
+			JWTSecret: "test-secret",
+		},
+	}
+
+	setPwdToken, err := common.GenerateJTWToken(jwt.MapClaims{
+		"typ":   types.ActionTokenTypeSetPwd,
+		"email": "johnsmith@gmail.com",
+	}, "test-secret", 24*time.Hour)
+	assert.NoError(t, err)
+
+	// ------------------ Act ----------------------
+	err = authService.ResetPwd(context.Background(), &authfx.ResetPwdBody{
+		ResetPwdToken: setPwdToken,
+		NewPassword:   "12345678",
+	})
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, common.ErrActionTokenClaimsRetrieval, err)
+	mockUserService.AssertNotCalled(t, "SetUserPwdByEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserService.AssertNotCalled(t, "UpdateUserPwdByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ResetPwd_RejectsOtherTokenKinds(t *testing.T) {
+	testCases := map[string]jwt.MapClaims{
+		"Registration": {"typ": types.ActionTokenTypeRegistration, "email": "johnsmith@gmail.com"},
+		"Invitation": {
+			"typ": types.ActionTokenTypeInvitation, "invitation_id": uuid.New(), "email": "johnsmith@gmail.com",
+		},
+		"No kind": {"email": "johnsmith@gmail.com"},
+	}
+
+	for name, claims := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			mockUserService := new(mocks.MockUserService)
+			authService := &authfx.AuthService{
+				Logger:      zap.NewNop(),
+				UserService: mockUserService,
+				AppConfig: &configfx.AppConfig{
+					JWTSecret: "test-secret",
+				},
+			}
+
+			tokenString, err := common.GenerateJTWToken(claims, "test-secret", 24*time.Hour)
+			assert.NoError(t, err)
+
+			// ------------------ Act ----------------------
+			err = authService.ResetPwd(context.Background(), &authfx.ResetPwdBody{
+				ResetPwdToken: tokenString,
+				NewPassword:   "12345678",
+			})
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, common.ErrInvalidActionToken, err)
+			mockUserService.AssertNotCalled(t, "UpdateUserPwdByEmail", mock.Anything, mock.Anything, mock.Anything)
+			mockUserService.AssertNotCalled(t, "SetUserPwdByEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
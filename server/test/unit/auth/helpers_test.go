@@ -0,0 +1,5 @@
+package auth_unit_test
+
+func ptr[T any](v T) *T {
+	return &v
+}
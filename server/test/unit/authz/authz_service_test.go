@@ -0,0 +1,199 @@
+package authz_unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeRelations answers the relationship checks from in-memory pairs
+type fakeRelations struct {
+	classTeachers     map[[2]uuid.UUID]bool // {teacher, class}
+	classSchools      map[uuid.UUID]uuid.UUID
+	userSchools       map[uuid.UUID]uuid.UUID
+	guardians         map[[2]uuid.UUID]bool // {guardian, student}
+	teachers          map[[2]uuid.UUID]bool // {teacher, student}
+	invitationClasses map[uuid.UUID]uuid.UUID
+	homeworkTeachers  map[[2]uuid.UUID]bool // {teacher, homework}
+	homeworkSchools   map[uuid.UUID]uuid.UUID
+	err               error
+}
+
+func (r *fakeRelations) IsClassTeacher(_ context.Context, teacherID uuid.UUID, classID uuid.UUID) (bool, error) {
+	return r.classTeachers[[2]uuid.UUID{teacherID, classID}], r.err
+}
+
+func (r *fakeRelations) IsClassInSchool(_ context.Context, classID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	s, ok := r.classSchools[classID]
+	return ok && s == schoolID, r.err
+}
+
+func (r *fakeRelations) IsUserInSchool(_ context.Context, userID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	s, ok := r.userSchools[userID]
+	return ok && s == schoolID, r.err
+}
+
+func (r *fakeRelations) IsGuardianOf(_ context.Context, guardianID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	return r.guardians[[2]uuid.UUID{guardianID, studentID}], r.err
+}
+
+func (r *fakeRelations) TeachesStudent(_ context.Context, teacherID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	return r.teachers[[2]uuid.UUID{teacherID, studentID}], r.err
+}
+
+func (r *fakeRelations) GetInvitationClassID(_ context.Context, invitationID uuid.UUID) (uuid.UUID, bool, error) {
+	classID, ok := r.invitationClasses[invitationID]
+	return classID, ok, r.err
+}
+
+func (r *fakeRelations) IsHomeworkTeacher(_ context.Context, teacherID uuid.UUID, homeworkID uuid.UUID) (bool, error) {
+	return r.homeworkTeachers[[2]uuid.UUID{teacherID, homeworkID}], r.err
+}
+
+func (r *fakeRelations) IsHomeworkInSchool(_ context.Context, homeworkID uuid.UUID, schoolID uuid.UUID) (bool, error) {
+	s, ok := r.homeworkSchools[homeworkID]
+	return ok && s == schoolID, r.err
+}
+
+func TestAuthzService_Authorize(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schoolA, schoolB := uuid.New(), uuid.New()
+	classA, classB := uuid.New(), uuid.New()
+	invitationA, invitationB := uuid.New(), uuid.New()
+	homeworkA, homeworkB := uuid.New(), uuid.New()
+
+	student := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleStudent, SchoolID: &schoolA}
+	otherStudent := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleStudent, SchoolID: &schoolA}
+	foreignStudent := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleStudent, SchoolID: &schoolB}
+	teacher := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleTeacher, SchoolID: &schoolA}
+	guardian := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleGuardian, SchoolID: &schoolA}
+	schoolAdmin := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleSchoolAdmin, SchoolID: &schoolA}
+	admin := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleAdmin}
+
+	relations := &fakeRelations{
+		classTeachers:     map[[2]uuid.UUID]bool{{teacher.UserID, classA}: true},
+		classSchools:      map[uuid.UUID]uuid.UUID{classA: schoolA, classB: schoolB},
+		userSchools:       map[uuid.UUID]uuid.UUID{student.UserID: schoolA, otherStudent.UserID: schoolA, foreignStudent.UserID: schoolB},
+		guardians:         map[[2]uuid.UUID]bool{{guardian.UserID, student.UserID}: true},
+		teachers:          map[[2]uuid.UUID]bool{{teacher.UserID, student.UserID}: true},
+		invitationClasses: map[uuid.UUID]uuid.UUID{invitationA: classA, invitationB: classB},
+		homeworkTeachers:  map[[2]uuid.UUID]bool{{teacher.UserID, homeworkA}: true},
+		homeworkSchools:   map[uuid.UUID]uuid.UUID{homeworkA: schoolA, homeworkB: schoolB},
+	}
+
+	cases := []struct {
+		name       string
+		subject    *authzfx.Subject
+		permission authzfx.Permission
+		resourceID uuid.UUID
+		expected   error
+	}{
+		// user:read
+		{"student reads self", student, authzfx.PermissionUserRead, student.UserID, nil},
+		{"student reads another student", student, authzfx.PermissionUserRead, otherStudent.UserID, common.ErrPermissionDenied},
+		{"guardian reads linked student", guardian, authzfx.PermissionUserRead, student.UserID, nil},
+		{"guardian reads unlinked student", guardian, authzfx.PermissionUserRead, otherStudent.UserID, common.ErrPermissionDenied},
+		{"teacher reads own student", teacher, authzfx.PermissionUserRead, student.UserID, nil},
+		{"teacher reads student not taught", teacher, authzfx.PermissionUserRead, otherStudent.UserID, common.ErrPermissionDenied},
+		{"school admin reads user of own school", schoolAdmin, authzfx.PermissionUserRead, otherStudent.UserID, nil},
+		{"school admin reads user of another school", schoolAdmin, authzfx.PermissionUserRead, foreignStudent.UserID, common.ErrPermissionDenied},
+		{"admin reads any user", admin, authzfx.PermissionUserRead, foreignStudent.UserID, nil},
+
+		// user:update and user:avatar:upload
+		{"student updates self", student, authzfx.PermissionUserUpdate, student.UserID, nil},
+		{"guardian updates linked student", guardian, authzfx.PermissionUserUpdate, student.UserID, common.ErrPermissionDenied},
+		{"admin updates self", admin, authzfx.PermissionUserUpdate, admin.UserID, common.ErrPermissionDenied},
+		{"teacher uploads own avatar", teacher, authzfx.PermissionUserAvatarUpload, teacher.UserID, nil},
+		{"school admin uploads avatar", schoolAdmin, authzfx.PermissionUserAvatarUpload, schoolAdmin.UserID, common.ErrPermissionDenied},
+
+		// user:homework:read
+		{"student reads own homework", student, authzfx.PermissionUserHomeworkRead, student.UserID, nil},
+		{"guardian reads homework of linked student", guardian, authzfx.PermissionUserHomeworkRead, student.UserID, nil},
+		{"guardian reads homework of unlinked student", guardian, authzfx.PermissionUserHomeworkRead, otherStudent.UserID, common.ErrPermissionDenied},
+		{"teacher reads homework of own student", teacher, authzfx.PermissionUserHomeworkRead, student.UserID, nil},
+
+		// class:manage and class:assignments:edit
+		{"teacher manages own class", teacher, authzfx.PermissionClassManage, classA, nil},
+		{"teacher manages another class", teacher, authzfx.PermissionClassManage, classB, common.ErrPermissionDenied},
+		{"student manages class", student, authzfx.PermissionClassManage, classA, common.ErrPermissionDenied},
+		{"school admin manages class of own school", schoolAdmin, authzfx.PermissionClassManage, classA, nil},
+		{"school admin manages class of another school", schoolAdmin, authzfx.PermissionClassManage, classB, common.ErrPermissionDenied},
+		{"admin manages any class", admin, authzfx.PermissionClassManage, classB, nil},
+		{"teacher edits assignments of own class", teacher, authzfx.PermissionClassAssignmentsEdit, classA, nil},
+		{"guardian edits assignments", guardian, authzfx.PermissionClassAssignmentsEdit, classA, common.ErrPermissionDenied},
+		{"teacher reads load of own class", teacher, authzfx.PermissionClassLoadRead, classA, nil},
+		{"teacher reads load of another class", teacher, authzfx.PermissionClassLoadRead, classB, common.ErrPermissionDenied},
+		{"student reads load of own class", student, authzfx.PermissionClassLoadRead, classA, common.ErrPermissionDenied},
+
+		// invitation:revoke
+		{"teacher revokes invitation of own class", teacher, authzfx.PermissionInvitationRevoke, invitationA, nil},
+		{"teacher revokes invitation of another class", teacher, authzfx.PermissionInvitationRevoke, invitationB, common.ErrPermissionDenied},
+		{"teacher revokes unknown invitation", teacher, authzfx.PermissionInvitationRevoke, uuid.New(), common.ErrPermissionDenied},
+		{"school admin revokes invitation of own school", schoolAdmin, authzfx.PermissionInvitationRevoke, invitationA, nil},
+		{"school admin revokes invitation of another school", schoolAdmin, authzfx.PermissionInvitationRevoke, invitationB, common.ErrPermissionDenied},
+		{"admin revokes any invitation", admin, authzfx.PermissionInvitationRevoke, invitationB, nil},
+
+		// user:role:update
+		{"admin changes the role of any user", admin, authzfx.PermissionUserRoleUpdate, foreignStudent.UserID, nil},
+		{"school admin changes the role of a user of own school", schoolAdmin, authzfx.PermissionUserRoleUpdate, student.UserID, nil},
+		{"school admin changes the role of a user of another school", schoolAdmin, authzfx.PermissionUserRoleUpdate, foreignStudent.UserID, common.ErrPermissionDenied},
+		{"teacher changes the role of own student", teacher, authzfx.PermissionUserRoleUpdate, student.UserID, common.ErrPermissionDenied},
+		{"student changes own role", student, authzfx.PermissionUserRoleUpdate, student.UserID, common.ErrPermissionDenied},
+
+		// homework:grade
+		{"teacher grades own homework", teacher, authzfx.PermissionHomeworkGrade, homeworkA, nil},
+		{"teacher grades homework of another teacher", teacher, authzfx.PermissionHomeworkGrade, homeworkB, common.ErrPermissionDenied},
+		{"admin grades any homework", admin, authzfx.PermissionHomeworkGrade, homeworkB, nil},
+		{"student grades homework", student, authzfx.PermissionHomeworkGrade, homeworkA, common.ErrPermissionDenied},
+
+		// homework:assign
+		{"teacher assigns own homework", teacher, authzfx.PermissionHomeworkAssign, homeworkA, nil},
+		{"teacher assigns homework of another teacher", teacher, authzfx.PermissionHomeworkAssign, homeworkB, common.ErrPermissionDenied},
+		{"school admin assigns homework of own school", schoolAdmin, authzfx.PermissionHomeworkAssign, homeworkA, nil},
+		{"school admin assigns homework of another school", schoolAdmin, authzfx.PermissionHomeworkAssign, homeworkB, common.ErrPermissionDenied},
+		{"admin assigns any homework", admin, authzfx.PermissionHomeworkAssign, homeworkB, nil},
+		{"guardian assigns homework", guardian, authzfx.PermissionHomeworkAssign, homeworkA, common.ErrPermissionDenied},
+
+		// audit:read
+		{"admin reads the audit log", admin, authzfx.PermissionAuditRead, uuid.Nil, nil},
+		{"school admin reads the audit log", schoolAdmin, authzfx.PermissionAuditRead, uuid.Nil, common.ErrPermissionDenied},
+		{"teacher reads the audit log", teacher, authzfx.PermissionAuditRead, uuid.Nil, common.ErrPermissionDenied},
+
+		// Unknown permission
+		{"admin with unknown permission", admin, authzfx.Permission("class:delete"), classA, common.ErrPermissionDenied},
+	}
+
+	service := authzfx.NewAuthzService(authzfx.AuthzServiceParams{Logger: zap.NewNop(), Relations: relations})
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			err := service.Authorize(context.Background(), tc.subject, tc.permission, tc.resourceID)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestAuthzService_Authorize_RelationsError(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schoolID := uuid.New()
+	teacher := &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleTeacher, SchoolID: &schoolID}
+	relations := &fakeRelations{err: errors.New("connection refused")}
+
+	service := authzfx.NewAuthzService(authzfx.AuthzServiceParams{Logger: zap.NewNop(), Relations: relations})
+
+	// ------------------ Act ----------------------
+	err := service.Authorize(context.Background(), teacher, authzfx.PermissionClassManage, uuid.New())
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, common.ErrDatabase, err)
+}
@@ -0,0 +1,102 @@
+package common_unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorContext(accept string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	ctx.Request = ctx.Request.WithContext(logging.WithRequestID(ctx.Request.Context(), "req-1"))
+	if accept != "" {
+		ctx.Request.Header.Set("Accept", accept)
+	}
+	return ctx, w
+}
+
+func TestHandleBusinessLogicErr_Envelope(t *testing.T) {
+	// ------------------ Arrange ------------------
+	ctx, w := newErrorContext("")
+
+	// ------------------ Act ----------------------
+	common.HandleBusinessLogicErr(ctx, fmt.Errorf("getting user: %w", common.ErrUserNotFound))
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.True(t, ctx.IsAborted())
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var responseBody common.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, common.ErrorBody{
+		Code:      "USER_NOT_FOUND",
+		Status:    http.StatusNotFound,
+		Message:   "user not found",
+		RequestID: "req-1",
+	}, responseBody.Error)
+}
+
+func TestHandleBusinessLogicErr_UnknownError(t *testing.T) {
+	// ------------------ Arrange ------------------
+	ctx, w := newErrorContext("")
+
+	// ------------------ Act ----------------------
+	common.HandleBusinessLogicErr(ctx, errors.New("connection reset"))
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var responseBody common.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, common.ErrUnknown.Code, responseBody.Error.Code)
+	assert.NotContains(t, w.Body.String(), "connection reset")
+}
+
+func TestRespondError_ProblemDetails(t *testing.T) {
+	// ------------------ Arrange ------------------
+	ctx, w := newErrorContext("application/problem+json, application/json")
+	details := map[string]string{"limit": "limit must be a positive number"}
+
+	// ------------------ Act ----------------------
+	common.RespondError(ctx, common.ErrValidation.WithDetails(details))
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, common.ContentTypeProblemJSON, w.Header().Get("Content-Type"))
+
+	var responseBody common.ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, common.ProblemDetails{
+		Type:      "about:blank",
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "request validation failed",
+		Instance:  "/api/v1/users/me",
+		Code:      "VALIDATION_FAILED",
+		Errors:    details,
+		RequestID: "req-1",
+	}, responseBody)
+}
+
+func TestCustomError_IsMatchesCode(t *testing.T) {
+	withDetails := common.ErrValidation.WithDetails(map[string]string{"id": "id is not uuid"})
+
+	// ------------------ Act & Assert -------------
+	assert.ErrorIs(t, withDetails, common.ErrValidation)
+	assert.ErrorIs(t, fmt.Errorf("wrapped: %w", withDetails), common.ErrValidation)
+	assert.NotErrorIs(t, withDetails, common.ErrMalformedRequestBody)
+	assert.Empty(t, common.ErrValidation.Details, "WithDetails must not modify the original")
+}
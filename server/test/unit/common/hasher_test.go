@@ -0,0 +1,25 @@
+package common_unit_test
+
+import (
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashPassword_RoundTrip(t *testing.T) {
+	// ------------------ Act ----------------------
+	hashed, err := common.HashPassword("correct horse")
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.NotEqual(t, "correct horse", hashed)
+	assert.True(t, common.CheckHashedPassword("correct horse", hashed))
+	assert.False(t, common.CheckHashedPassword("wrong horse", hashed))
+}
+
+func TestCheckHashedPassword_UnusablePasswordNeverMatches(t *testing.T) {
+	// ------------------ Act & Assert -------------
+	assert.False(t, common.CheckHashedPassword("", common.UnusablePassword))
+	assert.False(t, common.CheckHashedPassword(common.UnusablePassword, common.UnusablePassword))
+}
@@ -0,0 +1,98 @@
+package common_unit_test
+
+import (
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"latin", "John", true},
+		{"thai", "สมชาย", true},
+		{"thai with tone marks", "ณัฐวุฒิ", true},
+		{"thai with sara am", "คำแก้ว", true},
+		{"decomposed accent", "Rene\u0301e", true},
+		{"hyphen", "Jean-Luc", true},
+		{"apostrophe", "O'Neil", true},
+		{"typographic apostrophe", "O’Neil", true},
+		{"abbreviation", "Martin Luther King Jr.", true},
+		{"initial then space", "J. R. R.", true},
+		{"extra spaces", "  Mary   Jane ", true},
+		{"empty", "", false},
+		{"only spaces", "   ", false},
+		{"digit", "J4ne", false},
+		{"leading mark", "ัสมชาย", false},
+		{"leading punctuation", "-Jane", false},
+		{"double punctuation", "Jean--Luc", false},
+		{"trailing hyphen", "Jane-", false},
+		{"emoji", "Jane😀", false},
+		{"underscore", "Jane_Doe", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act & Assert -------------
+			assert.Equal(t, tc.expected, common.IsValidName(tc.input))
+		})
+	}
+}
+
+func TestIsValidRomanizedName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"latin", "Somchai", true},
+		{"hyphen and apostrophe", "Nattawut O'Brien-Smith", true},
+		{"thai", "สมชาย", false},
+		{"accent", "Ren\u00e9e", false},
+		{"digit", "Somchai2", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act & Assert -------------
+			assert.Equal(t, tc.expected, common.IsValidRomanizedName(tc.input))
+		})
+	}
+}
+
+func TestNormalizeName_ComposesToNFC(t *testing.T) {
+	// ------------------ Act ----------------------
+	normalized := common.NormalizeName("  Rene\u0301e   Dupont ")
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "Ren\u00e9e Dupont", normalized)
+}
+
+func TestNormalizeNamePtr_Nil(t *testing.T) {
+	// ------------------ Act & Assert -------------
+	assert.Nil(t, common.NormalizeNamePtr(nil))
+}
+
+func TestRegisterNameValidations(t *testing.T) {
+	// ------------------ Arrange ------------------
+	type body struct {
+		Name          string `validate:"required,name"`
+		RomanizedName string `validate:"omitempty,romanized_name"`
+	}
+	validate := validator.New()
+
+	// ------------------ Act ----------------------
+	err := common.RegisterNameValidations(validate)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.NoError(t, validate.Struct(body{Name: "ณัฐวุฒิ", RomanizedName: "Nattawut"}))
+	assert.Error(t, validate.Struct(body{Name: "J4ne"}))
+	assert.Error(t, validate.Struct(body{Name: "ณัฐวุฒิ", RomanizedName: "ณัฐวุฒิ"}))
+}
@@ -0,0 +1,36 @@
+package config_unit_test
+
+import (
+	"testing"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppConfig_Validate_DigestSendHour(t *testing.T) {
+	testCases := []struct {
+		hour  int
+		valid bool
+	}{
+		{hour: -1, valid: false},
+		{hour: 0, valid: true},
+		{hour: 6, valid: true},
+		{hour: 23, valid: true},
+		{hour: 24, valid: false},
+	}
+
+	for _, tc := range testCases {
+		// ------------------ Arrange ------------------
+		config := &configfx.AppConfig{DigestSendHour: tc.hour}
+
+		// ------------------ Act ----------------------
+		err := config.Validate()
+
+		// ------------------ Assert -------------------
+		if tc.valid {
+			assert.NoError(t, err, "hour %d", tc.hour)
+		} else {
+			assert.Error(t, err, "hour %d", tc.hour)
+		}
+	}
+}
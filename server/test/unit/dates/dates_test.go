@@ -0,0 +1,145 @@
+package dates_unit_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/dates"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return location
+}
+
+func TestDueAt_EndOfTheDayOfTheSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok := mustLoadLocation(t, "Asia/Bangkok")
+	date := dates.Date{Year: 2025, Month: time.March, Day: 4}
+
+	// ------------------ Act ----------------------
+	dueAt := dates.DueAt(date, bangkok)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "2025-03-04T23:59:59+07:00", dueAt.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-04T16:59:59Z", dueAt.UTC().Format(time.RFC3339))
+	assert.Equal(t, date, dates.DateOf(dueAt.In(bangkok)))
+}
+
+func TestDueAt_DaylightSavingTime(t *testing.T) {
+	// ------------------ Arrange ------------------
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	// Clocks moved forward at 2:00 on the 9th
+	before := dates.Date{Year: 2025, Month: time.March, Day: 8}
+	after := dates.Date{Year: 2025, Month: time.March, Day: 9}
+
+	// ------------------ Act ----------------------
+	beforeDueAt := dates.DueAt(before, newYork)
+	afterDueAt := dates.DueAt(after, newYork)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "2025-03-08T23:59:59-05:00", beforeDueAt.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-09T23:59:59-04:00", afterDueAt.Format(time.RFC3339))
+	assert.Equal(t, 23*time.Hour, afterDueAt.Sub(beforeDueAt))
+}
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{"2025-03-04", true},
+		{"2024-02-29", true},
+		{"2025-02-29", false},
+		{"2025-3-4", false},
+		{"2025-03-04T00:00:00Z", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			date, err := dates.ParseDate(tc.input)
+
+			// ------------------ Assert -------------------
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.input, date.String())
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	// ------------------ Arrange ------------------
+	date := dates.Date{Year: 2024, Month: time.February, Day: 28}
+
+	// ------------------ Act ----------------------
+	next := date.AddDays(1)
+	later := date.AddDays(2)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, dates.Date{Year: 2024, Month: time.February, Day: 29}, next)
+	assert.Equal(t, dates.Date{Year: 2024, Month: time.March, Day: 1}, later)
+	assert.Equal(t, 2, date.DaysUntil(later))
+	assert.Equal(t, -2, later.DaysUntil(date))
+	assert.True(t, date.Before(later))
+	assert.True(t, later.After(date))
+	assert.Equal(t, time.Friday, later.Weekday())
+}
+
+func TestDate_JSON(t *testing.T) {
+	// ------------------ Arrange ------------------
+	type body struct {
+		Due dates.Date `json:"due"`
+	}
+
+	// ------------------ Act ----------------------
+	var decoded body
+	err := json.Unmarshal([]byte(`{"due": "2025-03-04"}`), &decoded)
+	encoded, _ := json.Marshal(decoded)
+	invalidErr := json.Unmarshal([]byte(`{"due": "04/03/2025"}`), &decoded)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.Equal(t, dates.Date{Year: 2025, Month: time.March, Day: 4}, decoded.Due)
+	assert.JSONEq(t, `{"due": "2025-03-04"}`, string(encoded))
+	assert.Error(t, invalidErr)
+}
+
+func TestTimestamps_RFC3339WithOffset(t *testing.T) {
+	// ------------------ Arrange ------------------
+	createdAt := time.Date(2025, 3, 4, 9, 30, 0, 0, mustLoadLocation(t, "Asia/Bangkok"))
+	notification := models.Notification{CreatedAt: createdAt, ReadAt: new(time.Time)}
+	*notification.ReadAt = createdAt.UTC()
+
+	// ------------------ Act ----------------------
+	encoded, err := json.Marshal(notification)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"created_at":"2025-03-04T09:30:00+07:00"`)
+	assert.Contains(t, string(encoded), `"read_at":"2025-03-04T02:30:00Z"`)
+}
+
+func TestSchool_Location(t *testing.T) {
+	// ------------------ Arrange ------------------
+	school := &models.School{Timezone: "Asia/Tokyo"}
+	unknown := &models.School{Timezone: "Mars/Olympus_Mons"}
+	unset := &models.School{}
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "Asia/Tokyo", school.Location().String())
+	assert.Equal(t, models.DefaultTimezone, unknown.Location().String())
+	assert.Equal(t, models.DefaultTimezone, unset.Location().String())
+}
@@ -0,0 +1,146 @@
+package digest_unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	digestfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/digest"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+// store answers the queries of the digest service with its guardians, whose
+// students are never found, and keeps the guardians they were looked up for
+type store struct {
+	guardians []*models.User
+	lookedUp  []uuid.UUID
+	claimed   int
+	released  int
+}
+
+// newStoreDB is a dry-run DB answering the statements from the store
+func newStoreDB(t *testing.T, s *store) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	rows := func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *[]*models.User:
+			*dest = s.guardians
+			tx.RowsAffected = int64(len(s.guardians)) // Fewer than a batch, so the last one
+		case *[]*models.StudentGuardian:
+			s.lookedUp = append(s.lookedUp, tx.Statement.Vars[0].(uuid.UUID))
+			tx.AddError(errors.New("connection reset"))
+		}
+	}
+	claim := func(tx *gorm.DB) {
+		s.claimed++
+		tx.RowsAffected = 1
+	}
+	release := func(*gorm.DB) { s.released++ }
+
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:rows", rows))
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:claim", claim))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:release", release))
+
+	return db
+}
+
+// newDigestService sends the digests from 06:00, the guardians having the
+// preferences
+func newDigestService(t *testing.T, s *store, preferences map[uuid.UUID]*models.UserPreferences) *digestfx.DigestService {
+	t.Helper()
+
+	preferencesService := new(mocks.MockPreferencesService)
+	preferencesService.On("GetPreferencesByUserIDs", mock.Anything, mock.Anything).Return(preferences, nil)
+
+	return digestfx.NewDigestService(digestfx.DigestServiceParams{
+		AppConfig: &configfx.AppConfig{DigestDaysAhead: 7, DailyManHoursBudget: 3, DigestSendHour: 6},
+		Logger:    zap.NewNop(),
+		DB:        newStoreDB(t, s),
+		MailService: &mailfx.MailService{
+			FlagConfig: &configfx.FlagConfig{Environment: "development"},
+			Logger:     zap.NewNop(),
+		},
+		PreferencesService: preferencesService,
+	})
+}
+
+func TestDigestService_SendDigests_FailedUserDoesNotStopOthers(t *testing.T) {
+	// ------------------ Arrange ------------------
+	first := &models.User{ID: uuid.New(), Role: types.UserRoleGuardian}
+	second := &models.User{ID: uuid.New(), Role: types.UserRoleGuardian}
+	s := &store{guardians: []*models.User{first, second}}
+	service := newDigestService(t, s, map[uuid.UUID]*models.UserPreferences{
+		first.ID:  models.DefaultUserPreferences(first.ID),
+		second.ID: models.DefaultUserPreferences(second.ID),
+	})
+
+	// ------------------ Act ----------------------
+	err := service.SendDigests(context.Background(), types.DigestFrequencyDaily, now)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first.ID, second.ID}, s.lookedUp)
+	assert.Equal(t, 2, s.claimed)
+	assert.Equal(t, 2, s.released) // Retried on the next run
+}
+
+func TestDigestService_SendDigests_DueInTheTimeZoneOfTheUser(t *testing.T) {
+	quietStart, quietEnd := "15:00", "18:00"
+
+	testCases := []struct {
+		name       string
+		frequency  types.DigestFrequency
+		timezone   string
+		quietHours bool
+		due        bool
+	}{
+		// now is Monday 09:00 UTC
+		{name: "Before the send hour there", frequency: types.DigestFrequencyDaily, timezone: "America/New_York", due: false},
+		{name: "From the send hour there", frequency: types.DigestFrequencyDaily, timezone: "Asia/Bangkok", due: true},
+		{name: "In the quiet hours there", frequency: types.DigestFrequencyDaily, timezone: "Asia/Bangkok", quietHours: true, due: false},
+		{name: "Weekly on a Monday there", frequency: types.DigestFrequencyWeekly, timezone: "Asia/Bangkok", due: true},
+		{name: "Weekly on a Sunday there", frequency: types.DigestFrequencyWeekly, timezone: "Pacific/Honolulu", due: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			guardian := &models.User{ID: uuid.New(), Role: types.UserRoleGuardian}
+			preferences := models.DefaultUserPreferences(guardian.ID)
+			preferences.Timezone = tc.timezone
+			if tc.quietHours {
+				preferences.QuietHoursStart, preferences.QuietHoursEnd = &quietStart, &quietEnd
+			}
+			s := &store{guardians: []*models.User{guardian}}
+			service := newDigestService(t, s, map[uuid.UUID]*models.UserPreferences{guardian.ID: preferences})
+
+			// ------------------ Act ----------------------
+			err := service.SendDigests(context.Background(), tc.frequency, now)
+
+			// ------------------ Assert -------------------
+			require.NoError(t, err)
+			if tc.due {
+				assert.Equal(t, 1, s.claimed)
+			} else {
+				assert.Equal(t, 0, s.claimed)
+			}
+		})
+	}
+}
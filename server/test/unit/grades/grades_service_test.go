@@ -0,0 +1,90 @@
+package grades_unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	gradesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/grades"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// openTx is a transaction that is already open, so that gorm nests every
+// transaction in it as a save point instead of connecting to begin one
+type openTx struct {
+	gorm.ConnPool
+}
+
+func (openTx) Commit() error   { return nil }
+func (openTx) Rollback() error { return nil }
+
+// newGradeDB is a dry-run DB retrieving the grade with the score
+func newGradeDB(t *testing.T, score *float64) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+	db.Statement.ConnPool = openTx{db.Statement.ConnPool}
+
+	retrieve := func(tx *gorm.DB) {
+		if grade, ok := tx.Statement.Dest.(*models.HomeworkStudent); ok {
+			grade.Score = score
+		}
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:retrieve", retrieve))
+
+	return db
+}
+
+func TestGradeService_SetScore(t *testing.T) {
+	before, after := 7.0, 9.5
+
+	testCases := []struct {
+		name     string
+		current  *float64
+		score    *float64
+		recorded bool
+	}{
+		{name: "First score", current: nil, score: &after, recorded: true},
+		{name: "Changed score", current: &before, score: &after, recorded: true},
+		{name: "Cleared score", current: &before, score: nil, recorded: true},
+		{name: "Same score", current: &after, score: &after, recorded: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			actorID, homeworkID, studentID := uuid.New(), uuid.New(), uuid.New()
+			mockAuditService := new(mocks.MockAuditService)
+			mockAuditService.On("RecordTx", mock.Anything, mock.Anything).Return(nil)
+			service := gradesfx.NewGradeService(gradesfx.GradeServiceParams{
+				Logger:       zap.NewNop(),
+				DB:           newGradeDB(t, tc.current),
+				AuditService: mockAuditService,
+			})
+
+			// ------------------ Act ----------------------
+			grade, err := service.SetScore(context.Background(), actorID, homeworkID, studentID, tc.score)
+
+			// ------------------ Assert -------------------
+			require.NoError(t, err)
+			assert.Equal(t, tc.score, grade.Score)
+			if tc.recorded {
+				mockAuditService.AssertCalled(t, "RecordTx", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == types.AuditActionGradeChanged &&
+						*event.ActorID == actorID && *event.TargetID == studentID &&
+						event.Metadata["homework_id"] == homeworkID
+				}))
+			} else {
+				mockAuditService.AssertNotCalled(t, "RecordTx", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package health_unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	healthfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func okCheck(name string) healthfx.Check {
+	return healthfx.Check{
+		Name:    name,
+		Timeout: time.Second,
+		Run:     func(context.Context) error { return nil },
+	}
+}
+
+// blockingCheck only returns once its timeout is over
+func blockingCheck(name string, timeout time.Duration) healthfx.Check {
+	return healthfx.Check{
+		Name:    name,
+		Timeout: timeout,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+}
+
+func newHealthService(checks ...healthfx.Check) *healthfx.HealthService {
+	return &healthfx.HealthService{Logger: zap.NewNop(), Checks: checks}
+}
+
+func TestHealthService_CheckReadiness(t *testing.T) {
+	testCases := []struct {
+		name           string
+		checks         []healthfx.Check
+		expectedStatus healthfx.ReadinessStatus
+		expectedChecks map[string]healthfx.CheckStatus
+	}{
+		{
+			name:           "all checks pass",
+			checks:         []healthfx.Check{okCheck("database"), okCheck("storage")},
+			expectedStatus: healthfx.ReadinessStatusReady,
+			expectedChecks: map[string]healthfx.CheckStatus{
+				"database": healthfx.CheckStatusOK,
+				"storage":  healthfx.CheckStatusOK,
+			},
+		},
+		{
+			name: "a check fails",
+			checks: []healthfx.Check{
+				okCheck("database"),
+				{Name: "storage", Timeout: time.Second, Run: func(context.Context) error {
+					return errors.New("bucket \"avatars\" does not exist")
+				}},
+			},
+			expectedStatus: healthfx.ReadinessStatusUnavailable,
+			expectedChecks: map[string]healthfx.CheckStatus{
+				"database": healthfx.CheckStatusOK,
+				"storage":  healthfx.CheckStatusFailed,
+			},
+		},
+		{
+			name:           "a check times out",
+			checks:         []healthfx.Check{blockingCheck("database", 10*time.Millisecond), okCheck("storage")},
+			expectedStatus: healthfx.ReadinessStatusUnavailable,
+			expectedChecks: map[string]healthfx.CheckStatus{
+				"database": healthfx.CheckStatusTimeout,
+				"storage":  healthfx.CheckStatusOK,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			service := newHealthService(tc.checks...)
+
+			// ------------------ Act ----------------------
+			report := service.CheckReadiness(context.Background())
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tc.expectedChecks))
+			for name, status := range tc.expectedChecks {
+				assert.Equal(t, status, report.Checks[name].Status, name)
+			}
+		})
+	}
+}
+
+func TestHealthService_ChecksHaveTheirOwnTimeout(t *testing.T) {
+	// ------------------ Arrange ------------------
+	service := newHealthService(
+		blockingCheck("database", 10*time.Millisecond),
+		blockingCheck("storage", 50*time.Millisecond),
+	)
+
+	// ------------------ Act ----------------------
+	report := service.CheckReadiness(context.Background())
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, healthfx.CheckStatusTimeout, report.Checks["database"].Status)
+	assert.Equal(t, healthfx.CheckStatusTimeout, report.Checks["storage"].Status)
+	assert.Less(t, report.Checks["database"].DurationMs, int64(50))
+	assert.GreaterOrEqual(t, report.Checks["storage"].DurationMs, int64(50))
+}
+
+func TestHealthService_Draining_SkipsChecks(t *testing.T) {
+	// ------------------ Arrange ------------------
+	ran := false
+	service := newHealthService(healthfx.Check{
+		Name:    "database",
+		Timeout: time.Second,
+		Run: func(context.Context) error {
+			ran = true
+			return nil
+		},
+	})
+
+	// ------------------ Act ----------------------
+	service.StartDraining()
+	report := service.CheckReadiness(context.Background())
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, healthfx.ReadinessStatusDraining, report.Status)
+	assert.False(t, report.Ready())
+	assert.Empty(t, report.Checks)
+	assert.False(t, ran)
+}
+
+func TestHealthController_Readiness(t *testing.T) {
+	testCases := []struct {
+		name           string
+		draining       bool
+		expectedCode   int
+		expectedStatus string
+	}{
+		{name: "ready", expectedCode: http.StatusOK, expectedStatus: "ready"},
+		{name: "draining", draining: true, expectedCode: http.StatusServiceUnavailable, expectedStatus: "draining"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			service := newHealthService(okCheck("database"))
+			if tc.draining {
+				service.StartDraining()
+			}
+			controller := healthfx.NewHealthController(healthfx.HealthControllerParams{
+				Logger:        zap.NewNop(),
+				HealthService: service,
+			})
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			// ------------------ Act ----------------------
+			controller.Readiness(ctx)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expectedCode, w.Code)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedStatus, body["status"])
+		})
+	}
+}
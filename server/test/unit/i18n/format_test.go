@@ -0,0 +1,37 @@
+package i18n_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDate(t *testing.T) {
+	// ------------------ Arrange ------------------
+	date := time.Date(2025, 3, 4, 17, 5, 0, 0, time.UTC)
+
+	// ------------------ Act ----------------------
+	english := i18n.FormatDate("en", date)
+	thai := i18n.FormatDate("th", date)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "Mar 4, 2025", english)
+	assert.Contains(t, thai, "มี.ค.")
+	assert.NotEqual(t, english, thai)
+}
+
+func TestFormatDateTime_InTheGivenZone(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	date := time.Date(2025, 3, 4, 17, 5, 0, 0, time.UTC).In(bangkok)
+
+	// ------------------ Act ----------------------
+	formatted := i18n.FormatDateTime("en", date)
+
+	// ------------------ Assert -------------------
+	assert.Contains(t, formatted, "Mar 5, 2025")
+	assert.Contains(t, formatted, "12:05 AM")
+	assert.Contains(t, formatted, "(+07)")
+}
@@ -0,0 +1,172 @@
+package i18n_unit_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	bootstrapfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/bootstrap"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bindingTags collects the tags of the binding structs, by tag the first
+// struct field using it
+func bindingTags() map[string]string {
+	types := []reflect.Type{reflect.TypeFor[rosterfx.RosterRow]()}
+	for _, operation := range bootstrapfx.Operations() {
+		if operation.Body != nil {
+			types = append(types, reflect.TypeOf(operation.Body))
+		}
+	}
+
+	tags := map[string]string{}
+	seen := map[reflect.Type]bool{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || seen[t] {
+			return
+		}
+		seen[t] = true
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+			for rule := range strings.SplitSeq(field.Tag.Get("binding"), ",") {
+				for alternative := range strings.SplitSeq(rule, "|") {
+					tag, _, _ := strings.Cut(alternative, "=")
+					if _, ok := tags[tag]; tag != "" && !ok {
+						tags[tag] = t.Name() + "." + field.Name
+					}
+				}
+			}
+			walk(field.Type)
+		}
+	}
+	for _, t := range types {
+		walk(t)
+	}
+
+	return tags
+}
+
+func TestBindingTags_HaveValidationMessages(t *testing.T) {
+	// ------------------ Arrange ------------------
+	tags := bindingTags()
+	require.Contains(t, tags, "required", "no binding struct found")
+
+	for _, locale := range i18n.Locales {
+		for tag, field := range tags {
+			// ------------------ Act & Assert -------------
+			assert.True(t, i18n.HasValidationMessage(locale, tag),
+				"no %q message for the tag %q of %s", locale, tag, field)
+		}
+	}
+}
+
+func TestHasValidationMessage_UnsupportedLocale(t *testing.T) {
+	// ------------------ Act & Assert -------------
+	assert.False(t, i18n.HasValidationMessage("fr", "required"))
+	assert.False(t, i18n.HasValidationMessage(i18n.LocaleEnglish, "not_a_tag"))
+}
+
+type testBody struct {
+	Password        string  `json:"password"          binding:"required,min=8"`
+	Age             int     `json:"age"               binding:"min=13"`
+	MiddleName      string  `json:"middle_name"       binding:"omitempty,max=128,len=0|alpha"`
+	Gender          string  `json:"gender"            binding:"oneof='male' 'prefer_not_to_say'"`
+	Token           string  `json:"token"             binding:"required,jwt"`
+	QuietHoursStart *string `json:"quiet_hours_start" binding:"required_with=QuietHoursEnd"`
+	QuietHoursEnd   *string `json:"quiet_hours_end"   binding:"required_with=QuietHoursStart"`
+	Email           string  `json:"email"             binding:"required_without=Phone"`
+	Phone           string  `json:"phone"`
+}
+
+func validationErrors(t *testing.T) map[string]validator.FieldError {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+
+	quietHoursEnd := "07:00"
+	err := validate.Struct(testBody{
+		Password:      "short",
+		Age:           5,
+		MiddleName:    "J4ne",
+		Gender:        "x",
+		Token:         "abc",
+		QuietHoursEnd: &quietHoursEnd,
+	})
+
+	var fieldErrors validator.ValidationErrors
+	require.ErrorAs(t, err, &fieldErrors)
+
+	byField := map[string]validator.FieldError{}
+	for _, fieldError := range fieldErrors {
+		byField[fieldError.Field()] = fieldError
+	}
+	return byField
+}
+
+func TestValidationMessage(t *testing.T) {
+	fieldErrors := validationErrors(t)
+
+	testCases := []struct {
+		locale   string
+		field    string
+		expected string
+	}{
+		{i18n.LocaleEnglish, "password", "password must be at least 8 characters long"},
+		{i18n.LocaleEnglish, "age", "age must be at least 13"},
+		{i18n.LocaleEnglish, "middle_name", "middle_name must contain only letters"},
+		{i18n.LocaleEnglish, "gender", "gender must be one of: male, prefer_not_to_say"},
+		{i18n.LocaleEnglish, "token", "token must be a valid token"},
+		{i18n.LocaleEnglish, "quiet_hours_start", "quiet_hours_start is required when quiet_hours_end is set"},
+		{i18n.LocaleEnglish, "email", "email is required when phone is not set"},
+		{i18n.LocaleThai, "password", "password ต้องมีความยาวอย่างน้อย 8 ตัวอักษร"},
+		{i18n.LocaleThai, "age", "age ต้องมีค่าอย่างน้อย 13"},
+		{i18n.LocaleThai, "middle_name", "middle_name ต้องประกอบด้วยตัวอักษรเท่านั้น"},
+		{i18n.LocaleThai, "gender", "gender ต้องเป็นค่าใดค่าหนึ่งต่อไปนี้: male, prefer_not_to_say"},
+		{i18n.LocaleThai, "quiet_hours_start", "กรุณาระบุ quiet_hours_start เมื่อระบุ quiet_hours_end"},
+		{i18n.LocaleThai, "email", "กรุณาระบุ email เมื่อไม่ได้ระบุ phone"},
+		{"fr", "token", "token must be a valid token"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale+" "+tc.field, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			message := i18n.ValidationMessage(tc.locale, fieldErrors[tc.field])
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, message)
+		})
+	}
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected string
+	}{
+		{"", i18n.LocaleEnglish},
+		{"en-US,en;q=0.9", i18n.LocaleEnglish},
+		{"th-TH,th;q=0.9,en;q=0.8", i18n.LocaleThai},
+		{"th", i18n.LocaleThai},
+		{"fr-FR, th;q=0.5", i18n.LocaleThai},
+		{"fr-FR", i18n.LocaleEnglish},
+		{"not a header", i18n.LocaleEnglish},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			// ------------------ Act & Assert -------------
+			assert.Equal(t, tc.expected, i18n.MatchAcceptLanguage(tc.header))
+		})
+	}
+}
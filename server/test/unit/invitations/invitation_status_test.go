@@ -0,0 +1,58 @@
+package invitations_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationStatus(t *testing.T) {
+	// ------------------ Arrange ------------------
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	cases := []struct {
+		name       string
+		invitation *models.Invitation
+		expected   types.InvitationStatus
+	}{
+		{
+			name:       "pending before expiry",
+			invitation: &models.Invitation{ExpiresAt: now.Add(time.Hour)},
+			expected:   types.InvitationStatusPending,
+		},
+		{
+			name:       "expired at expiry",
+			invitation: &models.Invitation{ExpiresAt: now},
+			expected:   types.InvitationStatusExpired,
+		},
+		{
+			name:       "revoked before expiry",
+			invitation: &models.Invitation{ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier},
+			expected:   types.InvitationStatusRevoked,
+		},
+		{
+			name:       "closed at expiry when invited again stays expired",
+			invitation: &models.Invitation{ExpiresAt: earlier, RevokedAt: &earlier},
+			expected:   types.InvitationStatusExpired,
+		},
+		{
+			name:       "accepted stays accepted after expiry",
+			invitation: &models.Invitation{ExpiresAt: earlier, AcceptedAt: &earlier},
+			expected:   types.InvitationStatusAccepted,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			status := tc.invitation.Status(now)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package invitations_unit_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	invitationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/invitations"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomail "github.com/wneessen/go-mail"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+// openTx is a transaction that is already open, so that gorm nests every
+// transaction in it as a save point instead of connecting to begin one
+type openTx struct {
+	gorm.ConnPool
+}
+
+func (openTx) Commit() error   { return nil }
+func (openTx) Rollback() error { return nil }
+
+// store keeps the open invitation of an email into a class, as the unique
+// index does
+type store struct {
+	open       *models.Invitation
+	created    []*models.Invitation
+	rolledBack bool
+}
+
+// newStoreDB is a dry-run DB answering the statements from the store
+func newStoreDB(t *testing.T, s *store) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+	db.Statement.ConnPool = openTx{db.Statement.ConnPool}
+
+	retrieve := func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *models.Invitation:
+			*dest = *s.open
+		case *models.Class:
+			dest.Name = "M.4/1"
+		}
+	}
+	// Closes the open invitation only if it has expired
+	closeExpired := func(tx *gorm.DB) {
+		if tx.Statement.Table == "invitations" && s.open != nil && !now.Before(s.open.ExpiresAt) {
+			s.open.RevokedAt = &s.open.ExpiresAt
+			s.open = nil
+		}
+	}
+	create := func(tx *gorm.DB) {
+		invitation, ok := tx.Statement.Dest.(*models.Invitation)
+		if !ok {
+			return
+		}
+		if s.open != nil {
+			_ = tx.AddError(errors.New(`duplicate key value violates unique constraint "invitations_open_email_idx" (SQLSTATE 23505)`))
+			return
+		}
+		s.open = invitation
+		s.created = append(s.created, invitation)
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:retrieve", retrieve))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:close_expired", closeExpired))
+	rollback := func(tx *gorm.DB) {
+		if strings.HasPrefix(tx.Statement.SQL.String(), "ROLLBACK TO SAVEPOINT") {
+			s.rolledBack = true
+		}
+	}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:create", create))
+	require.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:rollback", rollback))
+
+	return db
+}
+
+func newInvitationService(t *testing.T, s *store) invitationsfx.InvitationServiceInterface {
+	t.Helper()
+
+	return invitationsfx.NewInvitationService(invitationsfx.InvitationServiceParams{
+		AppConfig:   &configfx.AppConfig{JWTSecret: "test-secret", InvitationExpiresIn: 7},
+		Logger:      zap.NewNop(),
+		DB:          newStoreDB(t, s),
+		MailService: &mailfx.MailService{FlagConfig: &configfx.FlagConfig{}, Logger: zap.NewNop()},
+		Clock:       clock.NewFrozen(now),
+	})
+}
+
+func TestInvitationService_CreateInvitation_InviteAgain(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expiresAt   time.Time
+		expectedErr error
+	}{
+		{name: "Pending", expiresAt: now.Add(time.Hour), expectedErr: common.ErrInvitationAlreadyPending},
+		{name: "At expiry", expiresAt: now, expectedErr: nil},
+		{name: "After expiry", expiresAt: now.Add(-time.Hour), expectedErr: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			previous := &models.Invitation{ID: uuid.New(), Email: "johnsmith@gmail.com", ExpiresAt: tc.expiresAt}
+			s := &store{open: previous}
+			service := newInvitationService(t, s)
+			body := &invitationsfx.CreateInvitationBody{
+				Email:     "JohnSmith@gmail.com",
+				Role:      types.UserRoleStudent,
+				SchoolNum: "12345",
+			}
+
+			// ------------------ Act ----------------------
+			view, err := service.CreateInvitation(context.Background(), uuid.New(), uuid.New(), body)
+
+			// ------------------ Assert -------------------
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, view)
+				assert.Nil(t, previous.RevokedAt)
+				assert.Empty(t, s.created)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, types.InvitationStatusPending, view.Status)
+			assert.Equal(t, now.Add(7*24*time.Hour), view.ExpiresAt)
+			assert.Equal(t, types.InvitationStatusExpired, previous.Status(now))
+			assert.Len(t, s.created, 1)
+		})
+	}
+}
+
+func TestInvitationService_CreateInvitation_MailFailureKeepsNoInvitation(t *testing.T) {
+	// ------------------ Arrange ------------------
+	t.Chdir("../../..") // The templates are read from the server directory
+	s := &store{}
+	db := newStoreDB(t, s)
+
+	// An SMTP server that refuses the connection
+	mailClient, err := gomail.NewClient("127.0.0.1", gomail.WithPort(1), gomail.WithTLSPolicy(gomail.NoTLS))
+	require.NoError(t, err)
+	appConfig := &configfx.AppConfig{
+		JWTSecret:           "test-secret",
+		InvitationExpiresIn: 7,
+		ClientURL:           "https://tgs.example.com",
+		MailSenderName:      "Touch-Grass-Scheduler",
+		MailSenderAddress:   "noreply@tgs.example.com",
+	}
+	service := invitationsfx.NewInvitationService(invitationsfx.InvitationServiceParams{
+		AppConfig: appConfig,
+		Logger:    zap.NewNop(),
+		DB:        db,
+		MailService: mailfx.NewMailService(mailfx.MailServiceParams{
+			FlagConfig:     &configfx.FlagConfig{Environment: "production"},
+			AppConfig:      appConfig,
+			Logger:         zap.NewNop(),
+			DB:             db,
+			MailClient:     mailClient,
+			Metrics:        metricsfx.NewMetrics(),
+			TracerProvider: noop.NewTracerProvider(),
+			Clock:          clock.NewFrozen(now),
+		}),
+		Clock: clock.NewFrozen(now),
+	})
+	body := &invitationsfx.CreateInvitationBody{
+		Email:     "johnsmith@gmail.com",
+		Role:      types.UserRoleStudent,
+		SchoolNum: "12345",
+	}
+
+	// ------------------ Act ----------------------
+	view, err := service.CreateInvitation(context.Background(), uuid.New(), uuid.New(), body)
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, common.ErrMailSending)
+	assert.Nil(t, view)
+	assert.True(t, s.rolledBack)
+}
+
+func TestInvitationService_GetInvitationByToken_TokenKinds(t *testing.T) {
+	testCases := map[string]struct {
+		claims      jwt.MapClaims
+		expectedErr error
+	}{
+		"Invitation": {
+			claims:      jwt.MapClaims{"typ": types.ActionTokenTypeInvitation, "invitation_id": uuid.New()},
+			expectedErr: nil,
+		},
+		"Registration": {
+			claims:      jwt.MapClaims{"typ": types.ActionTokenTypeRegistration, "invitation_id": uuid.New()},
+			expectedErr: common.ErrInvalidActionToken,
+		},
+		"Reset password": {
+			claims:      jwt.MapClaims{"typ": types.ActionTokenTypeResetPwd, "invitation_id": uuid.New()},
+			expectedErr: common.ErrInvalidActionToken,
+		},
+		"Set password": {
+			claims:      jwt.MapClaims{"typ": types.ActionTokenTypeSetPwd, "invitation_id": uuid.New()},
+			expectedErr: common.ErrInvalidActionToken,
+		},
+		"No kind": {
+			claims:      jwt.MapClaims{"invitation_id": uuid.New()},
+			expectedErr: common.ErrInvalidActionToken,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			service := newInvitationService(t, &store{
+				open: &models.Invitation{Email: "johnsmith@gmail.com", ExpiresAt: now.Add(time.Hour)},
+			})
+
+			tokenString, err := common.GenerateJTWToken(tc.claims, "test-secret", 24*time.Hour)
+			require.NoError(t, err)
+
+			// ------------------ Act ----------------------
+			preview, err := service.GetInvitationByToken(context.Background(), tokenString)
+
+			// ------------------ Assert -------------------
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, preview)
+				return
+			}
+			assert.NoError(t, err)
+			require.NotNil(t, preview)
+			assert.Equal(t, "johnsmith@gmail.com", preview.Email)
+			assert.Equal(t, "M.4/1", preview.ClassName)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package jobs_unit_test
+
+import (
+	"testing"
+	"time"
+
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule_DailyAtMidnight(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schedule, err := jobsfx.ParseSchedule("0 0 * * *")
+	assert.NoError(t, err)
+	now := time.Date(2026, 10, 19, 13, 45, 0, 0, time.UTC)
+
+	// ------------------ Act ----------------------
+	next := schedule.Next(now)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), next)
+	assert.Equal(t, time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), schedule.Next(next))
+}
+
+func TestParseSchedule_ListsRangesAndSteps(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schedule, err := jobsfx.ParseSchedule("*/15 9-10 * * 1-5")
+	assert.NoError(t, err)
+
+	// Saturday
+	now := time.Date(2026, 10, 24, 9, 0, 0, 0, time.UTC)
+
+	// ------------------ Act ----------------------
+	next := schedule.Next(now)
+
+	// ------------------ Assert -------------------
+	// Next weekday is Monday
+	assert.Equal(t, time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC), next)
+	assert.Equal(t, time.Date(2026, 10, 26, 9, 15, 0, 0, time.UTC), schedule.Next(next))
+	assert.Equal(t,
+		time.Date(2026, 10, 27, 9, 0, 0, 0, time.UTC),
+		schedule.Next(time.Date(2026, 10, 26, 10, 45, 0, 0, time.UTC)),
+	)
+}
+
+func TestParseSchedule_DayOfMonthOrDayOfWeek(t *testing.T) {
+	// ------------------ Arrange ------------------
+	// The 1st of the month or any Sunday (7 is Sunday too)
+	schedule, err := jobsfx.ParseSchedule("30 6 1 * 7")
+	assert.NoError(t, err)
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC) // Monday
+
+	// ------------------ Act ----------------------
+	next := schedule.Next(now)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, time.Date(2026, 10, 25, 6, 30, 0, 0, time.UTC), next)
+	assert.Equal(t, time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC), schedule.Next(next))
+}
+
+func TestParseSchedule_StepDayOfMonthAndDayOfWeek(t *testing.T) {
+	// ------------------ Arrange ------------------
+	// '*/2' does not restrict the day of month, so both day fields must match:
+	// the Mondays on odd days of the month
+	schedule, err := jobsfx.ParseSchedule("0 0 */2 * 1")
+	assert.NoError(t, err)
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC) // Monday
+
+	// ------------------ Act ----------------------
+	next := schedule.Next(now)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC), next)
+	assert.Equal(t, time.Date(2026, 11, 23, 0, 0, 0, 0, time.UTC), schedule.Next(next))
+}
+
+func TestParseSchedule_Every(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schedule, err := jobsfx.ParseSchedule("@every 5m")
+	assert.NoError(t, err)
+	now := time.Date(2026, 10, 19, 13, 45, 12, 500, time.UTC)
+
+	// ------------------ Act ----------------------
+	next := schedule.Next(now)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, time.Date(2026, 10, 19, 13, 50, 12, 0, time.UTC), next)
+}
+
+func TestParseSchedule_ImpossibleDateNeverRuns(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schedule, err := jobsfx.ParseSchedule("0 0 30 2 *")
+	assert.NoError(t, err)
+
+	// ------------------ Act & Assert -------------
+	assert.True(t, schedule.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
+
+func TestParseSchedule_InvalidSpecs(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 10ms",
+		"@every soon",
+	}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			_, err := jobsfx.ParseSchedule(spec)
+
+			// ------------------ Assert -------------------
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package jobs_unit_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// lockServer holds the session-level advisory locks of a Postgres shared by
+// the replicas, each session being a connection
+type lockServer struct {
+	mu         sync.Mutex
+	owners     map[int64]*lockConn
+	failUnlock bool // pg_advisory_unlock fails, as on a timeout
+}
+
+func newLockServer() *lockServer {
+	return &lockServer{owners: map[int64]*lockConn{}}
+}
+
+// held is the number of advisory locks taken
+func (server *lockServer) held() int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return len(server.owners)
+}
+
+// breakUnlock makes every pg_advisory_unlock fail from now on
+func (server *lockServer) breakUnlock() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.failUnlock = true
+}
+
+// terminate ends the sessions holding a lock, as a restart of Postgres would
+func (server *lockServer) terminate() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for key, conn := range server.owners {
+		conn.terminated = true
+		delete(server.owners, key)
+	}
+}
+
+// open returns the connection pool of a replica
+func (server *lockServer) open(t *testing.T) *sql.DB {
+	t.Helper()
+
+	sqlDB := sql.OpenDB(lockConnector{server: server})
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+type lockConnector struct {
+	server *lockServer
+}
+
+func (connector lockConnector) Connect(context.Context) (driver.Conn, error) {
+	return &lockConn{server: connector.server}, nil
+}
+
+func (connector lockConnector) Driver() driver.Driver { return lockDriver{} }
+
+type lockDriver struct{}
+
+func (lockDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("open through the connector")
+}
+
+// lockConn only answers pg_try_advisory_lock and pg_advisory_unlock
+type lockConn struct {
+	server     *lockServer
+	terminated bool // Guarded by the server
+}
+
+func (conn *lockConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (conn *lockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (conn *lockConn) Close() error {
+	conn.server.mu.Lock()
+	defer conn.server.mu.Unlock()
+	for key, owner := range conn.server.owners {
+		if owner == conn {
+			delete(conn.server.owners, key)
+		}
+	}
+	return nil
+}
+
+func (conn *lockConn) Ping(context.Context) error {
+	conn.server.mu.Lock()
+	defer conn.server.mu.Unlock()
+	if conn.terminated {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (conn *lockConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "pg_try_advisory_lock") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+
+	conn.server.mu.Lock()
+	defer conn.server.mu.Unlock()
+	if conn.terminated {
+		return nil, driver.ErrBadConn
+	}
+
+	key := args[0].Value.(int64)
+	owner, taken := conn.server.owners[key]
+	if !taken {
+		conn.server.owners[key] = conn
+	}
+	return &boolRows{value: !taken || owner == conn}, nil
+}
+
+func (conn *lockConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if !strings.Contains(query, "pg_advisory_unlock") {
+		return nil, errors.New("unexpected statement: " + query)
+	}
+
+	conn.server.mu.Lock()
+	defer conn.server.mu.Unlock()
+	if conn.server.failUnlock {
+		return nil, errors.New("canceling statement due to statement timeout")
+	}
+	key := args[0].Value.(int64)
+	if conn.server.owners[key] == conn {
+		delete(conn.server.owners, key)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// boolRows is the single boolean row of a SELECT
+type boolRows struct {
+	value bool
+	read  bool
+}
+
+func (rows *boolRows) Columns() []string { return []string{"locked"} }
+func (rows *boolRows) Close() error      { return nil }
+
+func (rows *boolRows) Next(dest []driver.Value) error {
+	if rows.read {
+		return io.EOF
+	}
+	rows.read = true
+	dest[0] = rows.value
+	return nil
+}
+
+// runs records the job runs saved by a replica
+type runs struct {
+	mu       sync.Mutex
+	created  []*models.JobRun
+	finished []map[string]any
+}
+
+func (r *runs) count() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.created), len(r.finished)
+}
+
+// newReplicaDB builds the statements of the job runs without a database,
+// taking the advisory locks on the server
+func newReplicaDB(t *testing.T, server *lockServer, r *runs) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDBOn(t, server.open(t))
+
+	create := func(tx *gorm.DB) {
+		if run, ok := tx.Statement.Dest.(*models.JobRun); ok {
+			run.ID = uuid.New()
+			r.mu.Lock()
+			r.created = append(r.created, run)
+			r.mu.Unlock()
+		}
+	}
+	update := func(tx *gorm.DB) {
+		if updates, ok := tx.Statement.Dest.(map[string]any); ok {
+			r.mu.Lock()
+			r.finished = append(r.finished, updates)
+			r.mu.Unlock()
+		}
+	}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:create", create))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:update", update))
+
+	return db
+}
+
+// blockingJob runs until released, counting its runs
+type blockingJob struct {
+	name     string
+	schedule string
+	started  atomic.Int32
+	release  chan struct{}
+	err      error
+}
+
+var _ jobsfx.Job = (*blockingJob)(nil)
+
+func newBlockingJob(name string, schedule string) *blockingJob {
+	return &blockingJob{name: name, schedule: schedule, release: make(chan struct{})}
+}
+
+func (job *blockingJob) Name() string     { return job.name }
+func (job *blockingJob) Schedule() string { return job.schedule }
+
+func (job *blockingJob) Run(ctx context.Context) error {
+	job.started.Add(1)
+	select {
+	case <-job.release:
+	case <-ctx.Done():
+	}
+	return job.err
+}
+
+// eventually waits for condition, the runs being started in goroutines
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+	require.Eventually(t, condition, time.Second, 5*time.Millisecond)
+}
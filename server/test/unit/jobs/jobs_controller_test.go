@@ -0,0 +1,173 @@
+package jobs_unit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newJobsController(jobService *mocks.MockJobService) *jobsfx.JobsController {
+	gin.SetMode(gin.TestMode)
+
+	return jobsfx.NewJobsController(jobsfx.JobsControllerParams{
+		Logger:     zap.NewNop(),
+		JobService: jobService,
+	})
+}
+
+// errorCode reads the code of the error envelope
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var response common.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Error.Code
+}
+
+func TestJobsController_TriggerJob(t *testing.T) {
+	adminID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		userID         string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Started", userID: adminID.String(), expectedStatus: http.StatusAccepted},
+		{
+			name:           "Unknown job",
+			userID:         adminID.String(),
+			serviceErr:     common.ErrJobNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   common.ErrJobNotFound.Code,
+		},
+		{
+			name:           "Already running",
+			userID:         adminID.String(),
+			serviceErr:     common.ErrJobAlreadyRunning,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   common.ErrJobAlreadyRunning.Code,
+		},
+		{
+			name:           "Without user",
+			userID:         "",
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   common.ErrInternal.Code,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			jobService := new(mocks.MockJobService)
+			controller := newJobsController(jobService)
+
+			run := &models.JobRun{
+				ID:          uuid.New(),
+				JobName:     "pending-uploads-cleanup",
+				Trigger:     types.JobTriggerManual,
+				TriggeredBy: &adminID,
+				Status:      types.JobRunStatusRunning,
+				StartedAt:   now,
+			}
+			if tc.serviceErr != nil {
+				jobService.On("TriggerJob", mock.Anything, "pending-uploads-cleanup", adminID).Return(nil, tc.serviceErr)
+			} else {
+				jobService.On("TriggerJob", mock.Anything, "pending-uploads-cleanup", adminID).Return(run, nil)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+			ctx.Params = gin.Params{{Key: "name", Value: "pending-uploads-cleanup"}}
+			ctx.Set("user_id", tc.userID)
+
+			// ------------------ Act ----------------------
+			controller.TriggerJob(ctx)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errorCode(t, w))
+				return
+			}
+
+			var responseBody struct {
+				JobRun *models.JobRun `json:"job_run"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+			assert.Equal(t, run.ID, responseBody.JobRun.ID)
+			assert.Equal(t, &adminID, responseBody.JobRun.TriggeredBy)
+			jobService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobsController_GetJobRuns(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedJob    string
+		expectedLimit  int
+		expectedStatus int
+	}{
+		{name: "Defaults", query: "", expectedJob: "", expectedLimit: 0, expectedStatus: http.StatusOK},
+		{
+			name:           "Of a job",
+			query:          "?job=pending-uploads-cleanup&limit=10",
+			expectedJob:    "pending-uploads-cleanup",
+			expectedLimit:  10,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "Negative limit", query: "?limit=-1", expectedStatus: http.StatusBadRequest},
+		{name: "Invalid limit", query: "?limit=ten", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			jobService := new(mocks.MockJobService)
+			controller := newJobsController(jobService)
+
+			runs := []*models.JobRun{{ID: uuid.New(), JobName: "pending-uploads-cleanup"}}
+			jobService.On("GetJobRuns", mock.Anything, tc.expectedJob, tc.expectedLimit).Return(runs, nil)
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
+
+			// ------------------ Act ----------------------
+			controller.GetJobRuns(ctx)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus != http.StatusOK {
+				assert.Equal(t, common.ErrValidation.Code, errorCode(t, w))
+				jobService.AssertNotCalled(t, "GetJobRuns", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			var responseBody struct {
+				JobRuns []*models.JobRun `json:"job_runs"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+			require.Len(t, responseBody.JobRuns, 1)
+			assert.Equal(t, runs[0].ID, responseBody.JobRuns[0].ID)
+			jobService.AssertExpectations(t)
+		})
+	}
+}
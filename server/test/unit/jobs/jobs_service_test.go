@@ -0,0 +1,189 @@
+package jobs_unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+// replica is one server of the deployment, sharing the advisory locks
+type replica struct {
+	service *jobsfx.JobService
+	job     *blockingJob
+	runs    *runs
+}
+
+func newReplica(t *testing.T, server *lockServer, frozen *clock.Frozen) *replica {
+	t.Helper()
+
+	r := &replica{job: newBlockingJob("cleanup", "@every 1h"), runs: &runs{}}
+	service, err := jobsfx.NewJobService(jobsfx.JobServiceParams{
+		Logger: zap.NewNop(),
+		DB:     newReplicaDB(t, server, r.runs),
+		Clock:  frozen,
+		Jobs:   []jobsfx.Job{r.job},
+	})
+	require.NoError(t, err)
+	r.service = service
+
+	return r
+}
+
+func TestJobService_Tick_OnlyTheLeaderRunsScheduledJobs(t *testing.T) {
+	// ------------------ Arrange ------------------
+	server := newLockServer()
+	frozen := clock.NewFrozen(now)
+	first := newReplica(t, server, frozen)
+	second := newReplica(t, server, frozen)
+	close(second.job.release) // Never blocks, should it run
+	ctx := context.Background()
+	first.service.Tick(ctx)
+	second.service.Tick(ctx)
+	frozen.Advance(time.Hour)
+
+	// ------------------ Act ----------------------
+	first.service.Tick(ctx)
+	eventually(t, func() bool { return first.job.started.Load() == 1 })
+	held := server.held()
+	close(first.job.release)
+	eventually(t, func() bool { _, finished := first.runs.count(); return finished == 1 })
+
+	second.service.Tick(ctx)
+	second.service.Stop() // Waits for the runs it started
+	first.service.Stop()
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, 2, held) // The leadership and the job
+	assert.Equal(t, int32(0), second.job.started.Load())
+	assert.Equal(t, 0, server.held())
+	assert.Equal(t, types.JobTriggerSchedule, first.runs.created[0].Trigger)
+	assert.Equal(t, types.JobRunStatusSucceeded, first.runs.finished[0]["status"])
+}
+
+func TestJobService_Tick_FollowerTakesOverTheLeadership(t *testing.T) {
+	testCases := []struct {
+		name    string
+		leaveBy func(leader *replica, server *lockServer)
+	}{
+		{
+			name:    "Leader stopped",
+			leaveBy: func(leader *replica, _ *lockServer) { leader.service.Stop() },
+		},
+		{
+			name:    "Leader session terminated",
+			leaveBy: func(_ *replica, server *lockServer) { server.terminate() },
+		},
+		{
+			name: "Leader stopped without unlocking",
+			leaveBy: func(leader *replica, server *lockServer) {
+				server.breakUnlock()
+				leader.service.Stop()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			server := newLockServer()
+			frozen := clock.NewFrozen(now)
+			leader := newReplica(t, server, frozen)
+			follower := newReplica(t, server, frozen)
+			ctx := context.Background()
+			leader.service.Tick(ctx)
+			follower.service.Tick(ctx)
+			tc.leaveBy(leader, server)
+
+			// ------------------ Act ----------------------
+			frozen.Advance(time.Hour)
+			follower.service.Tick(ctx)
+			leader.service.Tick(ctx)
+
+			// ------------------ Assert -------------------
+			eventually(t, func() bool { return follower.job.started.Load() == 1 })
+			assert.Equal(t, int32(0), leader.job.started.Load())
+
+			close(follower.job.release)
+			follower.service.Stop()
+			leader.service.Stop()
+			assert.Equal(t, 0, server.held())
+		})
+	}
+}
+
+func TestJobService_Tick_NextRunFromTheClock(t *testing.T) {
+	// ------------------ Arrange ------------------
+	frozen := clock.NewFrozen(now)
+	r := newReplica(t, newLockServer(), frozen)
+
+	// ------------------ Act ----------------------
+	wakeAt := r.service.Tick(context.Background())
+
+	// ------------------ Assert -------------------
+	jobs := r.service.GetJobs()
+	require.Len(t, jobs, 1)
+	assert.True(t, now.Add(time.Hour).Equal(jobs[0].NextRun))
+	assert.True(t, now.Add(30*time.Second).Equal(wakeAt)) // Checks the leadership before
+	assert.Equal(t, int32(0), r.job.started.Load())
+	r.service.Stop()
+}
+
+func TestJobService_TriggerJob_NeverOverlapsAcrossReplicas(t *testing.T) {
+	// ------------------ Arrange ------------------
+	server := newLockServer()
+	frozen := clock.NewFrozen(now)
+	first := newReplica(t, server, frozen)
+	second := newReplica(t, server, frozen)
+	first.job.err = errors.New("storage unavailable")
+	adminID := uuid.New()
+	ctx := context.Background()
+
+	// ------------------ Act ----------------------
+	run, err := first.service.TriggerJob(ctx, "cleanup", adminID)
+	eventually(t, func() bool { return first.job.started.Load() == 1 })
+	_, againErr := first.service.TriggerJob(ctx, "cleanup", adminID)
+	_, otherErr := second.service.TriggerJob(ctx, "cleanup", adminID)
+
+	close(first.job.release)
+	first.service.Stop()
+	_, afterErr := second.service.TriggerJob(ctx, "cleanup", adminID)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Equal(t, types.JobTriggerManual, run.Trigger)
+	assert.Equal(t, &adminID, run.TriggeredBy)
+	assert.Equal(t, now, run.StartedAt)
+	assert.Equal(t, common.ErrJobAlreadyRunning, againErr)
+	assert.Equal(t, common.ErrJobAlreadyRunning, otherErr)
+	assert.NoError(t, afterErr)
+	assert.Equal(t, types.JobRunStatusFailed, first.runs.finished[0]["status"])
+	assert.Equal(t, "storage unavailable", first.runs.finished[0]["error"])
+
+	close(second.job.release)
+	second.service.Stop()
+	assert.Equal(t, 0, server.held())
+}
+
+func TestJobService_TriggerJob_UnknownJob(t *testing.T) {
+	// ------------------ Arrange ------------------
+	r := newReplica(t, newLockServer(), clock.NewFrozen(now))
+
+	// ------------------ Act ----------------------
+	run, err := r.service.TriggerJob(context.Background(), "unknown", uuid.New())
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, common.ErrJobNotFound, err)
+	assert.Nil(t, run)
+}
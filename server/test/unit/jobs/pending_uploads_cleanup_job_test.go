@@ -0,0 +1,205 @@
+package jobs_unit_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const bucketName = "tgs"
+
+// bucket serves the listing and the deletion of its objects, by key, like S3
+type bucket struct {
+	mu        sync.Mutex
+	objects   map[string]time.Time // Last modified
+	removed   []string
+	forbidden bool // Denies the deletions
+}
+
+func (b *bucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/"+bucketName+"/")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		type content struct {
+			Key          string
+			LastModified string
+			ETag         string
+			Size         int64
+		}
+		listing := struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			Name        string
+			Prefix      string
+			IsTruncated bool
+			Contents    []content
+		}{Name: bucketName, Prefix: r.URL.Query().Get("prefix")}
+
+		keys := []string{}
+		for objectKey := range b.objects {
+			if strings.HasPrefix(objectKey, listing.Prefix) {
+				keys = append(keys, objectKey)
+			}
+		}
+		slices.Sort(keys)
+		for _, objectKey := range keys {
+			listing.Contents = append(listing.Contents, content{
+				Key:          objectKey,
+				LastModified: b.objects[objectKey].Format(time.RFC3339),
+				ETag:         `"etag"`,
+				Size:         1,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(listing)
+	case r.Method == http.MethodDelete && b.forbidden:
+		b.respondError(w, http.StatusForbidden, "AccessDenied")
+	case r.Method == http.MethodDelete:
+		if _, ok := b.objects[key]; !ok {
+			b.respondError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		delete(b.objects, key)
+		b.removed = append(b.removed, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		b.respondError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (b *bucket) respondError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
+
+// uploads answers the queries of the cleanup job, whatever their conditions
+type uploads struct {
+	expired    []*models.PendingUpload // Rows past their expiry, until deleted
+	referenced map[string]bool         // Object keys of the live rows and the avatars in use
+	deleted    []string                // Object keys of the deleted rows
+}
+
+func newUploadsDB(t *testing.T, u *uploads) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	query := func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *[]*models.PendingUpload:
+			*dest = slices.Clone(u.expired)
+		case *int64:
+			if u.referenced[tx.Statement.Vars[0].(string)] {
+				*dest = 1
+				tx.RowsAffected = 1 // The row of the count
+			}
+		}
+	}
+	remove := func(tx *gorm.DB) {
+		objectKey := tx.Statement.Vars[0].(string)
+		u.deleted = append(u.deleted, objectKey)
+		u.expired = slices.DeleteFunc(u.expired, func(upload *models.PendingUpload) bool {
+			return upload.ObjectKey == objectKey
+		})
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:query", query))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:remove", remove))
+
+	return db
+}
+
+func newCleanupJob(t *testing.T, b *bucket, u *uploads) *jobsfx.PendingUploadsCleanupJob {
+	t.Helper()
+
+	server := httptest.NewServer(b)
+	t.Cleanup(server.Close)
+
+	storageClient, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access-key", "secret-key", ""),
+		Region: "us-east-1", // Never asks for the location of the bucket
+	})
+	require.NoError(t, err)
+
+	return jobsfx.NewPendingUploadsCleanupJob(jobsfx.PendingUploadsCleanupJobParams{
+		AppConfig:     &configfx.AppConfig{StorageBucketName: bucketName},
+		Logger:        zap.NewNop(),
+		DB:            newUploadsDB(t, u),
+		StorageClient: storageClient,
+		Clock:         clock.NewFrozen(now),
+	})
+}
+
+func TestPendingUploadsCleanupJob_Run(t *testing.T) {
+	// ------------------ Arrange ------------------
+	old := now.Add(-2 * time.Hour)
+	b := &bucket{objects: map[string]time.Time{
+		"pending/expired":      old,
+		"pending/live":         old,
+		"pending/orphan":       old,
+		"pending/uploading":    now.Add(-10 * time.Minute),
+		"avatars/in-use.png":   old,
+		"avatars/replaced.png": old,
+	}}
+	u := &uploads{
+		expired: []*models.PendingUpload{
+			{ObjectKey: "expired", UserID: uuid.New()},
+			{ObjectKey: "never-uploaded", UserID: uuid.New()},
+		},
+		referenced: map[string]bool{"live": true, "avatars/in-use.png": true},
+	}
+	job := newCleanupJob(t, b, u)
+
+	// ------------------ Act ----------------------
+	err := job.Run(context.Background())
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Equal(t, []string{"expired", "never-uploaded"}, u.deleted)
+	assert.Equal(t, []string{"pending/expired", "pending/orphan", "avatars/replaced.png"}, b.removed)
+	assert.Contains(t, b.objects, "pending/live")
+	assert.Contains(t, b.objects, "pending/uploading") // Within the grace period
+	assert.Contains(t, b.objects, "avatars/in-use.png")
+}
+
+func TestPendingUploadsCleanupJob_Run_KeepsTheRowWhenTheObjectRemains(t *testing.T) {
+	// ------------------ Arrange ------------------
+	b := &bucket{objects: map[string]time.Time{"pending/expired": now.Add(-2 * time.Hour)}, forbidden: true}
+	u := &uploads{expired: []*models.PendingUpload{{ObjectKey: "expired", UserID: uuid.New()}}}
+	job := newCleanupJob(t, b, u)
+
+	// ------------------ Act ----------------------
+	err := job.Run(context.Background())
+
+	// ------------------ Assert -------------------
+	assert.ErrorContains(t, err, `remove object "pending/expired"`)
+	assert.Empty(t, u.deleted) // Retried on the next run
+	assert.Contains(t, b.objects, "pending/expired")
+}
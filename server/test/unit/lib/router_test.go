@@ -0,0 +1,155 @@
+package lib_unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	libfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/lib"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObservedRouter returns a router with a route logging through the request
+// scoped logger, and the logs written
+func newObservedRouter() (*gin.Engine, *observer.ObservedLogs) {
+	router, logs, _ := newInstrumentedRouter()
+	return router, logs
+}
+
+// newInstrumentedRouter is newObservedRouter with its metrics
+func newInstrumentedRouter() (*gin.Engine, *observer.ObservedLogs, *metricsfx.Metrics) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	metrics := metricsfx.NewMetrics()
+	router := libfx.NewRouter(libfx.RouterParam{
+		AppConfig:      &configfx.AppConfig{},
+		FlagConfig:     &configfx.FlagConfig{Environment: "test"},
+		Logger:         zap.New(core),
+		Metrics:        metrics,
+		TracerProvider: noop.NewTracerProvider(),
+	})
+
+	router.GET("/api/v1/things/:id", func(ctx *gin.Context) {
+		logging.FromContext(ctx.Request.Context(), nil).Info("Service log")
+		ctx.Status(http.StatusNoContent)
+	})
+
+	return router, logs, metrics
+}
+
+func TestRequestLogger_GeneratesRequestID(t *testing.T) {
+	// ------------------ Arrange ------------------
+	router, logs := newObservedRouter()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/things/42?x=1", nil)
+	w := httptest.NewRecorder()
+
+	// ------------------ Act ----------------------
+	router.ServeHTTP(w, req)
+
+	// ------------------ Assert -------------------
+	requestID := w.Header().Get(logging.RequestIDHeader)
+	_, err := uuid.Parse(requestID)
+	assert.NoError(t, err)
+
+	serviceLogs := logs.FilterMessage("Service log").All()
+	assert.Len(t, serviceLogs, 1)
+	assert.Equal(t, requestID, serviceLogs[0].ContextMap()["request_id"])
+	assert.Equal(t, "/api/v1/things/:id", serviceLogs[0].ContextMap()["route"])
+
+	accessLogs := logs.FilterMessage("Request served").All()
+	assert.Len(t, accessLogs, 1)
+	fields := accessLogs[0].ContextMap()
+	assert.Equal(t, requestID, fields["request_id"])
+	assert.Equal(t, "/api/v1/things/:id", fields["route"])
+	assert.Equal(t, "/api/v1/things/42", fields["path"])
+	assert.Equal(t, "x=1", fields["query"])
+	assert.Equal(t, int64(http.StatusNoContent), fields["status"])
+}
+
+func TestRequestLogger_RequestIDHeader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		accepted bool
+	}{
+		{name: "accepts a client ID", header: "req-1234_abc.def:5", accepted: true},
+		{name: "rejects control characters", header: "abc\ninjected", accepted: false},
+		{name: "rejects spaces", header: "abc def", accepted: false},
+		{name: "rejects long IDs", header: strings.Repeat("a", 129), accepted: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			router, _ := newObservedRouter()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/things/42", nil)
+			req.Header.Set(logging.RequestIDHeader, tc.header)
+			w := httptest.NewRecorder()
+
+			// ------------------ Act ----------------------
+			router.ServeHTTP(w, req)
+
+			// ------------------ Assert -------------------
+			requestID := w.Header().Get(logging.RequestIDHeader)
+			assert.Equal(t, tc.accepted, requestID == tc.header)
+			assert.NotEmpty(t, requestID)
+		})
+	}
+}
+
+func TestRequestLogger_UnmatchedRoute(t *testing.T) {
+	// ------------------ Arrange ------------------
+	router, logs := newObservedRouter()
+	req := httptest.NewRequest(http.MethodGet, "/nowhere", nil)
+	w := httptest.NewRecorder()
+
+	// ------------------ Act ----------------------
+	router.ServeHTTP(w, req)
+
+	// ------------------ Assert -------------------
+	accessLogs := logs.FilterMessage("Request served").All()
+	assert.Len(t, accessLogs, 1)
+	assert.Equal(t, "", accessLogs[0].ContextMap()["route"])
+	assert.Equal(t, int64(http.StatusNotFound), accessLogs[0].ContextMap()["status"])
+}
+
+func TestMetrics_ObservesRequestsByRouteTemplate(t *testing.T) {
+	// ------------------ Arrange ------------------
+	router, _, metrics := newInstrumentedRouter()
+
+	// ------------------ Act ----------------------
+	for _, target := range []string{"/api/v1/things/1", "/api/v1/things/2", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	// ------------------ Assert -------------------
+	// One series for both things, labelled by the route template
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	counts := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "tgs_http_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "route" {
+					counts[label.GetValue()] = metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]uint64{"/api/v1/things/:id": 2, "unmatched": 1}, counts)
+}
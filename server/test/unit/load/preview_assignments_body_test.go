@@ -0,0 +1,54 @@
+package load_unit_test
+
+import (
+	"testing"
+	"time"
+
+	loadfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/load"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewAssignmentsBody_Validation(t *testing.T) {
+	dueAt := time.Date(2026, 10, 23, 16, 59, 59, 0, time.UTC)
+	proposal := loadfx.ProposedAssignment{HomeworkID: uuid.New(), ClassID: uuid.New(), DueAt: dueAt}
+	tooMany := make([]loadfx.ProposedAssignment, 11)
+	for i := range tooMany {
+		tooMany[i] = proposal
+	}
+
+	testCases := []struct {
+		name  string
+		body  loadfx.PreviewAssignmentsBody
+		valid bool
+	}{
+		{"one proposal", loadfx.PreviewAssignmentsBody{Assignments: []loadfx.ProposedAssignment{proposal}}, true},
+		{"assigned at", loadfx.PreviewAssignmentsBody{Assignments: []loadfx.ProposedAssignment{
+			{HomeworkID: uuid.New(), ClassID: uuid.New(), AssignedAt: &dueAt, DueAt: dueAt},
+		}}, true},
+		{"no proposals", loadfx.PreviewAssignmentsBody{}, false},
+		{"empty proposals", loadfx.PreviewAssignmentsBody{Assignments: []loadfx.ProposedAssignment{}}, false},
+		{"too many proposals", loadfx.PreviewAssignmentsBody{Assignments: tooMany}, false},
+		{"no class", loadfx.PreviewAssignmentsBody{Assignments: []loadfx.ProposedAssignment{
+			{HomeworkID: uuid.New(), DueAt: dueAt},
+		}}, false},
+		{"no due date", loadfx.PreviewAssignmentsBody{Assignments: []loadfx.ProposedAssignment{
+			{HomeworkID: uuid.New(), ClassID: uuid.New()},
+		}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			err := binding.Validator.ValidateStruct(&tc.body)
+
+			// ------------------ Assert -------------------
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
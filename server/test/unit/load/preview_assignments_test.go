@@ -0,0 +1,45 @@
+package load_unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	loadfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/load"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPreviewAssignments_RejectsInvalidProposals(t *testing.T) {
+	// ------------------ Arrange ------------------
+	now := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	service := loadfx.NewLoadService(loadfx.LoadServiceParams{
+		Logger: zap.NewNop(),
+		Clock:  clock.NewFrozen(now),
+	})
+
+	homeworkID, classID := uuid.New(), uuid.New()
+	proposals := []loadfx.ProposedAssignment{
+		{HomeworkID: homeworkID, ClassID: classID, DueAt: now.Add(48 * time.Hour)},
+		{HomeworkID: homeworkID, ClassID: classID, DueAt: now.Add(72 * time.Hour)},
+		{HomeworkID: uuid.New(), ClassID: classID, AssignedAt: &now, DueAt: now.Add(-time.Hour)},
+	}
+
+	// ------------------ Act ----------------------
+	whatIf, err := service.PreviewAssignments(context.Background(), proposals)
+
+	// ------------------ Assert -------------------
+	assert.Nil(t, whatIf)
+	assert.ErrorIs(t, err, common.ErrValidation)
+
+	var customErr common.CustomError
+	require.ErrorAs(t, err, &customErr)
+	assert.Equal(t, map[string]string{
+		"assignments[1]":        "homework is already proposed to the class",
+		"assignments[2].due_at": "due_at must be after assigned_at",
+	}, customErr.Details)
+}
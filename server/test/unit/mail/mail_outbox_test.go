@@ -0,0 +1,184 @@
+package mail_unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gomail "github.com/wneessen/go-mail"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newOutboxDB is a dry-run DB answering the retrieval of a mail with stored
+// and keeping the last saved mail in saved
+func newOutboxDB(t *testing.T, stored *models.MailOutbox, saved **models.MailOutbox) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	retrieve := func(tx *gorm.DB) {
+		if mail, ok := tx.Statement.Dest.(*models.MailOutbox); ok && stored != nil {
+			*mail = *stored
+		}
+	}
+	save := func(tx *gorm.DB) {
+		if mail, ok := tx.Statement.Dest.(*models.MailOutbox); ok {
+			*saved = mail
+		}
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:retrieve", retrieve))
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:save", save))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:save", save))
+
+	return db
+}
+
+// newMailService sends through an SMTP server that refuses the connection,
+// so that every mail fails and is recorded in the outbox
+func newMailService(t *testing.T, environment string, db *gorm.DB) *mailfx.MailService {
+	t.Helper()
+	t.Chdir("../../..") // The templates are read from the server directory
+
+	mailClient, err := gomail.NewClient("127.0.0.1", gomail.WithPort(1), gomail.WithTLSPolicy(gomail.NoTLS))
+	require.NoError(t, err)
+
+	preferencesService := new(mocks.MockPreferencesService)
+	preferencesService.On("GetPreferences", mock.Anything, mock.Anything).
+		Return(models.DefaultUserPreferences(uuid.Nil), nil)
+
+	return mailfx.NewMailService(mailfx.MailServiceParams{
+		FlagConfig: &configfx.FlagConfig{Environment: environment},
+		AppConfig: &configfx.AppConfig{
+			ClientURL:         "https://tgs.example.com",
+			MailSenderName:    "Touch-Grass-Scheduler",
+			MailSenderAddress: "noreply@tgs.example.com",
+		},
+		Logger:             zap.NewNop(),
+		DB:                 db,
+		MailClient:         mailClient,
+		Metrics:            metricsfx.NewMetrics(),
+		TracerProvider:     noop.NewTracerProvider(),
+		PreferencesService: preferencesService,
+		Clock:              clock.NewFrozen(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)),
+	})
+}
+
+func TestMailService_Send_OutboxNeverKeepsOneTimeLinks(t *testing.T) {
+	// ------------------ Arrange ------------------
+	var saved *models.MailOutbox
+	service := newMailService(t, "production", newOutboxDB(t, nil, &saved))
+	user := &models.User{ID: uuid.New(), FirstName: "John", Email: "johnsmith@gmail.com"}
+
+	// ------------------ Act ----------------------
+	err := service.SendResetPwd(context.Background(), user, "one-time-reset-token")
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, common.ErrMailSending)
+	require.NotNil(t, saved)
+	assert.Equal(t, "reset_password", saved.Template)
+	assert.Equal(t, &user.ID, saved.UserID)
+	assert.Nil(t, saved.Data)
+
+	row, err := json.Marshal(saved)
+	require.NoError(t, err)
+	assert.NotContains(t, string(row), "one-time-reset-token")
+}
+
+func TestMailService_Send_OutboxKeepsDataOfResendableMails(t *testing.T) {
+	// ------------------ Arrange ------------------
+	var saved *models.MailOutbox
+	service := newMailService(t, "production", newOutboxDB(t, nil, &saved))
+	user := &models.User{ID: uuid.New(), FirstName: "John", Email: "johnsmith@gmail.com"}
+
+	// ------------------ Act ----------------------
+	err := service.SendReminder(context.Background(), user, "Essay is due soon", []string{"Essay is due today."})
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, common.ErrMailSending)
+	require.NotNil(t, saved)
+	assert.Equal(t, "reminder", saved.Template)
+	assert.JSONEq(t, `{
+		"UserFirstName": "John",
+		"AppName": "Touch-Grass-Scheduler",
+		"Title": "Essay is due soon",
+		"Lines": ["Essay is due today."]
+	}`, string(saved.Data))
+}
+
+func TestMailService_ResendMail(t *testing.T) {
+	testCases := []struct {
+		name        string
+		environment string
+		stored      *models.MailOutbox
+		expectedErr error
+		sent        bool
+	}{
+		{
+			name:        "One-time link",
+			environment: "production",
+			stored:      &models.MailOutbox{Template: "reset_password"},
+			expectedErr: common.ErrMailNotResendable,
+		},
+		{
+			name:        "Recorded before the templates",
+			environment: "production",
+			stored:      &models.MailOutbox{Template: ""},
+			expectedErr: common.ErrMailNotResendable,
+		},
+		{
+			name:        "Intercepted outside of production",
+			environment: "development",
+			stored:      &models.MailOutbox{Template: "reminder", Data: []byte(`{"Title": "Essay is due soon"}`)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Rendered again",
+			environment: "production",
+			stored:      &models.MailOutbox{Template: "reminder", Data: []byte(`{"Title": "Essay is due soon"}`)},
+			expectedErr: common.ErrMailSending,
+			sent:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			var saved *models.MailOutbox
+			tc.stored.ID = uuid.New()
+			tc.stored.Recipient = "johnsmith@gmail.com"
+			tc.stored.Attempts = 1
+			service := newMailService(t, tc.environment, newOutboxDB(t, tc.stored, &saved))
+
+			// ------------------ Act ----------------------
+			err := service.ResendMail(context.Background(), tc.stored.ID)
+
+			// ------------------ Assert -------------------
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			if tc.sent {
+				require.NotNil(t, saved)
+				assert.Equal(t, 2, saved.Attempts)
+			} else {
+				assert.Nil(t, saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package mail_unit_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomail "github.com/wneessen/go-mail"
+)
+
+// serveSMTP accepts every mail of a single connection, and sends the data of
+// the last one on the returned channel
+func serveSMTP(t *testing.T) (int, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250-localhost")
+				reply("250 8BITMIME")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				received <- data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestMailService_Send_PrefersHTMLPart(t *testing.T) {
+	// ------------------ Arrange ------------------
+	var saved *models.MailOutbox
+	service := newMailService(t, "production", newOutboxDB(t, nil, &saved))
+
+	port, received := serveSMTP(t)
+	mailClient, err := gomail.NewClient("127.0.0.1", gomail.WithPort(port), gomail.WithTLSPolicy(gomail.NoTLS))
+	require.NoError(t, err)
+	service.MailClient = mailClient
+
+	user := &models.User{ID: uuid.New(), FirstName: "John", Email: "johnsmith@gmail.com"}
+
+	// ------------------ Act ----------------------
+	err = service.SendReminder(context.Background(), user, "Essay is due soon", []string{"Essay is due today."})
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	data := <-received
+
+	plainIdx := strings.Index(data, "Content-Type: text/plain")
+	htmlIdx := strings.Index(data, "Content-Type: text/html")
+	require.NotEqual(t, -1, plainIdx)
+	require.NotEqual(t, -1, htmlIdx)
+	// RFC 2046, the last part of multipart/alternative is the preferred one
+	assert.Less(t, plainIdx, htmlIdx)
+	assert.Contains(t, data, "Content-Type: multipart/alternative")
+}
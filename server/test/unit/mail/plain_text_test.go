@@ -0,0 +1,63 @@
+package mail_unit_test
+
+import (
+	"testing"
+
+	mailfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/mail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainTextFromHTML_DropsHeadAndStyles(t *testing.T) {
+	// ------------------ Arrange ------------------
+	htmlStr := `<!doctype html>
+<html>
+  <head>
+    <title>Security Notice</title>
+    <style>body { color: #555; }</style>
+  </head>
+  <body>
+    <p>Hi John,</p>
+    <p>Your account is <strong>secure</strong>.</p>
+  </body>
+</html>`
+
+	// ------------------ Act ----------------------
+	text := mailfx.PlainTextFromHTML(htmlStr)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, "Hi John,\n\nYour account is secure.\n", text)
+	assert.NotContains(t, text, "Security Notice")
+	assert.NotContains(t, text, "color")
+}
+
+func TestPlainTextFromHTML_KeepsLinkTargets(t *testing.T) {
+	// ------------------ Arrange ------------------
+	htmlStr := `<p><a href="https://example.com/reset">Reset Your Password</a></p>
+<p><a href="https://example.com/reset">https://example.com/reset</a></p>`
+
+	// ------------------ Act ----------------------
+	text := mailfx.PlainTextFromHTML(htmlStr)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t,
+		"Reset Your Password (https://example.com/reset)\n\nhttps://example.com/reset\n",
+		text,
+	)
+}
+
+func TestPlainTextFromHTML_ListsAndEntities(t *testing.T) {
+	// ------------------ Arrange ------------------
+	htmlStr := `<ul>
+  <li>If this was you: don&#39;t register again</li>
+  <li>If this was NOT you: ignore this email</li>
+</ul>`
+
+	// ------------------ Act ----------------------
+	text := mailfx.PlainTextFromHTML(htmlStr)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t,
+		"- If this was you: don't register again\n- If this was NOT you: ignore this email\n",
+		text,
+	)
+}
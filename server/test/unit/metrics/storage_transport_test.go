@@ -0,0 +1,74 @@
+package metrics_unit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metricsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestStorageOperation(t *testing.T) {
+	cases := []struct {
+		method   string
+		target   string
+		expected string
+	}{
+		{http.MethodHead, "/bucket/avatars/1.png", "stat"},
+		{http.MethodGet, "/bucket/?location=", "get_bucket_location"},
+		{http.MethodGet, "/bucket/?list-type=2&prefix=avatars", "list_objects"},
+		{http.MethodGet, "/bucket/avatars/1.png", "get_object"},
+		{http.MethodPut, "/bucket/avatars/1.png", "put_object"},
+		{http.MethodDelete, "/bucket/avatars/1.png", "remove_object"},
+		{http.MethodPost, "/bucket/?delete=", "remove_objects"},
+		{http.MethodPost, "/bucket/avatars/1.png?uploads=", "post"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+
+			// ------------------ Act ----------------------
+			operation := metricsfx.StorageOperation(req)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, operation)
+		})
+	}
+}
+
+func TestStorageTransport_RecordsRequests(t *testing.T) {
+	// ------------------ Arrange ------------------
+	metrics := metricsfx.NewMetrics()
+	failing := true
+	transport := &metricsfx.StorageTransport{
+		Metrics: metrics,
+		Base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			if failing {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{StatusCode: http.StatusNotFound}, nil
+		}),
+	}
+
+	// ------------------ Act ----------------------
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodHead, "/bucket/key", nil))
+	failing = false
+	_, _ = transport.RoundTrip(httptest.NewRequest(http.MethodHead, "/bucket/key", nil))
+
+	// ------------------ Assert -------------------
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.StorageRequests.WithLabelValues("stat", "error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.StorageRequests.WithLabelValues("stat", "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.StorageRequestDuration))
+}
@@ -0,0 +1,195 @@
+package middlewares_unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/logging"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+const testJWTSecret = "test-secret"
+
+// newUsersDB is a dry-run DB counting the active users with the ID, one unless
+// the user is deactivated
+func newUsersDB(t *testing.T, deactivated bool) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	count := func(tx *gorm.DB) {
+		if dest, ok := tx.Statement.Dest.(*int64); ok && !deactivated {
+			*dest, tx.RowsAffected = 1, 1
+		}
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:count", count))
+
+	return db
+}
+
+// serveWithToken runs the auth middleware and records the tenant scope of the request
+func serveWithToken(t *testing.T, claims jwt.MapClaims) (*httptest.ResponseRecorder, *uuid.UUID, bool) {
+	t.Helper()
+
+	return serveWithTokenOf(t, claims, false)
+}
+
+// serveWithTokenOf is serveWithToken for a user that may be deactivated
+func serveWithTokenOf(
+	t *testing.T,
+	claims jwt.MapClaims,
+	deactivated bool,
+) (*httptest.ResponseRecorder, *uuid.UUID, bool) {
+	t.Helper()
+
+	accessToken, err := common.GenerateJTWToken(claims, testJWTSecret, time.Hour)
+	require.NoError(t, err)
+
+	authMiddleware := middlewarefx.NewAuthMiddleware(middlewarefx.AuthMiddlewareParams{
+		AppConfig: &configfx.AppConfig{JWTSecret: testJWTSecret},
+		Logger:    zap.NewNop(),
+		DB:        newUsersDB(t, deactivated),
+	})
+
+	var schoolID *uuid.UUID
+	var scoped bool
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.GET("/test", authMiddleware.Handler(), func(ctx *gin.Context) {
+		id, ok := tenant.SchoolFromContext(ctx.Request.Context())
+		schoolID, scoped = &id, ok
+		ctx.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.AddCookie(&http.Cookie{Name: "accessToken", Value: accessToken})
+	router.ServeHTTP(w, req)
+
+	return w, schoolID, scoped
+}
+
+func TestAuthMiddleware_ScopesRequestToSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	schoolID := uuid.New()
+	claims := jwt.MapClaims{"user_id": uuid.NewString(), "role": "teacher", "school_id": schoolID.String()}
+
+	// ------------------ Act ----------------------
+	w, scopedSchoolID, scoped := serveWithToken(t, claims)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, scoped)
+	assert.Equal(t, schoolID, *scopedSchoolID)
+}
+
+func TestAuthMiddleware_UserWithoutSchool_ScopedToNoSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	claims := jwt.MapClaims{"user_id": uuid.NewString(), "role": "guardian", "school_id": nil}
+
+	// ------------------ Act ----------------------
+	w, scopedSchoolID, scoped := serveWithToken(t, claims)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, scoped)
+	assert.Equal(t, uuid.Nil, *scopedSchoolID)
+}
+
+func TestAuthMiddleware_PlatformAdmin_NotScoped(t *testing.T) {
+	// ------------------ Arrange ------------------
+	claims := jwt.MapClaims{"user_id": uuid.NewString(), "role": "admin", "school_id": nil}
+
+	// ------------------ Act ----------------------
+	w, _, scoped := serveWithToken(t, claims)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, scoped)
+}
+
+func TestAuthMiddleware_TokenWithoutSchoolClaim_Rejected(t *testing.T) {
+	// ------------------ Arrange ------------------
+	// Issued before tenancy, the user must log in again
+	claims := jwt.MapClaims{"user_id": uuid.NewString(), "role": "teacher"}
+
+	// ------------------ Act ----------------------
+	w, _, scoped := serveWithToken(t, claims)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.False(t, scoped)
+}
+
+func TestAuthMiddleware_DeactivatedUser_Rejected(t *testing.T) {
+	// ------------------ Arrange ------------------
+	// Deactivated after the token was issued
+	claims := jwt.MapClaims{"user_id": uuid.NewString(), "role": "teacher", "school_id": uuid.NewString()}
+
+	// ------------------ Act ----------------------
+	w, _, scoped := serveWithTokenOf(t, claims, true)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "USER_DEACTIVATED")
+	assert.False(t, scoped)
+}
+
+func TestAuthMiddleware_AddsUserToRequestLogger(t *testing.T) {
+	// ------------------ Arrange ------------------
+	userID := uuid.NewString()
+	claims := jwt.MapClaims{"user_id": userID, "role": "admin", "school_id": nil}
+	accessToken, err := common.GenerateJTWToken(claims, testJWTSecret, time.Hour)
+	require.NoError(t, err)
+
+	authMiddleware := middlewarefx.NewAuthMiddleware(middlewarefx.AuthMiddlewareParams{
+		AppConfig: &configfx.AppConfig{JWTSecret: testJWTSecret},
+		Logger:    zap.NewNop(),
+		DB:        newUsersDB(t, false),
+	})
+
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.GET("/test",
+		func(ctx *gin.Context) {
+			// As set by the router's request logger
+			reqCtx := logging.WithLogger(ctx.Request.Context(), zap.New(core))
+			ctx.Request = ctx.Request.WithContext(reqCtx)
+		},
+		authMiddleware.Handler(),
+		func(ctx *gin.Context) {
+			logging.FromContext(ctx.Request.Context(), nil).Info("Service log")
+			ctx.Status(http.StatusOK)
+		})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.AddCookie(&http.Cookie{Name: "accessToken", Value: accessToken})
+
+	// ------------------ Act ----------------------
+	router.ServeHTTP(w, req)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusOK, w.Code)
+	serviceLogs := logs.FilterMessage("Service log").All()
+	assert.Len(t, serviceLogs, 1)
+	assert.Equal(t, userID, serviceLogs[0].ContextMap()["user_id"])
+}
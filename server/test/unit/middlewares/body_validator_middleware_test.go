@@ -8,8 +8,13 @@ import (
 	"testing"
 
 	middlewarefx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/middlewares"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/i18n"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -48,7 +53,7 @@ func TestRequestBodyValidator_Success(t *testing.T) {
 	requestBodyValidator := &middlewarefx.RequestBodyValidator{Logger: zap.NewNop()}
 
 	router.POST("/test",
-		requestBodyValidator.Handler("test", TestRequestBody{}),
+		requestBodyValidator.Handler(TestRequestBody{}),
 		func(c *gin.Context) {
 			nextHandlerCalled = true
 
@@ -104,7 +109,7 @@ func TestRequestBodyValidator_Error(t *testing.T) {
 
 	// Create middleware
 	requestBodyValidator := &middlewarefx.RequestBodyValidator{Logger: zap.NewNop()}
-	middleware := requestBodyValidator.Handler("test", TestRequestBody{})
+	middleware := requestBodyValidator.Handler(TestRequestBody{})
 
 	// ------------------ Act ----------------------
 	middleware(ctx)
@@ -113,14 +118,75 @@ func TestRequestBodyValidator_Error(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Parse response body
-	var responseBody map[string]any
+	var responseBody common.ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 	assert.NoError(t, err)
-	assert.Contains(t, responseBody, "error")
-	assert.Contains(t, responseBody["error"], "Name")
-	assert.Contains(t, responseBody["error"], "Email")
-	assert.NotContains(t, responseBody["error"], "Age")
-	assert.NotContains(t, responseBody["error"], "Phone")
-	assert.Contains(t, responseBody["error"], "Role")
-	assert.Contains(t, responseBody["error"], "Password")
+	assert.Equal(t, common.ErrValidation.Code, responseBody.Error.Code)
+	assert.Equal(t, http.StatusBadRequest, responseBody.Error.Status)
+	assert.Contains(t, responseBody.Error.Details, "Name")
+	assert.Contains(t, responseBody.Error.Details, "Email")
+	assert.NotContains(t, responseBody.Error.Details, "Age")
+	assert.NotContains(t, responseBody.Error.Details, "Phone")
+	assert.Contains(t, responseBody.Error.Details, "Role")
+	assert.Contains(t, responseBody.Error.Details, "Password")
+}
+
+func TestRequestBodyValidator_Error_LocalizedMessages(t *testing.T) {
+	// ------------------ Arrange ------------------
+	jsonBody, _ := json.Marshal(map[string]any{"name": "John", "password": "short"})
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Request = ctx.Request.WithContext(i18n.WithLocale(ctx.Request.Context(), i18n.LocaleThai))
+
+	requestBodyValidator := &middlewarefx.RequestBodyValidator{Logger: zap.NewNop()}
+	middleware := requestBodyValidator.Handler(TestRequestBody{})
+
+	// ------------------ Act ----------------------
+	middleware(ctx)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var responseBody common.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "กรุณาระบุ Email", responseBody.Error.Details["Email"])
+	assert.Equal(t, "Password ต้องมีความยาวอย่างน้อย 8 ตัวอักษร", responseBody.Error.Details["Password"])
+}
+
+type TestNameBody struct {
+	FirstName string `json:"first_name" binding:"required,max=128,name"`
+}
+
+func (body *TestNameBody) Normalize() {
+	body.FirstName = common.NormalizeName(body.FirstName)
+}
+
+func TestRequestBodyValidator_NormalizesAfterValidation(t *testing.T) {
+	// ------------------ Arrange ------------------
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, common.RegisterNameValidations(validate))
+
+	// A decomposed accent and extra spaces
+	jsonBody, _ := json.Marshal(map[string]any{"first_name": " Rene\u0301e  ณัฐวุฒิ "})
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	requestBodyValidator := &middlewarefx.RequestBodyValidator{Logger: zap.NewNop()}
+	middleware := requestBodyValidator.Handler(TestNameBody{})
+
+	// ------------------ Act ----------------------
+	middleware(ctx)
+
+	// ------------------ Assert -------------------
+	validatedBody, exists := ctx.Get("validatedBody")
+	require.True(t, exists)
+	assert.Equal(t, "Ren\u00e9e ณัฐวุฒิ", validatedBody.(*TestNameBody).FirstName)
 }
@@ -0,0 +1,93 @@
+package migrate_unit_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/migrations"
+	migratefx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations_SortsByVersion(t *testing.T) {
+	// ------------------ Arrange ------------------
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":   {Data: []byte("CREATE INDEX;")},
+		"0010_add_index.down.sql": {Data: []byte("DROP INDEX;")},
+		"0002_add_table.up.sql":   {Data: []byte("CREATE TABLE;")},
+		"0002_add_table.down.sql": {Data: []byte("DROP TABLE;")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TYPE;")},
+		"0001_init.down.sql":      {Data: []byte("DROP TYPE;")},
+	}
+
+	// ------------------ Act ----------------------
+	loaded, err := migratefx.LoadMigrations(fsys)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 3)
+	assert.Equal(t, int64(1), loaded[0].Version)
+	assert.Equal(t, "init", loaded[0].Name)
+	assert.Equal(t, "CREATE TYPE;", loaded[0].Up)
+	assert.Equal(t, "DROP TYPE;", loaded[0].Down)
+	assert.Equal(t, int64(2), loaded[1].Version)
+	assert.Equal(t, int64(10), loaded[2].Version)
+}
+
+func TestLoadMigrations_RejectsInvalidSets(t *testing.T) {
+	testCases := map[string]fstest.MapFS{
+		"missing down file": {
+			"0001_init.up.sql": {Data: []byte("CREATE TYPE;")},
+		},
+		"duplicated version": {
+			"0001_init.up.sql":    {Data: []byte("CREATE TYPE;")},
+			"0001_init.down.sql":  {Data: []byte("DROP TYPE;")},
+			"0001_other.up.sql":   {Data: []byte("CREATE TABLE;")},
+			"0001_other.down.sql": {Data: []byte("DROP TABLE;")},
+		},
+		"invalid file name": {
+			"init.sql": {Data: []byte("CREATE TYPE;")},
+		},
+		"zero version": {
+			"0000_init.up.sql":   {Data: []byte("CREATE TYPE;")},
+			"0000_init.down.sql": {Data: []byte("DROP TYPE;")},
+		},
+	}
+
+	for name, fsys := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			_, err := migratefx.LoadMigrations(fsys)
+
+			// ------------------ Assert -------------------
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadMigrations_EmbeddedMigrationsAreValid(t *testing.T) {
+	// ------------------ Act ----------------------
+	loaded, err := migratefx.LoadMigrations(migrations.FS)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+	for i, migration := range loaded {
+		// Versions are contiguous, starting at 1
+		assert.Equal(t, int64(i+1), migration.Version)
+	}
+}
+
+func TestLoadMigrations_BaselineSkipsExistingSchema(t *testing.T) {
+	// ------------------ Act ----------------------
+	loaded, err := migratefx.LoadMigrations(migrations.FS)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	baseline := loaded[0].Up
+	// A database created from db/postgres/sqls/000_init.sql already has all of it
+	assert.Equal(t, strings.Count(baseline, "CREATE TYPE"), strings.Count(baseline, "WHEN duplicate_object THEN NULL"))
+	assert.Equal(t, strings.Count(baseline, "CREATE TABLE"), strings.Count(baseline, "CREATE TABLE IF NOT EXISTS"))
+	assert.NotContains(t, baseline, "CREATE INDEX ")
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	auditfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/audit"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+// Verify mock implements the interface
+var _ auditfx.AuditServiceInterface = (*MockAuditService)(nil)
+
+func (m *MockAuditService) Record(ctx context.Context, event *models.AuditEvent) {
+	m.Called(ctx, event)
+}
+
+func (m *MockAuditService) RecordTx(tx *gorm.DB, event *models.AuditEvent) error {
+	args := m.Called(tx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) GetAuditEvents(ctx context.Context, filter *auditfx.AuditEventFilter) ([]*models.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*models.AuditEvent), args.Error(1)
+}
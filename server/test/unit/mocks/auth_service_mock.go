@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	authfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/auth"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
 	"github.com/stretchr/testify/mock"
@@ -12,14 +14,24 @@ type MockAuthService struct {
 
 var _ authfx.AuthServiceInterface = (*MockAuthService)(nil)
 
-func (m *MockAuthService) GetRegistrationMail(email string) error {
-	args := m.Called(email)
+func (m *MockAuthService) GetRegistrationMail(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
 
 	return args.Error(0)
 }
 
-func (m *MockAuthService) Register(registrationTokenString string, body *authfx.RegisterBody) (*models.PublicUser, string, error) {
-	args := m.Called(registrationTokenString, body)
+func (m *MockAuthService) Register(ctx context.Context, registrationTokenString string, body *authfx.RegisterBody) (*models.PublicUser, string, error) {
+	args := m.Called(ctx, registrationTokenString, body)
+
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+
+	return args.Get(0).(*models.PublicUser), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthService) RegisterByInvitation(ctx context.Context, invitationTokenString string, body *authfx.InvitationRegisterBody) (*models.PublicUser, string, error) {
+	args := m.Called(ctx, invitationTokenString, body)
 
 	if args.Get(0) == nil {
 		return nil, args.String(1), args.Error(2)
@@ -28,8 +40,8 @@ func (m *MockAuthService) Register(registrationTokenString string, body *authfx.
 	return args.Get(0).(*models.PublicUser), args.String(1), args.Error(2)
 }
 
-func (m *MockAuthService) Login(body *authfx.LoginBody) (*models.PublicUser, string, error) {
-	args := m.Called(body)
+func (m *MockAuthService) Login(ctx context.Context, body *authfx.LoginBody) (*models.PublicUser, string, error) {
+	args := m.Called(ctx, body)
 
 	if args.Get(0) == nil {
 		return nil, args.String(1), args.Error(2)
@@ -37,3 +49,15 @@ func (m *MockAuthService) Login(body *authfx.LoginBody) (*models.PublicUser, str
 
 	return args.Get(0).(*models.PublicUser), args.String(1), args.Error(2)
 }
+
+func (m *MockAuthService) GetResetPwdMail(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ResetPwd(ctx context.Context, body *authfx.ResetPwdBody) error {
+	args := m.Called(ctx, body)
+
+	return args.Error(0)
+}
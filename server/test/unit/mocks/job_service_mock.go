@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	jobsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/jobs"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobService struct {
+	mock.Mock
+}
+
+// Verify mock implements the interface
+var _ jobsfx.JobServiceInterface = (*MockJobService)(nil)
+
+func (m *MockJobService) GetJobs() []*jobsfx.JobInfo {
+	args := m.Called()
+	return args.Get(0).([]*jobsfx.JobInfo)
+}
+
+func (m *MockJobService) GetJobRuns(ctx context.Context, jobName string, limit int) ([]*models.JobRun, error) {
+	args := m.Called(ctx, jobName, limit)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*models.JobRun), args.Error(1)
+}
+
+func (m *MockJobService) TriggerJob(ctx context.Context, jobName string, triggeredBy uuid.UUID) (*models.JobRun, error) {
+	args := m.Called(ctx, jobName, triggeredBy)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.JobRun), args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	notificationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/notifications"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+// Verify mock implements the interface
+var _ notificationsfx.NotificationServiceInterface = (*MockNotificationService)(nil)
+
+func (m *MockNotificationService) CreateNotification(ctx context.Context, userID uuid.UUID, title, body string) error {
+	args := m.Called(ctx, userID, title, body)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetNotificationsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Notification, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationService) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) (*models.Notification, error) {
+	args := m.Called(ctx, userID, notificationID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
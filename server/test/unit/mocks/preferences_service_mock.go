@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPreferencesService struct {
+	mock.Mock
+}
+
+// Verify mock implements the interface
+var _ preferencesfx.PreferencesServiceInterface = (*MockPreferencesService)(nil)
+
+func (m *MockPreferencesService) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.UserPreferences), args.Error(1)
+}
+
+func (m *MockPreferencesService) GetPreferencesByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.UserPreferences, error) {
+	args := m.Called(ctx, userIDs)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[uuid.UUID]*models.UserPreferences), args.Error(1)
+}
+
+func (m *MockPreferencesService) UpdatePreferences(ctx context.Context, userID uuid.UUID, body *preferencesfx.UpdatePreferencesBody) (*models.UserPreferences, error) {
+	args := m.Called(ctx, userID, body)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.UserPreferences), args.Error(1)
+}
@@ -1,6 +1,11 @@
 package mocks
 
 import (
+	"context"
+	"net/url"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
 	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
 	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
 	"github.com/google/uuid"
@@ -14,13 +19,82 @@ type MockUserService struct {
 // Verify mock implements the interface
 var _ usersfx.UserServiceInterface = (*MockUserService)(nil)
 
-func (m *MockUserService) CreateUser(user *models.User) error {
-	args := m.Called(user)
+func (m *MockUserService) GetPublicUserByID(
+	ctx context.Context,
+	reader *authzfx.Subject,
+	userID uuid.UUID,
+) (*models.PublicUser, error) {
+	args := m.Called(ctx, reader, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.PublicUser), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserByID(ctx context.Context, userID uuid.UUID, body *usersfx.UpdateUserBody) (*models.PublicUser, error) {
+	args := m.Called(ctx, userID, body)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.PublicUser), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, role types.UserRole) (*models.PublicUser, error) {
+	args := m.Called(ctx, actorID, userID, role)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.PublicUser), args.Error(1)
+}
+
+func (m *MockUserService) GetUploadAvatarSignedURL(ctx context.Context, userID uuid.UUID) (*usersfx.GetUploadAvatarSignedURLResponse, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*usersfx.GetUploadAvatarSignedURLResponse), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserPwdByEmail(ctx context.Context, email, newPassword string) error {
+	args := m.Called(ctx, email, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetUserPwdByEmail(ctx context.Context, email, pwdFingerprint, newPassword string) error {
+	args := m.Called(ctx, email, pwdFingerprint, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserService) DeactivateUserByEmail(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockUserService) HandleAvatarUpload(ctx context.Context, userID uuid.UUID) (*url.URL, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*url.URL), args.Error(1)
+}
+
+func (m *MockUserService) CreateUser(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
-func (m *MockUserService) GetUserByEmail(email string) (*models.User, error) {
-	args := m.Called(email)
+func (m *MockUserService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -29,8 +103,8 @@ func (m *MockUserService) GetUserByEmail(email string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserService) GetUserByID(id uuid.UUID) (*models.User, error) {
-	args := m.Called(id)
+func (m *MockUserService) GetUserByID(ctx context.Context, reader *authzfx.Subject, id uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, reader, id)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
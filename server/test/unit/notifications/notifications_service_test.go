@@ -0,0 +1,66 @@
+package notifications_unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/clock"
+	notificationsfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/notifications"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newCaptureDB is a dry-run DB keeping the variables of the last insert
+func newCaptureDB(t *testing.T, vars *[]any) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	capture := func(tx *gorm.DB) { *vars = tx.Statement.Vars }
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture", capture))
+
+	return db
+}
+
+func TestNotificationService_CreateNotification_FrozenTime(t *testing.T) {
+	// ------------------ Arrange ------------------
+	var vars []any
+	frozen := clock.NewFrozen(time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC))
+	service := notificationsfx.NewNotificationService(notificationsfx.NotificationServiceParams{
+		Logger: zap.NewNop(),
+		DB:     newCaptureDB(t, &vars),
+		Clock:  frozen,
+	})
+	frozen.Advance(90 * time.Minute)
+
+	// ------------------ Act ----------------------
+	err := service.CreateNotification(context.Background(), uuid.New(), "Title", "Body")
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Contains(t, vars, time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC))
+}
+
+func TestFrozen_SetAndAdvance(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	frozen := clock.NewFrozen(time.Date(2025, 3, 4, 9, 0, 0, 0, bangkok))
+
+	// ------------------ Act ----------------------
+	first := frozen.Now()
+	second := frozen.Now()
+	frozen.Advance(time.Hour)
+	advanced := frozen.Now()
+	frozen.Set(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, first, second)
+	assert.Equal(t, time.UTC, first.Location())
+	assert.Equal(t, time.Date(2025, 3, 4, 3, 0, 0, 0, time.UTC), advanced)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), frozen.Now())
+}
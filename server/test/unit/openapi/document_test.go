@@ -0,0 +1,206 @@
+package openapi_unit_test
+
+import (
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	bootstrapfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/bootstrap"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const generatedClientPath = "../../../../client/src/services/api.gen.ts"
+
+// registeredRoutes sets up every route of bootstrapfx.RoutesParams on a fresh
+// router, the dependencies left as zero values since no request is served
+func registeredRoutes(t *testing.T) []string {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	paramsType := reflect.TypeFor[bootstrapfx.RoutesParams]()
+	for i := range paramsType.NumField() {
+		field := paramsType.Field(i)
+		if field.Anonymous {
+			continue // fx.In
+		}
+
+		routes := reflect.New(field.Type.Elem())
+		for j := range routes.Elem().NumField() {
+			dependency := routes.Elem().Field(j)
+			switch dependency.Type() {
+			case reflect.TypeFor[*zap.Logger]():
+				dependency.Set(reflect.ValueOf(zap.NewNop()))
+			case reflect.TypeFor[*gin.Engine]():
+				dependency.Set(reflect.ValueOf(router))
+			default:
+				if dependency.Kind() == reflect.Pointer {
+					dependency.Set(reflect.New(dependency.Type().Elem()))
+				}
+			}
+		}
+
+		route, ok := routes.Interface().(bootstrapfx.Route)
+		require.True(t, ok, "%s has no Setup method", field.Name)
+		route.Setup()
+	}
+
+	var registered []string
+	for _, info := range router.Routes() {
+		registered = append(registered, info.Method+" "+openapifx.SpecPath(info.Path))
+	}
+	slices.Sort(registered)
+
+	return registered
+}
+
+func TestDocument_DescribesEveryRoute(t *testing.T) {
+	// ------------------ Arrange ------------------
+	registered := registeredRoutes(t)
+
+	// ------------------ Act ------------------
+	document, err := bootstrapfx.NewDocument()
+
+	// ------------------ Assert ------------------
+	require.NoError(t, err)
+
+	var described []string
+	for path, methods := range document.Paths {
+		for method := range methods {
+			described = append(described, strings.ToUpper(method)+" "+path)
+		}
+	}
+	slices.Sort(described)
+
+	for _, route := range registered {
+		assert.Contains(t, described, route, "add the route to the Operations of its package")
+	}
+	for _, route := range described {
+		assert.Contains(t, registered, route, "the Operations describe a route that is not registered")
+	}
+}
+
+func TestDocument_GeneratedClientIsUpToDate(t *testing.T) {
+	// ------------------ Arrange ------------------
+	document, err := bootstrapfx.NewDocument()
+	require.NoError(t, err)
+
+	// ------------------ Act ------------------
+	generated, err := os.ReadFile(generatedClientPath)
+
+	// ------------------ Assert ------------------
+	require.NoError(t, err)
+	assert.Equal(t, document.TypeScript(), string(generated), `run "go run ./cmd/openapi" in server`)
+}
+
+type testBody struct {
+	Name     string  `json:"name"     binding:"required,min=2,max=10"`
+	Nickname *string `json:"nickname" binding:"omitempty"`
+	Kind     string  `json:"kind"     binding:"required,oneof='a b' c"`
+	Ignored  string  `json:"-"`
+}
+
+type testResponse struct {
+	Count   int      `json:"count"`
+	Tags    []string `json:"tags,omitempty"`
+	Details *testBody
+}
+
+func TestNewDocument_Schemas(t *testing.T) {
+	// ------------------ Arrange ------------------
+	operations := []openapifx.Operation{{
+		Method:    http.MethodPost,
+		Path:      "api/test/:id",
+		ID:        "createTest",
+		Body:      testBody{},
+		Responses: []openapifx.Response{{Status: http.StatusCreated, Body: testResponse{}}},
+	}}
+
+	// ------------------ Act ------------------
+	document, err := openapifx.NewDocument("Test", "1.0.0", operations)
+
+	// ------------------ Assert ------------------
+	require.NoError(t, err)
+
+	pathOp := document.Paths["/api/test/{id}"]["post"]
+	require.NotNil(t, pathOp)
+	require.Len(t, pathOp.Parameters, 1)
+	assert.Equal(t, "id", pathOp.Parameters[0].Name)
+	assert.Equal(t, "path", pathOp.Parameters[0].In)
+
+	// Usual errors of an operation with a body and parameters
+	assert.Contains(t, pathOp.Responses, "201")
+	assert.Contains(t, pathOp.Responses, "400")
+	assert.Contains(t, pathOp.Responses, "500")
+	assert.NotContains(t, pathOp.Responses, "401")
+
+	body := document.Components.Schemas["testBody"]
+	require.NotNil(t, body)
+	assert.Equal(t, []string{"name", "kind"}, body.Required)
+	assert.NotContains(t, body.Properties, "Ignored")
+	assert.Equal(t, 2, *body.Properties["name"].MinLength)
+	assert.Equal(t, 10, *body.Properties["name"].MaxLength)
+	assert.Equal(t, []string{"a b", "c"}, body.Properties["kind"].Enum)
+	assert.True(t, body.Properties["nickname"].Nullable)
+
+	// Not a request body, so the properties without omitempty are required
+	response := document.Components.Schemas["testResponse"]
+	require.NotNil(t, response)
+	assert.Equal(t, []string{"count", "Details"}, response.Required)
+	assert.Equal(t, "array", response.Properties["tags"].Type)
+}
+
+func TestNewDocument_Invalid(t *testing.T) {
+	ok := []openapifx.Response{{Status: http.StatusOK}}
+
+	testCases := []struct {
+		name       string
+		operations []openapifx.Operation
+	}{
+		{
+			name: "duplicated operation ID",
+			operations: []openapifx.Operation{
+				{Method: http.MethodGet, Path: "api/a", ID: "get", Responses: ok},
+				{Method: http.MethodGet, Path: "api/b", ID: "get", Responses: ok},
+			},
+		},
+		{
+			name: "duplicated route",
+			operations: []openapifx.Operation{
+				{Method: http.MethodGet, Path: "api/a", ID: "getA", Responses: ok},
+				{Method: http.MethodGet, Path: "api/a", ID: "getAgain", Responses: ok},
+			},
+		},
+		{
+			name: "path parameter missing from the path",
+			operations: []openapifx.Operation{{
+				Method:     http.MethodGet,
+				Path:       "api/a",
+				ID:         "getA",
+				PathParams: []openapifx.Param{{Name: "id", Schema: ""}},
+				Responses:  ok,
+			}},
+		},
+		{
+			name:       "no successful response",
+			operations: []openapifx.Operation{{Method: http.MethodGet, Path: "api/a", ID: "getA"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// ------------------ Act ------------------
+			document, err := openapifx.NewDocument("Test", "1.0.0", testCase.operations)
+
+			// ------------------ Assert ------------------
+			assert.Error(t, err)
+			assert.Nil(t, document)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package pagination_unit_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pagination"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var eventsResource = &pagination.Resource{
+	Fields: map[string]pagination.Field{
+		"id":         {Column: "id", Kind: pagination.KindUUID, Sortable: true},
+		"created_at": {Column: "created_at", Kind: pagination.KindTime, Sortable: true, Filterable: true},
+		"action":     {Column: "action", Kind: pagination.KindString, Sortable: true, Filterable: true},
+		"actor_id":   {Column: "actor_id", Kind: pagination.KindUUID, Filterable: true},
+		"kind": {Column: "action", Kind: pagination.KindString, Filterable: true, Values: []string{
+			string(types.AuditActionLoginSucceeded), string(types.AuditActionLoginFailed),
+		}},
+	},
+	Key:         "id",
+	DefaultSort: "-created_at",
+	MaxLimit:    50,
+}
+
+// Columns the resource may reach, whatever the request
+var eventsColumns = []string{"id", "created_at", "action", "actor_id"}
+
+// findStatement returns the statement listing the audit events of query
+func findStatement(db *gorm.DB, query *pagination.Query) *gorm.Statement {
+	var events []*models.AuditEvent
+	return db.Scopes(query.Scope).Find(&events).Statement
+}
+
+func mustParse(t *testing.T, rawQuery string) *pagination.Query {
+	t.Helper()
+
+	values, err := url.ParseQuery(rawQuery)
+	require.NoError(t, err)
+	query, err := pagination.Parse(eventsResource, values)
+	require.NoError(t, err)
+
+	return query
+}
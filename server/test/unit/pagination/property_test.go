@@ -0,0 +1,192 @@
+package pagination_unit_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pagination"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var quickConfig = &quick.Config{MaxCount: 2000}
+
+// Names a client may try, the random ones mixed with the known ones so that
+// both the whitelisted and the rejected paths are exercised
+func pick(random string, known ...string) string {
+	if random == "" || len(known) == 0 {
+		return random
+	}
+	return known[int(random[0])%len(known)]
+}
+
+func TestParse_Property_OnlyWhitelistedColumns(t *testing.T) {
+	// ------------------ Arrange ------------------
+	property := func(sort, field, operator, value string, pickSort, pickField bool) bool {
+		if pickSort {
+			sort = pick(sort, "action", "-created_at", "id,action", "metadata", "password")
+		}
+		if pickField {
+			field = pick(field, "action", "created_at", "actor_id", "kind", "metadata", "role")
+		}
+		values := url.Values{
+			"sort":                  {sort},
+			"filter[" + field + "]": {value},
+			"filter[" + field + "][" + operator + "]": {value},
+		}
+
+		// ------------------ Act ----------------------
+		query, err := pagination.Parse(eventsResource, values)
+
+		// ------------------ Assert -------------------
+		if err != nil {
+			return query == nil
+		}
+		for _, sort := range query.Sort {
+			if !slices.Contains(eventsColumns, sort.Column) {
+				return false
+			}
+		}
+		for _, filter := range query.Filters {
+			if !slices.Contains(eventsColumns, filter.Column) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// ------------------ Act & Assert -------------
+	assert.NoError(t, quick.Check(property, quickConfig))
+}
+
+func TestScope_Property_ValuesAreBoundParameters(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+
+	// The statement of a value, with its cursor after an event of that action
+	statement := func(value string) (string, []any, bool) {
+		rawQuery := url.Values{
+			"sort":                   {"action"},
+			"filter[action]":         {value},
+			"filter[action][ne]":     {value},
+			"filter[action][prefix]": {value},
+			"filter[action][in]":     {value + "," + value},
+		}
+		query, err := pagination.Parse(eventsResource, rawQuery)
+		if err != nil {
+			return "", nil, false
+		}
+
+		cursor, err := query.CursorAfter(db, &models.AuditEvent{ID: uuid.Nil, Action: types.AuditAction(value)})
+		if err != nil {
+			return "", nil, false
+		}
+		rawQuery.Set("cursor", cursor)
+		query, err = pagination.Parse(eventsResource, rawQuery)
+		if err != nil {
+			return "", nil, false
+		}
+
+		stmt := findStatement(db, query)
+		return stmt.SQL.String(), stmt.Vars, true
+	}
+	expectedSQL, _, ok := statement("x")
+	require.True(t, ok)
+
+	property := func(value string) bool {
+		// ------------------ Act ----------------------
+		sql, vars, ok := statement(value)
+
+		// ------------------ Assert -------------------
+		if !ok {
+			// Only the values no column could hold are rejected
+			return strings.ContainsRune(value, 0) || len([]rune(value)) > 256 || !isValidUTF8(value)
+		}
+		if strings.Contains(value, ",") {
+			// The in filter then has more values, thus more parameters
+			return slices.Contains(vars, any(value))
+		}
+		// Same statement whatever the value, which is only ever a parameter
+		return sql == expectedSQL && slices.Contains(vars, any(value))
+	}
+
+	// ------------------ Act & Assert -------------
+	assert.NoError(t, quick.Check(property, quickConfig))
+}
+
+func TestParse_Property_LimitIsBounded(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+
+	property := func(limit int, raw string, useRaw bool) bool {
+		value := strconv.Itoa(limit)
+		if useRaw {
+			value = raw
+		}
+
+		// ------------------ Act ----------------------
+		query, err := pagination.Parse(eventsResource, url.Values{"limit": {value}})
+
+		// ------------------ Assert -------------------
+		if err != nil {
+			return true
+		}
+		stmt := findStatement(db, query)
+		pageSize := stmt.Vars[len(stmt.Vars)-1]
+		return query.Limit >= 1 && query.Limit <= eventsResource.MaxLimit &&
+			strings.HasSuffix(stmt.SQL.String(), "LIMIT $1") && pageSize == query.Limit+1
+	}
+
+	// ------------------ Act & Assert -------------
+	assert.NoError(t, quick.Check(property, quickConfig))
+	assert.NoError(t, quick.Check(func(limit uint8) bool {
+		return property(int(limit), "", false)
+	}, quickConfig))
+}
+
+func TestParse_Property_ForgedCursorIsRejectedOrTyped(t *testing.T) {
+	// ------------------ Arrange ------------------
+	property := func(garbage []byte, createdAt, id string, forge bool) bool {
+		raw := garbage
+		if forge {
+			raw, _ = json.Marshal(map[string]any{"s": "-created_at,-id", "v": []string{createdAt, id}})
+		}
+		cursor := base64.RawURLEncoding.EncodeToString(raw)
+
+		// ------------------ Act ----------------------
+		query, err := pagination.Parse(eventsResource, url.Values{"cursor": {cursor}})
+
+		// ------------------ Assert -------------------
+		if err != nil {
+			return true
+		}
+		if query.After == nil {
+			return cursor == "" // The first page
+		}
+		_, isTime := query.After[0].(time.Time)
+		_, isUUID := query.After[1].(uuid.UUID)
+		return len(query.After) == 2 && isTime && isUUID
+	}
+
+	// ------------------ Act & Assert -------------
+	assert.NoError(t, quick.Check(property, quickConfig))
+	assert.NoError(t, quick.Check(func(seconds int64, id [16]byte) bool {
+		createdAt := time.Unix(seconds%(1<<35), 0).UTC().Format(time.RFC3339)
+		return property(nil, createdAt, uuid.UUID(id).String(), true)
+	}, quickConfig))
+}
+
+func isValidUTF8(value string) bool {
+	return strings.ToValidUTF8(value, "�") == value
+}
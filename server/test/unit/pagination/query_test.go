@@ -0,0 +1,181 @@
+package pagination_unit_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	openapifx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/openapi"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Defaults(t *testing.T) {
+	// ------------------ Act ----------------------
+	query := mustParse(t, "")
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, pagination.DefaultLimit, query.Limit)
+	assert.Equal(t, []pagination.Sort{
+		{Field: "created_at", Column: "created_at", Kind: pagination.KindTime, Desc: true},
+		{Field: "id", Column: "id", Kind: pagination.KindUUID, Desc: true},
+	}, query.Sort)
+	assert.Empty(t, query.Filters)
+	assert.Nil(t, query.After)
+}
+
+func TestParse_SortAndFilters(t *testing.T) {
+	// ------------------ Arrange ------------------
+	actorID := uuid.New()
+	values := url.Values{
+		"limit":                   {"5"},
+		"sort":                    {"action,-created_at"},
+		"filter[actor_id]":        {actorID.String()},
+		"filter[created_at][gte]": {"2025-01-01T00:00:00+07:00"},
+		"filter[kind][in]":        {"auth.login_succeeded,auth.login_failed"},
+		"class_id":                {"left to the controller"},
+	}
+
+	// ------------------ Act ----------------------
+	query, err := pagination.Parse(eventsResource, values)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Equal(t, 5, query.Limit)
+	assert.Equal(t, []pagination.Sort{
+		{Field: "action", Column: "action", Kind: pagination.KindString},
+		{Field: "created_at", Column: "created_at", Kind: pagination.KindTime, Desc: true},
+		{Field: "id", Column: "id", Kind: pagination.KindUUID, Desc: true},
+	}, query.Sort)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.FixedZone("", 7*60*60))
+	require.Len(t, query.Filters, 3)
+	assert.Equal(t, pagination.Filter{
+		Field: "actor_id", Column: "actor_id", Operator: pagination.OperatorEq, Values: []any{actorID},
+	}, query.Filters[0])
+	assert.Equal(t, pagination.OperatorGte, query.Filters[1].Operator)
+	assert.True(t, from.Equal(query.Filters[1].Values[0].(time.Time)))
+	assert.Equal(t, pagination.Filter{
+		Field: "kind", Column: "action", Operator: pagination.OperatorIn,
+		Values: []any{"auth.login_succeeded", "auth.login_failed"},
+	}, query.Filters[2])
+}
+
+func TestParse_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rawQuery string
+		param    string
+	}{
+		{"limit zero", "limit=0", "limit"},
+		{"limit over the resource maximum", "limit=51", "limit"},
+		{"limit not a number", "limit=ten", "limit"},
+		{"sort unknown field", "sort=metadata", "sort"},
+		{"sort not sortable field", "sort=actor_id", "sort"},
+		{"sort sql", "sort=created_at%3BDROP%20TABLE%20users", "sort"},
+		{"sort too many fields", "sort=action,created_at,id,action", "sort"},
+		{"sort repeated field", "sort=action,-action", "sort"},
+		{"filter unknown field", "filter[password]=x", "filter[password]"},
+		{"filter unknown operator", "filter[action][regex]=.*", "filter[action][regex]"},
+		{"filter unsupported operator", "filter[actor_id][gt]=" + uuid.NewString(), "filter[actor_id][gt]"},
+		{"filter malformed", "filter[action]x=y", "filter[action]x"},
+		{"filter invalid time", "filter[created_at]=yesterday", "filter[created_at]"},
+		{"filter invalid uuid", "filter[actor_id]=1 OR 1=1", "filter[actor_id]"},
+		{"filter value not allowed", "filter[kind]=user.read", "filter[kind]"},
+		{"filter value too long", "filter[action]=" + strings.Repeat("a", 257), "filter[action]"},
+		{"filter nul", "filter[action]=a%00b", "filter[action]"},
+		{"filter repeated", "filter[action]=a&filter[action]=b", "filter[action]"},
+		{"filter too many in values", "filter[action][in]=" + strings.Repeat("a,", 50) + "a", "filter[action][in]"},
+		{"cursor not base64", "cursor=%25%25", "cursor"},
+		{"cursor not json", "cursor=bm90IGpzb24", "cursor"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			values, err := url.ParseQuery(tc.rawQuery)
+			require.NoError(t, err)
+
+			// ------------------ Act ----------------------
+			query, err := pagination.Parse(eventsResource, values)
+
+			// ------------------ Assert -------------------
+			assert.Nil(t, query)
+			assert.ErrorIs(t, err, common.ErrValidation)
+
+			var customErr common.CustomError
+			require.ErrorAs(t, err, &customErr)
+			assert.Contains(t, customErr.Details, tc.param)
+		})
+	}
+}
+
+func TestParse_TooManyFilters(t *testing.T) {
+	// ------------------ Arrange ------------------
+	values := url.Values{}
+	for _, operator := range []string{"eq", "ne", "gt", "gte", "lt", "lte", "in", "prefix"} {
+		values.Set("filter[action]["+operator+"]", "a")
+	}
+	values.Set("filter[created_at][gt]", "2025-01-01T00:00:00Z")
+	values.Set("filter[created_at][lt]", "2025-02-01T00:00:00Z")
+	values.Set("filter[actor_id]", uuid.NewString())
+
+	// ------------------ Act ----------------------
+	_, err := pagination.Parse(eventsResource, values)
+
+	// ------------------ Assert -------------------
+	var customErr common.CustomError
+	require.ErrorAs(t, err, &customErr)
+	assert.Contains(t, customErr.Details, "filter")
+}
+
+func TestParse_MisconfiguredResourcePanics(t *testing.T) {
+	// ------------------ Arrange ------------------
+	withoutKey := &pagination.Resource{Fields: eventsResource.Fields, Key: "metadata"}
+	unknownDefaultSort := &pagination.Resource{Fields: eventsResource.Fields, Key: "id", DefaultSort: "-metadata"}
+
+	// ------------------ Act & Assert -------------
+	assert.Panics(t, func() { _, _ = pagination.Parse(withoutKey, url.Values{}) })
+	assert.Panics(t, func() { _, _ = pagination.Parse(unknownDefaultSort, url.Values{}) })
+}
+
+func TestResource_Params(t *testing.T) {
+	// ------------------ Act ----------------------
+	params := eventsResource.Params()
+
+	// ------------------ Assert -------------------
+	names := []string{}
+	for _, param := range params {
+		names = append(names, param.Name)
+	}
+	assert.Equal(t, []string{
+		"limit", "cursor", "sort",
+		"filter[action]", "filter[actor_id]", "filter[created_at]", "filter[kind]",
+	}, names)
+}
+
+func TestPage_DocumentedByItsItems(t *testing.T) {
+	// ------------------ Arrange ------------------
+	operations := []openapifx.Operation{{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/events",
+		ID:        "getEvents",
+		Query:     eventsResource.Params(),
+		Responses: []openapifx.Response{{Status: http.StatusOK, Body: pagination.Page[*models.AuditEvent]{}}},
+	}}
+
+	// ------------------ Act ----------------------
+	document, err := openapifx.NewDocument("test", "0", operations)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	require.Contains(t, document.Components.Schemas, "PageAuditEvent")
+	assert.Contains(t, document.Components.Schemas["PageAuditEvent"].Properties, "next_cursor")
+	assert.Contains(t, document.TypeScript(), "PageAuditEvent")
+}
@@ -0,0 +1,86 @@
+package pagination_unit_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/pagination"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_FirstPage(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+	query := mustParse(t, "limit=10&filter[created_at][lt]=2025-01-01T00:00:00Z&filter[action][prefix]=auth.")
+
+	// ------------------ Act ----------------------
+	stmt := findStatement(db, query)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t,
+		`SELECT * FROM "audit_events" WHERE "action" LIKE $1 AND "created_at" < $2 ORDER BY "created_at" DESC,"id" DESC LIMIT $3`,
+		stmt.SQL.String())
+	assert.Equal(t, []any{"auth.%", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 11}, stmt.Vars)
+}
+
+func TestScope_PrefixEscapesWildcards(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+	query := mustParse(t, `filter[action][prefix]=a_%25\`)
+
+	// ------------------ Act ----------------------
+	stmt := findStatement(db, query)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, `a\_\%\\%`, stmt.Vars[0])
+}
+
+func TestCursorAfter_NextPageStartsAfterTheRow(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+	query := mustParse(t, "sort=action,-created_at")
+	last := &models.AuditEvent{
+		ID:        uuid.New(),
+		Action:    types.AuditActionLoginFailed,
+		CreatedAt: time.Date(2025, 3, 1, 8, 30, 0, 123456000, time.FixedZone("", 7*60*60)),
+	}
+
+	// ------------------ Act ----------------------
+	cursor, err := query.CursorAfter(db, last)
+	require.NoError(t, err)
+	next := mustParse(t, "sort=action,-created_at&cursor="+cursor)
+	stmt := findStatement(db, next)
+
+	// ------------------ Assert -------------------
+	require.Len(t, next.After, 3)
+	assert.Equal(t, string(last.Action), next.After[0])
+	assert.True(t, last.CreatedAt.Equal(next.After[1].(time.Time)))
+	assert.Equal(t, last.ID, next.After[2])
+
+	assert.Contains(t, stmt.SQL.String(),
+		`WHERE ("action" > $1 OR ("action" = $2 AND "created_at" < $3) OR ("action" = $4 AND "created_at" = $5 AND "id" < $6))`)
+	assert.Contains(t, stmt.SQL.String(), `ORDER BY "action","created_at" DESC,"id" DESC`)
+}
+
+func TestCursorAfter_RejectedWithAnotherSort(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t)
+	query := mustParse(t, "sort=action")
+	cursor, err := query.CursorAfter(db, &models.AuditEvent{ID: uuid.New(), Action: types.AuditActionUserRead})
+	require.NoError(t, err)
+
+	// ------------------ Act ----------------------
+	_, err = pagination.Parse(eventsResource, url.Values{"sort": {"-action"}, "cursor": {cursor}})
+
+	// ------------------ Assert -------------------
+	var customErr common.CustomError
+	require.ErrorAs(t, err, &customErr)
+	assert.Contains(t, customErr.Details, "cursor")
+}
@@ -0,0 +1,47 @@
+package preferences_unit_test
+
+import (
+	"testing"
+
+	preferencesfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/preferences"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePreferencesBody_Validation(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  preferencesfx.UpdatePreferencesBody
+		valid bool
+	}{
+		{"empty", preferencesfx.UpdatePreferencesBody{}, true},
+		{"timezone", preferencesfx.UpdatePreferencesBody{Timezone: ptr("Europe/London")}, true},
+		{"unknown timezone", preferencesfx.UpdatePreferencesBody{Timezone: ptr("Mars/Olympus_Mons")}, false},
+		{"locale", preferencesfx.UpdatePreferencesBody{Locale: ptr("th")}, true},
+		{"unsupported locale", preferencesfx.UpdatePreferencesBody{Locale: ptr("fr")}, false},
+		{"quiet hours", preferencesfx.UpdatePreferencesBody{
+			QuietHoursStart: ptr("22:00"), QuietHoursEnd: ptr("07:00"),
+		}, true},
+		{"cleared quiet hours", preferencesfx.UpdatePreferencesBody{
+			QuietHoursStart: ptr(""), QuietHoursEnd: ptr(""),
+		}, true},
+		{"quiet hours start alone", preferencesfx.UpdatePreferencesBody{QuietHoursStart: ptr("22:00")}, false},
+		{"invalid quiet hours", preferencesfx.UpdatePreferencesBody{
+			QuietHoursStart: ptr("25:00"), QuietHoursEnd: ptr("07:00"),
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			err := binding.Validator.ValidateStruct(&tc.body)
+
+			// ------------------ Assert -------------------
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
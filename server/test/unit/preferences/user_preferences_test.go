@@ -0,0 +1,112 @@
+package preferences_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestDefaultUserPreferences_WantEverything(t *testing.T) {
+	// ------------------ Arrange ------------------
+	preferences := models.DefaultUserPreferences(uuid.New())
+
+	// ------------------ Assert -------------------
+	for _, kind := range []types.NotificationKind{types.NotificationKindReminder, types.NotificationKindEscalation} {
+		for _, channel := range []types.NotificationChannel{types.NotificationChannelEmail, types.NotificationChannelInApp} {
+			assert.True(t, preferences.Wants(kind, channel), "%s over %s", kind, channel)
+		}
+	}
+	assert.Equal(t, "Asia/Bangkok", preferences.Location().String())
+	assert.Equal(t, types.DigestFrequencyWeekly, preferences.DigestFrequency)
+}
+
+func TestUserPreferences_Wants(t *testing.T) {
+	// ------------------ Arrange ------------------
+	preferences := models.DefaultUserPreferences(uuid.New())
+	preferences.Channels.Reminder.Email = false
+	preferences.Channels.Escalation.InApp = false
+
+	// ------------------ Assert -------------------
+	assert.False(t, preferences.Wants(types.NotificationKindReminder, types.NotificationChannelEmail))
+	assert.True(t, preferences.Wants(types.NotificationKindReminder, types.NotificationChannelInApp))
+	assert.True(t, preferences.Wants(types.NotificationKindEscalation, types.NotificationChannelEmail))
+	assert.False(t, preferences.Wants(types.NotificationKindEscalation, types.NotificationChannelInApp))
+}
+
+func TestUserPreferences_Location_UnknownFallsBackToDefault(t *testing.T) {
+	// ------------------ Arrange ------------------
+	preferences := models.DefaultUserPreferences(uuid.New())
+	preferences.Timezone = "Mars/Olympus_Mons"
+
+	// ------------------ Act ----------------------
+	location := preferences.Location()
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, models.DefaultTimezone, location.String())
+}
+
+func TestUserPreferences_InQuietHours(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	at := func(hour, minute int) time.Time {
+		// In UTC, so that the preferences must convert it
+		return time.Date(2025, 3, 4, hour, minute, 0, 0, bangkok).UTC()
+	}
+
+	testCases := []struct {
+		name     string
+		start    *string
+		end      *string
+		at       time.Time
+		expected bool
+	}{
+		{"no quiet hours", nil, nil, at(23, 0), false},
+		{"within the same day", ptr("12:00"), ptr("14:00"), at(13, 0), true},
+		{"start is inclusive", ptr("12:00"), ptr("14:00"), at(12, 0), true},
+		{"end is exclusive", ptr("12:00"), ptr("14:00"), at(14, 0), false},
+		{"before the same day", ptr("12:00"), ptr("14:00"), at(11, 59), false},
+		{"spanning midnight, evening", ptr("22:00"), ptr("07:00"), at(23, 30), true},
+		{"spanning midnight, morning", ptr("22:00"), ptr("07:00"), at(6, 59), true},
+		{"spanning midnight, daytime", ptr("22:00"), ptr("07:00"), at(7, 0), false},
+		{"empty span", ptr("22:00"), ptr("22:00"), at(22, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			preferences := models.DefaultUserPreferences(uuid.New())
+			preferences.QuietHoursStart = tc.start
+			preferences.QuietHoursEnd = tc.end
+
+			// ------------------ Act ----------------------
+			actual := preferences.InQuietHours(tc.at)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUserPreferences_InQuietHours_InTheUserTimezone(t *testing.T) {
+	// ------------------ Arrange ------------------
+	preferences := models.DefaultUserPreferences(uuid.New())
+	preferences.Timezone = "Europe/London"
+	preferences.QuietHoursStart = ptr("22:00")
+	preferences.QuietHoursEnd = ptr("07:00")
+
+	// 23:00 in London, 06:00 the next day in Bangkok
+	at := time.Date(2025, 1, 10, 23, 0, 0, 0, time.UTC)
+
+	// ------------------ Act ----------------------
+	actual := preferences.InQuietHours(at)
+
+	// ------------------ Assert -------------------
+	assert.True(t, actual)
+}
@@ -0,0 +1,227 @@
+package reminders_unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	remindersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/reminders"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+var (
+	student  = &models.User{ID: uuid.New(), FirstName: "Somchai"}
+	guardian = &models.User{ID: uuid.New(), FirstName: "Malee"}
+	teacher  = &models.User{ID: uuid.New(), FirstName: "Anan"}
+	classID  = uuid.New()
+)
+
+// Names of the users in the claims
+var names = map[uuid.UUID]string{
+	student.ID:  "student",
+	guardian.ID: "guardian",
+	teacher.ID:  "teacher",
+}
+
+// store answers the queries of the reminder service, whatever their
+// conditions, and keeps the claims of the deliveries
+type store struct {
+	claims   map[string]bool // "kind recipient channel"
+	claimed  []string        // "kind recipient", in order
+	released []string        // "kind recipient", in order
+}
+
+func claimKey(delivery *models.ReminderDelivery) string {
+	return delivery.Kind + " " + names[delivery.RecipientID] + " " + string(delivery.Channel)
+}
+
+// newStoreDB is a dry-run DB answering the statements from the store
+func newStoreDB(t *testing.T, s *store) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+
+	rows := func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *[]*models.StudentGuardian:
+			*dest = []*models.StudentGuardian{{StudentID: student.ID, GuardianID: guardian.ID}}
+		case *[]*models.ClassTeacher:
+			*dest = []*models.ClassTeacher{{ClassID: classID, TeacherID: teacher.ID}}
+		case *[]*models.User:
+			*dest = []*models.User{student, guardian, teacher}
+		}
+	}
+	claim := func(tx *gorm.DB) {
+		delivery := tx.Statement.Dest.(*models.ReminderDelivery)
+		if s.claims[claimKey(delivery)] {
+			return
+		}
+		s.claims[claimKey(delivery)] = true
+		s.claimed = append(s.claimed, delivery.Kind+" "+names[delivery.RecipientID])
+		tx.RowsAffected = 1
+	}
+	release := func(tx *gorm.DB) {
+		delivery := tx.Statement.Dest.(*models.ReminderDelivery)
+		delete(s.claims, claimKey(delivery))
+		s.released = append(s.released, delivery.Kind+" "+names[delivery.RecipientID])
+	}
+
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:rows", rows))
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:claim", claim))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:release", release))
+
+	return db
+}
+
+// newReminderService sends the reminders in the app only
+func newReminderService(
+	t *testing.T,
+	s *store,
+	notificationService *mocks.MockNotificationService,
+) *remindersfx.ReminderService {
+	t.Helper()
+
+	inApp := models.ChannelSet{InApp: true}
+	preferences := map[uuid.UUID]*models.UserPreferences{}
+	for _, user := range []*models.User{student, guardian, teacher} {
+		preferences[user.ID] = &models.UserPreferences{
+			UserID:   user.ID,
+			Timezone: "UTC",
+			Locale:   "en",
+			Channels: models.NotificationChannels{Reminder: inApp, Escalation: inApp},
+		}
+	}
+	preferencesService := new(mocks.MockPreferencesService)
+	preferencesService.On("GetPreferencesByUserIDs", mock.Anything, mock.Anything).Return(preferences, nil)
+
+	return remindersfx.NewReminderService(remindersfx.ReminderServiceParams{
+		AppConfig: &configfx.AppConfig{
+			ReminderOffsets:        []time.Duration{24 * time.Hour, 2 * time.Hour},
+			EscalateGuardiansAfter: 12 * time.Hour,
+			EscalateTeachersAfter:  48 * time.Hour,
+		},
+		Logger:              zap.NewNop(),
+		DB:                  newStoreDB(t, s),
+		NotificationService: notificationService,
+		PreferencesService:  preferencesService,
+	})
+}
+
+func pendingSubmission(dueAt time.Time) remindersfx.PendingSubmission {
+	return remindersfx.PendingSubmission{
+		HomeworkID:       uuid.New(),
+		HomeworkName:     "Essay",
+		ClassID:          classID,
+		ClassName:        "M.4/1",
+		DueAt:            dueAt,
+		StudentID:        student.ID,
+		StudentFirstName: student.FirstName,
+	}
+}
+
+func TestReminderService_Remind_Timing(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dueIn    time.Duration
+		expected []string
+	}{
+		{name: "Before the largest offset", dueIn: 30 * time.Hour, expected: nil},
+		{name: "Within the largest offset", dueIn: 20 * time.Hour, expected: []string{"before_24h0m0s student"}},
+		{name: "Within the smallest offset", dueIn: 90 * time.Minute, expected: []string{"before_2h0m0s student"}},
+		{name: "At an offset", dueIn: 2 * time.Hour, expected: []string{"before_2h0m0s student"}},
+		{name: "Just overdue", dueIn: -time.Minute, expected: []string{"overdue student"}},
+		{
+			name:     "Overdue before the guardian escalation",
+			dueIn:    -11 * time.Hour,
+			expected: []string{"overdue student"},
+		},
+		{
+			name:     "Overdue at the guardian escalation",
+			dueIn:    -12 * time.Hour,
+			expected: []string{"overdue student", "escalate_guardians guardian"},
+		},
+		{
+			name:     "Overdue past the teacher escalation",
+			dueIn:    -49 * time.Hour,
+			expected: []string{"overdue student", "escalate_guardians guardian", "escalate_teachers teacher"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			s := &store{claims: map[string]bool{}}
+			notificationService := new(mocks.MockNotificationService)
+			notificationService.On("CreateNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+			service := newReminderService(t, s, notificationService)
+
+			// ------------------ Act ----------------------
+			err := service.Remind(context.Background(),
+				[]remindersfx.PendingSubmission{pendingSubmission(now.Add(tc.dueIn))}, now)
+
+			// ------------------ Assert -------------------
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, s.claimed)
+			notificationService.AssertNumberOfCalls(t, "CreateNotification", len(tc.expected))
+		})
+	}
+}
+
+func TestReminderService_Remind_SkipsClaimedReminders(t *testing.T) {
+	// ------------------ Arrange ------------------
+	s := &store{claims: map[string]bool{}}
+	notificationService := new(mocks.MockNotificationService)
+	notificationService.On("CreateNotification", mock.Anything, student.ID, mock.Anything, mock.Anything).
+		Return(nil)
+	service := newReminderService(t, s, notificationService)
+	submissions := []remindersfx.PendingSubmission{pendingSubmission(now.Add(time.Hour))}
+
+	// ------------------ Act ----------------------
+	firstErr := service.Remind(context.Background(), submissions, now)
+	secondErr := service.Remind(context.Background(), submissions, now.Add(time.Minute))
+
+	// ------------------ Assert -------------------
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, []string{"before_2h0m0s student"}, s.claimed)
+	notificationService.AssertNumberOfCalls(t, "CreateNotification", 1)
+}
+
+func TestReminderService_Remind_ReleasesClaimWhenDeliveryFails(t *testing.T) {
+	// ------------------ Arrange ------------------
+	s := &store{claims: map[string]bool{}}
+	notificationService := new(mocks.MockNotificationService)
+	notificationService.On("CreateNotification", mock.Anything, student.ID, mock.Anything, mock.Anything).
+		Return(errors.New("notifications unavailable")).Once()
+	notificationService.On("CreateNotification", mock.Anything, student.ID, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	service := newReminderService(t, s, notificationService)
+	submissions := []remindersfx.PendingSubmission{pendingSubmission(now.Add(time.Hour))}
+
+	// ------------------ Act ----------------------
+	failedErr := service.Remind(context.Background(), submissions, now)
+	released := append([]string{}, s.released...)
+	retriedErr := service.Remind(context.Background(), submissions, now.Add(time.Minute))
+
+	// ------------------ Assert -------------------
+	require.NoError(t, failedErr)
+	require.NoError(t, retriedErr)
+	assert.Equal(t, []string{"before_2h0m0s student"}, released)
+	assert.Equal(t, []string{"before_2h0m0s student", "before_2h0m0s student"}, s.claimed)
+	assert.True(t, s.claims["before_2h0m0s student "+string(types.NotificationChannelInApp)])
+	notificationService.AssertExpectations(t)
+}
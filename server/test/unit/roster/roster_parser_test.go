@@ -0,0 +1,96 @@
+package roster_unit_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	rosterfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/roster"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseCSV_MapsColumnsByHeader(t *testing.T) {
+	// ------------------ Arrange ------------------
+	csv := "\uFEFFEmail,Phone,First_Name,Last_Name,School_Num,Gender,Note\n" +
+		"Somchai@Example.com,+66812345678,Somchai,Jaidee,1001,Male,ignored\n" +
+		"\n" +
+		"malee@example.com, +66898765432 ,Malee,,1002,,\n"
+
+	// ------------------ Act ----------------------
+	rows, err := rosterfx.ParseCSV(strings.NewReader(csv))
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "somchai@example.com", rows[0].Email)
+	assert.Equal(t, "Somchai", rows[0].FirstName)
+	assert.Equal(t, "Jaidee", rows[0].LastName)
+	assert.Equal(t, "1001", rows[0].SchoolNum)
+	assert.Equal(t, "+66812345678", rows[0].Phone)
+	assert.Equal(t, types.UserGenderMale, rows[0].Gender)
+
+	// Blank lines are skipped but still counted
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, "+66898765432", rows[1].Phone)
+	assert.Empty(t, rows[1].LastName)
+	assert.Empty(t, rows[1].Gender)
+}
+
+func TestParseCSV_MissingColumn(t *testing.T) {
+	// ------------------ Arrange ------------------
+	csv := "first_name,last_name,email,phone\nSomchai,Jaidee,somchai@example.com,+66812345678\n"
+
+	// ------------------ Act ----------------------
+	_, err := rosterfx.ParseCSV(strings.NewReader(csv))
+
+	// ------------------ Assert -------------------
+	assert.ErrorContains(t, err, `missing column "school_num"`)
+}
+
+func TestParseCSV_HeaderOnly(t *testing.T) {
+	// ------------------ Arrange ------------------
+	csv := "first_name,last_name,email,school_num,phone\n"
+
+	// ------------------ Act ----------------------
+	_, err := rosterfx.ParseCSV(strings.NewReader(csv))
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, rosterfx.ErrEmptyRoster)
+}
+
+func TestParseXLSX_ReadsFirstSheet(t *testing.T) {
+	// ------------------ Arrange ------------------
+	workbook := excelize.NewFile()
+	sheet := workbook.GetSheetName(0)
+	_ = workbook.SetSheetRow(sheet, "A1", &[]any{"first_name", "last_name", "email", "school_num", "phone"})
+	_ = workbook.SetSheetRow(sheet, "A2", &[]any{"Somchai", "Jaidee", "SOMCHAI@example.com", "1001", "+66812345678"})
+	_ = workbook.SetSheetRow(sheet, "A4", &[]any{"Malee", "", "malee@example.com", "1002", "+66898765432"})
+
+	var buffer bytes.Buffer
+	assert.NoError(t, workbook.Write(&buffer))
+
+	// ------------------ Act ----------------------
+	rows, err := rosterfx.ParseFile("Roster.XLSX", &buffer)
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "somchai@example.com", rows[0].Email)
+	assert.Equal(t, "1001", rows[0].SchoolNum)
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, "Malee", rows[1].FirstName)
+}
+
+func TestParseFile_UnsupportedType(t *testing.T) {
+	// ------------------ Act ----------------------
+	rows, err := rosterfx.ParseFile("roster.pdf", strings.NewReader(""))
+
+	// ------------------ Assert -------------------
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, err, rosterfx.ErrUnsupportedRosterType)
+}
@@ -0,0 +1,279 @@
+package tenant_unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tenant"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	schoolA = uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000001")
+	schoolB = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000002")
+)
+
+func TestPlugin_Query_ScopedToSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+
+	// ------------------ Act ----------------------
+	var users []*models.User
+	stmt := db.WithContext(ctx).Where("role = ?", "student").Find(&users).Statement
+
+	// ------------------ Assert -------------------
+	assert.Contains(t, stmt.SQL.String(), `"users"."school_id" = $2`)
+	assert.Equal(t, []any{"student", schoolA}, stmt.Vars)
+}
+
+func TestPlugin_First_CannotReadAnotherSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+	userOfSchoolB := uuid.New()
+
+	// ------------------ Act ----------------------
+	stmt := db.WithContext(ctx).First(&models.User{}, userOfSchoolB).Statement
+
+	// ------------------ Assert -------------------
+	// The id alone is not enough, the row must also be of school A
+	assert.Contains(t, stmt.SQL.String(), `"users"."id" = $1`)
+	assert.Contains(t, stmt.SQL.String(), `"users"."school_id" = $2`)
+	assert.Contains(t, stmt.Vars, schoolA)
+	assert.NotContains(t, stmt.Vars, schoolB)
+}
+
+func TestPlugin_Count_ScopedToSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolB)
+
+	// ------------------ Act ----------------------
+	var count int64
+	stmt := db.WithContext(ctx).Model(&models.Class{}).Where("id = ?", uuid.New()).Count(&count).Statement
+
+	// ------------------ Assert -------------------
+	assert.Contains(t, stmt.SQL.String(), `"classes"."school_id" = $2`)
+	assert.Contains(t, stmt.Vars, schoolB)
+}
+
+func TestPlugin_WithoutSchool_MatchesNull(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), uuid.Nil)
+
+	// ------------------ Act ----------------------
+	var users []*models.User
+	stmt := db.WithContext(ctx).Find(&users).Statement
+
+	// ------------------ Assert -------------------
+	assert.Contains(t, stmt.SQL.String(), `"users"."school_id" IS NULL`)
+}
+
+func TestPlugin_Bypass_Unscoped(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.Bypass(tenant.WithSchool(context.Background(), schoolA))
+
+	// ------------------ Act ----------------------
+	var users []*models.User
+	stmt := db.WithContext(ctx).Find(&users).Statement
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, stmt.Error)
+	assert.NotContains(t, stmt.SQL.String(), "school_id")
+}
+
+func TestPlugin_WithoutTenant_Rejected(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(db *gorm.DB) error
+	}{
+		{
+			name: "query",
+			run:  func(db *gorm.DB) error { return db.Find(&[]*models.User{}).Error },
+		},
+		{
+			name: "count",
+			run: func(db *gorm.DB) error {
+				var count int64
+				return db.Model(&models.Class{}).Count(&count).Error
+			},
+		},
+		{
+			name: "update",
+			run: func(db *gorm.DB) error {
+				return db.Model(&models.User{}).Where("id = ?", uuid.New()).Update("first_name", "Somchai").Error
+			},
+		},
+		{
+			name: "delete",
+			run:  func(db *gorm.DB) error { return db.Where("id = ?", uuid.New()).Delete(&models.Invitation{}).Error },
+		},
+		{
+			name: "create",
+			run:  func(db *gorm.DB) error { return db.Create(&models.User{Email: "somchai@example.com"}).Error },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			db := testdb.NewDryRunDB(t, tenant.Plugin{})
+
+			// ------------------ Act ----------------------
+			err := tc.run(db.WithContext(context.Background()))
+
+			// ------------------ Assert -------------------
+			assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		})
+	}
+}
+
+func TestPlugin_WithoutTenant_ModelWithoutSchoolAllowed(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+
+	// ------------------ Act ----------------------
+	var notifications []*models.Notification
+	err := db.WithContext(context.Background()).Find(&notifications).Error
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+}
+
+func TestColumn(t *testing.T) {
+	cases := []struct {
+		name         string
+		ctx          context.Context
+		expectedSQL  string
+		expectedVars []any
+		expectedErr  error
+	}{
+		{
+			name:         "school",
+			ctx:          tenant.WithSchool(context.Background(), schoolA),
+			expectedSQL:  "c.school_id = $1",
+			expectedVars: []any{schoolA},
+		},
+		{
+			name:        "without school",
+			ctx:         tenant.WithSchool(context.Background(), uuid.Nil),
+			expectedSQL: "c.school_id IS NULL",
+		},
+		{name: "bypassed", ctx: tenant.Bypass(context.Background())},
+		{name: "no tenant", ctx: context.Background(), expectedErr: tenant.ErrNoTenant},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			db := testdb.NewDryRunDB(t, tenant.Plugin{})
+
+			// ------------------ Act ----------------------
+			var rows []struct{ ID uuid.UUID }
+			stmt := db.WithContext(tc.ctx).
+				Table("assignments AS a").
+				Select("a.id").
+				Joins("JOIN classes AS c ON c.id = a.class_id").
+				Scopes(tenant.Column("c.school_id")).
+				Find(&rows).Statement
+
+			// ------------------ Assert -------------------
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, stmt.Error, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, stmt.Error)
+			if tc.expectedSQL == "" {
+				assert.NotContains(t, stmt.SQL.String(), "school_id")
+				return
+			}
+			assert.Contains(t, stmt.SQL.String(), tc.expectedSQL)
+			assert.Equal(t, tc.expectedVars, stmt.Vars)
+		})
+	}
+}
+
+func TestPlugin_ModelWithoutSchool_NotFiltered(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+
+	// ------------------ Act ----------------------
+	var notifications []*models.Notification
+	stmt := db.WithContext(ctx).Find(&notifications).Statement
+
+	// ------------------ Assert -------------------
+	assert.NotContains(t, stmt.SQL.String(), "school_id")
+}
+
+func TestPlugin_UpdateAndDelete_ScopedToSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+	userID := uuid.New()
+
+	// ------------------ Act ----------------------
+	updateStmt := db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("first_name", "Somchai").Statement
+	deleteStmt := db.WithContext(ctx).
+		Where("id = ?", userID).
+		Delete(&models.User{}).Statement
+
+	// ------------------ Assert -------------------
+	assert.Contains(t, updateStmt.SQL.String(), `"users"."school_id" = $`)
+	assert.Contains(t, updateStmt.Vars, schoolA)
+	assert.Contains(t, deleteStmt.SQL.String(), `"users"."school_id" = $`)
+	assert.Contains(t, deleteStmt.Vars, schoolA)
+}
+
+func TestPlugin_GlobalUpdate_StillRejected(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+
+	// ------------------ Act ----------------------
+	err := db.WithContext(ctx).Model(&models.User{}).Update("first_name", "Somchai").Error
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, gorm.ErrMissingWhereClause)
+}
+
+func TestPlugin_Create_AssignsSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+	user := &models.User{Email: "somchai@example.com"}
+
+	// ------------------ Act ----------------------
+	err := db.WithContext(ctx).Create(user).Error
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, err)
+	if assert.NotNil(t, user.SchoolID) {
+		assert.Equal(t, schoolA, *user.SchoolID)
+	}
+}
+
+func TestPlugin_Create_RejectsAnotherSchool(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := testdb.NewDryRunDB(t, tenant.Plugin{})
+	ctx := tenant.WithSchool(context.Background(), schoolA)
+	classes := []*models.Class{
+		{Name: "M.1/1", SchoolID: schoolA},
+		{Name: "M.1/2", SchoolID: schoolB},
+	}
+
+	// ------------------ Act ----------------------
+	err := db.WithContext(ctx).Create(&classes).Error
+
+	// ------------------ Assert -------------------
+	assert.ErrorIs(t, err, tenant.ErrCrossTenantWrite)
+}
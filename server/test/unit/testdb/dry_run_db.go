@@ -0,0 +1,39 @@
+package testdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// NewDryRunDB builds the statements without a database, with the plugins in
+// use. Callbacks registered on it can answer the statements instead.
+func NewDryRunDB(t *testing.T, plugins ...gorm.Plugin) *gorm.DB {
+	t.Helper()
+
+	return NewDryRunDBOn(t, nil, plugins...)
+}
+
+// NewDryRunDBOn is NewDryRunDB over conn, which only the raw SQL reaches, e.g.
+// through DB(). A nil conn never connects.
+func NewDryRunDBOn(t *testing.T, conn gorm.ConnPool, plugins ...gorm.Plugin) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost", Conn: conn}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true, // Beginning a transaction would connect
+		},
+	)
+	require.NoError(t, err)
+
+	for _, plugin := range plugins {
+		require.NoError(t, db.Use(plugin))
+	}
+
+	return db
+}
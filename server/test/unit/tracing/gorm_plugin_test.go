@@ -0,0 +1,75 @@
+package tracing_unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newRecordingTracerProvider records every span, to be read from the recorder
+func newRecordingTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func attributesOf(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestGormPlugin_Query_ChildOfContextSpan(t *testing.T) {
+	// ------------------ Arrange ------------------
+	tracerProvider, recorder := newRecordingTracerProvider()
+	db := testdb.NewDryRunDB(t, tracingfx.GormPlugin{TracerProvider: tracerProvider})
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+
+	// ------------------ Act ----------------------
+	db.WithContext(ctx).Where("email = ?", "johnsmith@gmail.com").First(&models.User{})
+	parent.End()
+
+	// ------------------ Assert -------------------
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "gorm.query", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+
+	attributes := attributesOf(span)
+	assert.Equal(t, "users", attributes["db.sql.table"].AsString())
+	// Values are never recorded
+	assert.Contains(t, attributes["db.statement"].AsString(), "email = $1")
+	assert.NotContains(t, attributes["db.statement"].AsString(), "johnsmith")
+}
+
+func TestGormPlugin_OneSpanPerStatement(t *testing.T) {
+	// ------------------ Arrange ------------------
+	tracerProvider, recorder := newRecordingTracerProvider()
+	db := testdb.NewDryRunDB(t, tracingfx.GormPlugin{TracerProvider: tracerProvider})
+	userID := uuid.New()
+
+	// ------------------ Act ----------------------
+	db.Create(&models.User{ID: userID})
+	db.Model(&models.User{}).Where("id = ?", userID).Update("first_name", "John")
+	db.Delete(&models.User{}, userID)
+
+	// ------------------ Assert -------------------
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	assert.Equal(t, []string{"gorm.create", "gorm.update", "gorm.delete"}, names)
+}
@@ -0,0 +1,91 @@
+package tracing_unit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tracingfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestMiddleware_ContinuesCallerTrace(t *testing.T) {
+	// ------------------ Arrange ------------------
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracerProvider, recorder := newRecordingTracerProvider()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(tracingfx.Middleware(tracerProvider))
+
+	var handlerSpan trace.SpanContext
+	router.GET("/api/v1/users/:id", func(ctx *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(ctx.Request.Context())
+		ctx.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	// ------------------ Act ----------------------
+	router.ServeHTTP(w, req)
+
+	// ------------------ Assert -------------------
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "GET /api/v1/users/:id", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, int64(http.StatusInternalServerError),
+		attributesOf(span)["http.response.status_code"].AsInt64())
+}
+
+func TestTransport_ClientSpanPerRequest(t *testing.T) {
+	// ------------------ Arrange ------------------
+	tracerProvider, recorder := newRecordingTracerProvider()
+	transport := &tracingfx.Transport{
+		Base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+		TracerProvider: tracerProvider,
+		SpanName:       func(req *http.Request) string { return "storage." + req.Method },
+	}
+
+	// ------------------ Act ----------------------
+	_, okErr := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "/bucket/a.png", nil))
+	_, failedErr := transport.RoundTrip(httptest.NewRequest(http.MethodDelete, "/bucket/a.png", nil))
+
+	// ------------------ Assert -------------------
+	assert.NoError(t, okErr)
+	assert.Error(t, failedErr)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "storage.GET", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "storage.DELETE", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -0,0 +1,174 @@
+package users_unit_test
+
+import (
+	"context"
+	"testing"
+
+	configfx "github.com/TeaChanathip/touch-grass-scheduler/server/internal/config"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/internal/types"
+	authzfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/authz"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/common"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/models"
+	usersfx "github.com/TeaChanathip/touch-grass-scheduler/server/pkg/users"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/mocks"
+	"github.com/TeaChanathip/touch-grass-scheduler/server/test/unit/testdb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// openTx is a transaction that is already open, so that gorm nests every
+// transaction in it as a save point instead of connecting to begin one
+type openTx struct {
+	gorm.ConnPool
+}
+
+func (openTx) Commit() error   { return nil }
+func (openTx) Rollback() error { return nil }
+
+// newUserDB is a dry-run DB retrieving the user with the password and
+// updating exactly one row
+func newUserDB(t *testing.T, password string) *gorm.DB {
+	t.Helper()
+
+	db := testdb.NewDryRunDB(t)
+	db.Statement.ConnPool = openTx{db.Statement.ConnPool}
+
+	retrieve := func(tx *gorm.DB) {
+		if user, ok := tx.Statement.Dest.(*models.User); ok {
+			user.ID = uuid.New()
+			user.Password = password
+		}
+	}
+	update := func(tx *gorm.DB) { tx.RowsAffected = 1 }
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:retrieve", retrieve))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:update", update))
+
+	return db
+}
+
+func TestUserService_SetUserPwdByEmail(t *testing.T) {
+	testCases := []struct {
+		name            string
+		currentPassword string
+		expectedErr     error
+	}{
+		{name: "Password not set yet", currentPassword: common.UnusablePassword, expectedErr: nil},
+		{name: "Password already set", currentPassword: "$2a$12$alreadysetpasswordhash", expectedErr: common.ErrActionTokenUsed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			mockAuditService := new(mocks.MockAuditService)
+			mockAuditService.On("RecordTx", mock.Anything, mock.Anything).Return(nil)
+			service := usersfx.NewUserService(usersfx.UserServiceParams{
+				Logger:       zap.NewNop(),
+				DB:           newUserDB(t, tc.currentPassword),
+				AuditService: mockAuditService,
+			})
+
+			// ------------------ Act ----------------------
+			err := service.SetUserPwdByEmail(context.Background(), "johnsmith@gmail.com",
+				common.PasswordFingerprint(common.UnusablePassword), "12345678")
+
+			// ------------------ Assert -------------------
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				mockAuditService.AssertNumberOfCalls(t, "RecordTx", 1)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				mockAuditService.AssertNotCalled(t, "RecordTx", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserService_GetUserByEmail_CaseInsensitive(t *testing.T) {
+	// ------------------ Arrange ------------------
+	db := newUserDB(t, common.UnusablePassword)
+	var statement *gorm.Statement
+	capture := func(tx *gorm.DB) { statement = tx.Statement }
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", capture))
+	service := usersfx.NewUserService(usersfx.UserServiceParams{
+		Logger: zap.NewNop(),
+		DB:     db,
+	})
+
+	// ------------------ Act ----------------------
+	_, err := service.GetUserByEmail(context.Background(), "JohnSmith@Gmail.com")
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Contains(t, statement.SQL.String(), "LOWER(email) = $1")
+	assert.Equal(t, "johnsmith@gmail.com", statement.Vars[0])
+}
+
+func TestUserService_UpdateUserRole_RecordsTheActor(t *testing.T) {
+	// ------------------ Arrange ------------------
+	actorID, userID := uuid.New(), uuid.New()
+	mockAuditService := new(mocks.MockAuditService)
+	mockAuditService.On("RecordTx", mock.Anything, mock.Anything).Return(nil)
+	service := usersfx.NewUserService(usersfx.UserServiceParams{
+		AppConfig:    &configfx.AppConfig{JWTExpiresIn: 1},
+		Logger:       zap.NewNop(),
+		DB:           newUserDB(t, common.UnusablePassword),
+		AuditService: mockAuditService,
+	})
+
+	// ------------------ Act ----------------------
+	user, err := service.UpdateUserRole(context.Background(), actorID, userID, types.UserRoleTeacher)
+
+	// ------------------ Assert -------------------
+	require.NoError(t, err)
+	assert.Equal(t, types.UserRoleTeacher, user.Role)
+	mockAuditService.AssertCalled(t, "RecordTx", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == types.AuditActionRoleChanged &&
+			*event.ActorID == actorID && *event.TargetID == userID
+	}))
+}
+
+func TestUserService_GetUserByID_AuditsAdminReads(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		reader  *authzfx.Subject
+		audited bool
+	}{
+		{name: "Admin reads another user", reader: &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleAdmin}, audited: true},
+		{name: "School admin reads another user", reader: &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleSchoolAdmin}, audited: true},
+		{name: "Admin reads themselves", reader: &authzfx.Subject{UserID: userID, Role: types.UserRoleAdmin}, audited: false},
+		{name: "Teacher reads a student", reader: &authzfx.Subject{UserID: uuid.New(), Role: types.UserRoleTeacher}, audited: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Arrange ------------------
+			mockAuditService := new(mocks.MockAuditService)
+			mockAuditService.On("Record", mock.Anything, mock.Anything).Return()
+			service := usersfx.NewUserService(usersfx.UserServiceParams{
+				Logger:       zap.NewNop(),
+				DB:           newUserDB(t, common.UnusablePassword),
+				AuditService: mockAuditService,
+			})
+
+			// ------------------ Act ----------------------
+			_, err := service.GetUserByID(context.Background(), tc.reader, userID)
+
+			// ------------------ Assert -------------------
+			require.NoError(t, err)
+			if tc.audited {
+				mockAuditService.AssertCalled(t, "Record", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == types.AuditActionUserRead &&
+						*event.ActorID == tc.reader.UserID && *event.TargetID == userID
+				}))
+			} else {
+				mockAuditService.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package workload_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgets_Of(t *testing.T) {
+	// ------------------ Arrange ------------------
+	own, other := uuid.New(), uuid.New()
+	budgets := workload.Budgets{Default: 3, ByStudent: map[uuid.UUID]float64{own: 1.5}}
+
+	// ------------------ Act & Assert -------------
+	assert.Equal(t, 1.5, budgets.Of(own))
+	assert.Equal(t, 3.0, budgets.Of(other))
+	assert.Equal(t, 2.0, workload.Budgets{Default: 2}.Of(own))
+}
+
+func TestCalendar_IsSchoolDay(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	calendar := workload.Calendar{
+		Weekend:  []time.Weekday{time.Saturday, time.Sunday},
+		Holidays: []time.Time{time.Date(2026, 10, 23, 0, 0, 0, 0, bangkok)},
+	}
+
+	testCases := []struct {
+		name     string
+		day      time.Time
+		expected bool
+	}{
+		{"monday", time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok), true},
+		{"late on a monday", time.Date(2026, 10, 19, 23, 59, 59, 0, bangkok), true},
+		{"holiday", time.Date(2026, 10, 23, 0, 0, 0, 0, bangkok), false},
+		{"late on a holiday", time.Date(2026, 10, 23, 23, 59, 59, 0, bangkok), false},
+		{"saturday", time.Date(2026, 10, 24, 0, 0, 0, 0, bangkok), false},
+		{"sunday", time.Date(2026, 10, 25, 0, 0, 0, 0, bangkok), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			actual := calendar.IsSchoolDay(tc.day)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package workload_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		p        float64
+		expected float64
+	}{
+		{"no values", nil, 50, 0},
+		{"single value", []float64{2}, 50, 2},
+		{"odd median", []float64{5, 1, 3}, 50, 3},
+		{"even median is interpolated", []float64{4, 1, 3, 2}, 50, 2.5},
+		{"maximum", []float64{4, 1, 3, 2}, 100, 4},
+		{"minimum", []float64{4, 1, 3, 2}, 0, 1},
+		{"out of range is clamped", []float64{4, 1, 3, 2}, 150, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			actual := workload.Percentile(tc.values, tc.p)
+
+			// ------------------ Assert -------------------
+			assert.InDelta(t, tc.expected, actual, 1e-9)
+		})
+	}
+}
+
+func TestPercentile_LeavesValuesUnsorted(t *testing.T) {
+	// ------------------ Arrange ------------------
+	values := []float64{3, 1, 2}
+
+	// ------------------ Act ----------------------
+	workload.Percentile(values, 50)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, []float64{3, 1, 2}, values)
+}
+
+func TestBuildHeatmap(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok)
+	busy, free, other := uuid.New(), uuid.New(), uuid.New()
+
+	itemsByStudent := map[uuid.UUID][]workload.Item{
+		busy: {
+			{ManHours: 4, AssignedAt: &from, DueAt: time.Date(2026, 10, 19, 23, 59, 59, 0, bangkok)},
+			{ManHours: 2, AssignedAt: &from, DueAt: time.Date(2026, 10, 20, 23, 59, 59, 0, bangkok)},
+		},
+		other: {
+			{ManHours: 1, AssignedAt: &from, DueAt: time.Date(2026, 10, 19, 23, 59, 59, 0, bangkok)},
+		},
+	}
+
+	// ------------------ Act ----------------------
+	heatmap := workload.BuildHeatmap([]uuid.UUID{busy, free, other}, itemsByStudent, from, 3, workload.Budgets{Default: 3})
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, []time.Time{from, from.AddDate(0, 0, 1), from.AddDate(0, 0, 2)}, heatmap.Days)
+	assert.Equal(t, [][]float64{{5, 1, 0}, {0, 0, 0}, {1, 0, 0}}, heatmap.ManHours)
+	assert.Equal(t, []workload.DaySummary{
+		{Median: 1, Max: 5, Overloaded: 1},
+		{Median: 0, Max: 1, Overloaded: 0},
+		{Median: 0, Max: 0, Overloaded: 0},
+	}, heatmap.Summary)
+}
+
+func TestBuildHeatmap_NoStudents(t *testing.T) {
+	// ------------------ Act ----------------------
+	heatmap := workload.BuildHeatmap(nil, nil, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), 2, workload.Budgets{Default: 3})
+
+	// ------------------ Assert -------------------
+	assert.Len(t, heatmap.Days, 2)
+	assert.Empty(t, heatmap.ManHours)
+	assert.Equal(t, []workload.DaySummary{{}, {}}, heatmap.Summary)
+}
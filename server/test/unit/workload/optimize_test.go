@@ -0,0 +1,174 @@
+package workload_unit_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A week of school from Monday 2026-10-19 in Bangkok
+func weekProblem(studentIDs []uuid.UUID, itemsByStudent map[uuid.UUID][]workload.Item, manHours float64) workload.Problem {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	return workload.Problem{
+		StudentIDs:     studentIDs,
+		ItemsByStudent: itemsByStudent,
+		ManHours:       manHours,
+		From:           time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok),
+		Days:           7,
+		Budgets:        workload.Budgets{Default: 3},
+		Calendar:       workload.Calendar{Weekend: []time.Weekday{time.Saturday, time.Sunday}},
+	}
+}
+
+func TestOptimize_SpreadsLargeHomework(t *testing.T) {
+	// ------------------ Arrange ------------------
+	problem := weekProblem([]uuid.UUID{uuid.New()}, nil, 6)
+	day := func(i int) time.Time { return problem.From.AddDate(0, 0, i) }
+
+	// ------------------ Act ----------------------
+	slots := workload.Optimize(problem, 2)
+
+	// ------------------ Assert -------------------
+	require.Len(t, slots, 2)
+	assert.Equal(t, day(0), slots[0].AssignedOn)
+	assert.Equal(t, day(4), slots[0].DueOn) // Friday, from Monday
+	assert.InDelta(t, 1.2, slots[0].PeakManHours, 1e-9)
+	assert.Equal(t, day(0), slots[1].AssignedOn)
+	assert.Equal(t, day(3), slots[1].DueOn)
+	assert.InDelta(t, 1.5, slots[1].PeakManHours, 1e-9)
+}
+
+func TestOptimize_RespectsBudgets(t *testing.T) {
+	// ------------------ Arrange ------------------
+	busy, free := uuid.New(), uuid.New()
+	problem := weekProblem([]uuid.UUID{busy, free}, nil, 1)
+	day := func(i int) time.Time { return problem.From.AddDate(0, 0, i) }
+
+	// busy spends the budget on Wednesday
+	problem.ItemsByStudent = map[uuid.UUID][]workload.Item{
+		busy: {{ManHours: 3, AssignedAt: ptr(day(2)), DueAt: day(2).Add(23 * time.Hour)}},
+	}
+
+	// ------------------ Act ----------------------
+	slots := workload.Optimize(problem, 10)
+
+	// ------------------ Assert -------------------
+	windows := [][2]time.Time{}
+	for _, slot := range slots {
+		windows = append(windows, [2]time.Time{slot.AssignedOn, slot.DueOn})
+	}
+	assert.Equal(t, [][2]time.Time{
+		{day(0), day(1)}, // Half an hour a day, before Wednesday
+		{day(3), day(4)}, // The same, after Wednesday
+		{day(0), day(0)},
+		{day(3), day(3)},
+		{day(0), day(2)}, // Pushes busy over the budget, the least
+	}, windows)
+	assert.Equal(t, 0, slots[0].PushedOverBudget)
+	assert.InDelta(t, 3, slots[0].PeakManHours, 1e-9)
+	assert.Equal(t, 1, slots[4].PushedOverBudget)
+}
+
+func TestOptimize_OwnBudget(t *testing.T) {
+	// ------------------ Arrange ------------------
+	strict := uuid.New()
+	problem := weekProblem([]uuid.UUID{strict}, nil, 2)
+	problem.Days = 1
+	problem.Budgets.ByStudent = map[uuid.UUID]float64{strict: 1}
+
+	// ------------------ Act ----------------------
+	slots := workload.Optimize(problem, 1)
+
+	// ------------------ Assert -------------------
+	require.Len(t, slots, 1)
+	assert.Equal(t, 1, slots[0].PushedOverBudget)
+}
+
+func TestOptimize_SkipsHolidays(t *testing.T) {
+	// ------------------ Arrange ------------------
+	problem := weekProblem([]uuid.UUID{uuid.New()}, nil, 2)
+	day := func(i int) time.Time { return problem.From.AddDate(0, 0, i) }
+	problem.Calendar.Holidays = []time.Time{day(0), day(4)}
+
+	// ------------------ Act ----------------------
+	slots := workload.Optimize(problem, 10)
+
+	// ------------------ Assert -------------------
+	require.Len(t, slots, 3)
+	for _, slot := range slots {
+		assert.True(t, problem.Calendar.IsSchoolDay(slot.AssignedOn), slot.AssignedOn)
+		assert.True(t, problem.Calendar.IsSchoolDay(slot.DueOn), slot.DueOn)
+	}
+	assert.Equal(t, day(1), slots[0].AssignedOn)
+	assert.Equal(t, day(3), slots[0].DueOn)
+}
+
+func TestOptimize_NoSchoolDay(t *testing.T) {
+	// ------------------ Arrange ------------------
+	problem := weekProblem([]uuid.UUID{uuid.New()}, nil, 2)
+	problem.From = problem.From.AddDate(0, 0, 5) // Saturday
+	problem.Days = 2
+
+	// ------------------ Act ----------------------
+	slots := workload.Optimize(problem, 5)
+
+	// ------------------ Assert -------------------
+	assert.Empty(t, slots)
+}
+
+func TestOptimize_Deterministic(t *testing.T) {
+	// ------------------ Arrange ------------------
+	problem := classProblem(50, 100, 14)
+
+	// ------------------ Act ----------------------
+	first := workload.Optimize(problem, 5)
+	second := workload.Optimize(problem, 5)
+
+	// ------------------ Assert -------------------
+	require.Len(t, first, 5)
+	assert.Equal(t, first, second)
+}
+
+func BenchmarkOptimize(b *testing.B) {
+	for _, days := range []int{7, 14, 31} {
+		problem := classProblem(50, 100, days)
+
+		b.Run(fmt.Sprintf("%d days", days), func(b *testing.B) {
+			for b.Loop() {
+				workload.Optimize(problem, 5)
+			}
+		})
+	}
+}
+
+// classProblem is a class of students, all of them with the same outstanding
+// assignments, due in the 31 days from Monday 2026-10-19
+func classProblem(students, assignments, days int) workload.Problem {
+	random := rand.New(rand.NewPCG(1, 2))
+	problem := weekProblem(make([]uuid.UUID, students), map[uuid.UUID][]workload.Item{}, 2.5)
+	problem.Days = days
+
+	items := make([]workload.Item, assignments)
+	for i := range items {
+		assignedAt := problem.From.AddDate(0, 0, random.IntN(21)-7)
+		items[i] = workload.Item{
+			ManHours:   float64(random.IntN(12)+1) / 4,
+			AssignedAt: &assignedAt,
+			DueAt:      assignedAt.AddDate(0, 0, random.IntN(10)+1),
+		}
+	}
+
+	for s := range problem.StudentIDs {
+		problem.StudentIDs[s] = uuid.NewSHA1(uuid.Nil, []byte{byte(s)})
+		problem.ItemsByStudent[problem.StudentIDs[s]] = items
+	}
+	problem.Budgets.ByStudent = map[uuid.UUID]float64{problem.StudentIDs[0]: 1.5}
+
+	return problem
+}
@@ -0,0 +1,134 @@
+package workload_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestPushedOverBudget(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok)
+	dueToday := time.Date(2026, 10, 19, 23, 59, 59, 0, bangkok)
+	pushed, alreadyOver, untouched := uuid.New(), uuid.New(), uuid.New()
+
+	before := map[uuid.UUID][]workload.Item{
+		pushed:      {{ManHours: 2, DueAt: dueToday}},
+		alreadyOver: {{ManHours: 4, DueAt: dueToday}},
+		untouched:   {{ManHours: 1, DueAt: dueToday}},
+	}
+	after := map[uuid.UUID][]workload.Item{
+		pushed:      {{ManHours: 2, DueAt: dueToday}, {ManHours: 2, DueAt: dueToday}},
+		alreadyOver: {{ManHours: 4, DueAt: dueToday}, {ManHours: 2, DueAt: dueToday}},
+		untouched:   {{ManHours: 1, DueAt: dueToday}},
+	}
+
+	// ------------------ Act ----------------------
+	actual := workload.PushedOverBudget([]uuid.UUID{untouched, alreadyOver, pushed}, before, after, from, 7, workload.Budgets{Default: 3})
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, []uuid.UUID{pushed}, actual)
+}
+
+func TestPushedOverBudget_OwnBudget(t *testing.T) {
+	// ------------------ Arrange ------------------
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok)
+	dueToday := time.Date(2026, 10, 19, 23, 59, 59, 0, bangkok)
+	relaxed, strict := uuid.New(), uuid.New()
+
+	before := map[uuid.UUID][]workload.Item{}
+	after := map[uuid.UUID][]workload.Item{
+		relaxed: {{ManHours: 2, DueAt: dueToday}},
+		strict:  {{ManHours: 2, DueAt: dueToday}},
+	}
+	budgets := workload.Budgets{Default: 3, ByStudent: map[uuid.UUID]float64{strict: 1.5}}
+
+	// ------------------ Act ----------------------
+	actual := workload.PushedOverBudget([]uuid.UUID{relaxed, strict}, before, after, from, 7, budgets)
+
+	// ------------------ Assert -------------------
+	assert.Equal(t, []uuid.UUID{strict}, actual)
+}
+
+func TestAlternativeDueDays(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok)
+	day := func(i int) time.Time { return from.AddDate(0, 0, i) }
+	busy, free := uuid.New(), uuid.New()
+
+	// busy already has 3 man-hours on the third day, the budget
+	itemsByStudent := map[uuid.UUID][]workload.Item{
+		busy: {{ManHours: 3, AssignedAt: ptr(day(2)), DueAt: day(2).Add(23 * time.Hour)}},
+	}
+	studentIDs := []uuid.UUID{busy, free}
+
+	testCases := []struct {
+		name       string
+		studentIDs []uuid.UUID
+		item       workload.Item
+		calendar   workload.Calendar
+		limit      int
+		expected   []time.Time
+	}{
+		{
+			name:       "days not spreading over the busy day",
+			studentIDs: studentIDs,
+			item:       workload.Item{ManHours: 1, DueAt: day(2)},
+			limit:      5,
+			expected:   []time.Time{day(1), day(0)},
+		},
+		{
+			name:       "limit",
+			studentIDs: studentIDs,
+			item:       workload.Item{ManHours: 1, DueAt: day(2)},
+			limit:      1,
+			expected:   []time.Time{day(1)},
+		},
+		{
+			name:       "not before the assignment",
+			studentIDs: studentIDs,
+			item:       workload.Item{ManHours: 1, AssignedAt: ptr(day(1)), DueAt: day(2)},
+			limit:      5,
+			expected:   []time.Time{day(1)},
+		},
+		{
+			name:       "closest first, earlier on a tie",
+			studentIDs: []uuid.UUID{free},
+			item:       workload.Item{ManHours: 1, DueAt: day(3)},
+			limit:      3,
+			expected:   []time.Time{day(2), day(4), day(1)},
+		},
+		{
+			name:       "school days only",
+			studentIDs: []uuid.UUID{free},
+			item:       workload.Item{ManHours: 1, DueAt: day(4)},
+			calendar: workload.Calendar{
+				Weekend:  []time.Weekday{time.Saturday, time.Sunday},
+				Holidays: []time.Time{day(3)},
+			},
+			limit:    3,
+			expected: []time.Time{day(2), day(1), day(0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ------------------ Act ----------------------
+			actual := workload.AlternativeDueDays(
+				tc.studentIDs, itemsByStudent, tc.item, from, 7, workload.Budgets{Default: 3}, tc.calendar, tc.limit,
+			)
+
+			// ------------------ Assert -------------------
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
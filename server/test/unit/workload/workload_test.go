@@ -0,0 +1,87 @@
+package workload_unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TeaChanathip/touch-grass-scheduler/server/pkg/workload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyLoad_SpreadsManHoursUntilDueDate(t *testing.T) {
+	// ------------------ Arrange ------------------
+	from := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	assignedAt := from.AddDate(0, 0, -2)
+	items := []workload.Item{
+		{HomeworkName: "Math", ManHours: 4, AssignedAt: &assignedAt, DueAt: from.AddDate(0, 0, 1)},
+		{HomeworkName: "Essay", ManHours: 2, DueAt: from.AddDate(0, 0, 3)},
+	}
+
+	// ------------------ Act ----------------------
+	days := workload.DailyLoad(items, from, 7, 3)
+
+	// ------------------ Assert -------------------
+	assert.Len(t, days, 7)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), days[0].Date)
+	assert.InDelta(t, 2.5, days[0].ManHours, 1e-9)
+	assert.InDelta(t, 2.5, days[1].ManHours, 1e-9)
+	assert.InDelta(t, 0.5, days[2].ManHours, 1e-9)
+	assert.InDelta(t, 0.5, days[3].ManHours, 1e-9)
+	assert.Zero(t, days[4].ManHours)
+
+	assert.Empty(t, days[0].Due)
+	assert.Len(t, days[1].Due, 1)
+	assert.Equal(t, "Math", days[1].Due[0].HomeworkName)
+	assert.Len(t, days[3].Due, 1)
+	assert.Equal(t, "Essay", days[3].Due[0].HomeworkName)
+}
+
+func TestDailyLoad_FlagsOverloadedDays(t *testing.T) {
+	// ------------------ Arrange ------------------
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	items := []workload.Item{
+		{HomeworkName: "Project", ManHours: 5, AssignedAt: &from, DueAt: from},
+	}
+
+	// ------------------ Act ----------------------
+	days := workload.DailyLoad(items, from, 2, 3)
+
+	// ------------------ Assert -------------------
+	assert.True(t, days[0].Overloaded)
+	assert.False(t, days[1].Overloaded)
+}
+
+func TestDailyLoad_IgnoresDaysOutsideWindow(t *testing.T) {
+	// ------------------ Arrange ------------------
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	assignedAt := from.AddDate(0, 0, 1)
+	items := []workload.Item{
+		// 10 days of 1 man-hour, only the first 2 fall in the window
+		{HomeworkName: "Reading", ManHours: 10, AssignedAt: &assignedAt, DueAt: from.AddDate(0, 0, 10)},
+	}
+
+	// ------------------ Act ----------------------
+	days := workload.DailyLoad(items, from, 3, 3)
+
+	// ------------------ Assert -------------------
+	assert.Zero(t, days[0].ManHours)
+	assert.InDelta(t, 1, days[1].ManHours, 1e-9)
+	assert.InDelta(t, 1, days[2].ManHours, 1e-9)
+	for _, day := range days {
+		assert.Empty(t, day.Due)
+	}
+}
+
+func TestDaysBetween_AcrossDST(t *testing.T) {
+	// ------------------ Arrange ------------------
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	// DST ends on 2026-10-25 in Europe
+	a := time.Date(2026, 10, 24, 0, 0, 0, 0, loc)
+	b := time.Date(2026, 10, 26, 0, 0, 0, 0, loc)
+
+	// ------------------ Act & Assert -------------
+	assert.Equal(t, 2, workload.DaysBetween(a, b))
+	assert.Equal(t, -2, workload.DaysBetween(b, a))
+}